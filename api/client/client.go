@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	pb "github.com/textileio/textile/api/pb"
+	"github.com/textileio/textile/tracing"
 	"google.golang.org/grpc"
 )
 
@@ -15,6 +16,17 @@ type Auth struct {
 	Scope string // user or team ID
 }
 
+// TeamInfo holds a team's editable fields.
+type TeamInfo struct {
+	Name        string
+	Description string
+	Website     string
+	// Avatar replaces the team avatar if not empty. It must be a PNG, JPEG, GIF, or WebP image.
+	Avatar []byte
+	// RemoveAvatar removes the team avatar.
+	RemoveAvatar bool
+}
+
 // Client provides the client api.
 type Client struct {
 	c    pb.APIClient
@@ -32,6 +44,7 @@ func NewClient(target string, creds credentials.TransportCredentials) (*Client,
 		opts = append(opts, grpc.WithInsecure())
 	}
 	opts = append(opts, grpc.WithPerRPCCredentials(auth))
+	opts = append(opts, tracing.DialOptions()...)
 	conn, err := grpc.Dial(target, opts...)
 	if err != nil {
 		return nil, err
@@ -71,6 +84,23 @@ func (c *Client) Whoami(ctx context.Context, auth Auth) (*pb.WhoamiReply, error)
 	return c.c.Whoami(authCtx(ctx, auth), &pb.WhoamiRequest{})
 }
 
+// GetProfile returns the profile of the current user.
+func (c *Client) GetProfile(ctx context.Context, auth Auth) (*pb.GetProfileReply, error) {
+	return c.c.GetProfile(authCtx(ctx, auth), &pb.GetProfileRequest{})
+}
+
+// UpdateProfile replaces the display name and username of the current user.
+// If email is not empty and differs from the current address, a confirmation
+// link is sent to it, and the call blocks until the link is followed.
+func (c *Client) UpdateProfile(ctx context.Context, name, username, email string, auth Auth) error {
+	_, err := c.c.UpdateProfile(authCtx(ctx, auth), &pb.UpdateProfileRequest{
+		Name:     name,
+		Username: username,
+		Email:    email,
+	})
+	return err
+}
+
 // AddTeam add a new team.
 func (c *Client) AddTeam(ctx context.Context, name string, auth Auth) (*pb.AddTeamReply, error) {
 	return c.c.AddTeam(authCtx(ctx, auth), &pb.AddTeamRequest{Name: name})
@@ -88,6 +118,18 @@ func (c *Client) ListTeams(ctx context.Context, auth Auth) (*pb.ListTeamsReply,
 	return c.c.ListTeams(authCtx(ctx, auth), &pb.ListTeamsRequest{})
 }
 
+// UpdateTeam replaces a team's name, description, and website, and updates its avatar.
+func (c *Client) UpdateTeam(ctx context.Context, teamID string, info TeamInfo, auth Auth) (*pb.UpdateTeamReply, error) {
+	return c.c.UpdateTeam(authCtx(ctx, auth), &pb.UpdateTeamRequest{
+		ID:           teamID,
+		Name:         info.Name,
+		Description:  info.Description,
+		Website:      info.Website,
+		Avatar:       info.Avatar,
+		RemoveAvatar: info.RemoveAvatar,
+	})
+}
+
 // RemoveTeam removes a team by ID.
 func (c *Client) RemoveTeam(ctx context.Context, teamID string, auth Auth) error {
 	_, err := c.c.RemoveTeam(authCtx(ctx, auth), &pb.RemoveTeamRequest{
@@ -131,6 +173,15 @@ func (c *Client) ListProjects(ctx context.Context, auth Auth) (*pb.ListProjectsR
 	return c.c.ListProjects(authCtx(ctx, auth), &pb.ListProjectsRequest{})
 }
 
+// UpdateProject renames a project by ID.
+func (c *Client) UpdateProject(ctx context.Context, projID, name string, auth Auth) error {
+	_, err := c.c.UpdateProject(authCtx(ctx, auth), &pb.UpdateProjectRequest{
+		ID:   projID,
+		Name: name,
+	})
+	return err
+}
+
 // RemoveProject removes a project by ID.
 func (c *Client) RemoveProject(ctx context.Context, projID string, auth Auth) error {
 	_, err := c.c.RemoveProject(authCtx(ctx, auth), &pb.RemoveProjectRequest{
@@ -140,9 +191,11 @@ func (c *Client) RemoveProject(ctx context.Context, projID string, auth Auth) er
 }
 
 // AddAppToken add a new app token under the given project.
-func (c *Client) AddAppToken(ctx context.Context, projID string, auth Auth) (*pb.AddAppTokenReply, error) {
+// Browsers may only register app users with the token from allowedOrigins if any are given.
+func (c *Client) AddAppToken(ctx context.Context, projID string, allowedOrigins []string, auth Auth) (*pb.AddAppTokenReply, error) {
 	return c.c.AddAppToken(authCtx(ctx, auth), &pb.AddAppTokenRequest{
-		ProjectID: projID,
+		ProjectID:      projID,
+		AllowedOrigins: allowedOrigins,
 	})
 }
 
@@ -161,6 +214,65 @@ func (c *Client) RemoveAppToken(ctx context.Context, tokenID string, auth Auth)
 	return err
 }
 
+// ListAppUsers returns a list of app users for a project.
+func (c *Client) ListAppUsers(ctx context.Context, projID string, auth Auth) (*pb.ListAppUsersReply, error) {
+	return c.c.ListAppUsers(authCtx(ctx, auth), &pb.ListAppUsersRequest{
+		ProjectID: projID,
+	})
+}
+
+// RemoveAppUser removes an app user and its sessions by ID.
+func (c *Client) RemoveAppUser(ctx context.Context, userID string, auth Auth) error {
+	_, err := c.c.RemoveAppUser(authCtx(ctx, auth), &pb.RemoveAppUserRequest{
+		ID: userID,
+	})
+	return err
+}
+
+// AddWebhook adds a webhook to a project that receives events, e.g., "project.remove",
+// posted to url. Deliveries are signed with secret, which is generated if empty.
+func (c *Client) AddWebhook(ctx context.Context, projID, url, secret string, events []string, auth Auth) (*pb.AddWebhookReply, error) {
+	return c.c.AddWebhook(authCtx(ctx, auth), &pb.AddWebhookRequest{
+		ProjectID: projID,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+	})
+}
+
+// ListWebhooks returns a list of webhooks for a project.
+func (c *Client) ListWebhooks(ctx context.Context, projID string, auth Auth) (*pb.ListWebhooksReply, error) {
+	return c.c.ListWebhooks(authCtx(ctx, auth), &pb.ListWebhooksRequest{
+		ProjectID: projID,
+	})
+}
+
+// RemoveWebhook removes a webhook by ID.
+func (c *Client) RemoveWebhook(ctx context.Context, hookID string, auth Auth) error {
+	_, err := c.c.RemoveWebhook(authCtx(ctx, auth), &pb.RemoveWebhookRequest{
+		ID: hookID,
+	})
+	return err
+}
+
+// TestWebhook sends a ping event to a webhook. It returns an error unless
+// the webhook responds with a 2xx status.
+func (c *Client) TestWebhook(ctx context.Context, hookID string, auth Auth) error {
+	_, err := c.c.TestWebhook(authCtx(ctx, auth), &pb.TestWebhookRequest{
+		ID: hookID,
+	})
+	return err
+}
+
+// ListAuditEvents returns the audit events of the current scope created in [since, until),
+// given as Unix timestamps. A zero bound leaves that end of the range open.
+func (c *Client) ListAuditEvents(ctx context.Context, since, until int64, auth Auth) (*pb.ListAuditEventsReply, error) {
+	return c.c.ListAuditEvents(authCtx(ctx, auth), &pb.ListAuditEventsRequest{
+		Since: since,
+		Until: until,
+	})
+}
+
 type tokenAuth struct {
 	secure bool
 }
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -19,6 +20,9 @@ import (
 	"github.com/textileio/textile/api/pb"
 	"github.com/textileio/textile/core"
 	"github.com/textileio/textile/util"
+	"github.com/textileio/textile/webhooks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -36,6 +40,14 @@ func TestClient_Login(t *testing.T) {
 			t.Fatal("got empty token from login")
 		}
 	})
+
+	t.Run("test login with unnormalized email", func(t *testing.T) {
+		user := login(t, client, conf, "jon@doe.com")
+		user2 := login(t, client, conf, " Jon@Doe.com ")
+		if user2.ID != user.ID {
+			t.Fatal("got different users for the same email")
+		}
+	})
 }
 
 func TestClient_Switch(t *testing.T) {
@@ -133,6 +145,56 @@ func TestClient_Whoami(t *testing.T) {
 	})
 }
 
+func TestClient_UpdateProfile(t *testing.T) {
+	t.Parallel()
+	conf, client, done := setup(t)
+	defer done()
+
+	user := login(t, client, conf, "jon@doe.com")
+
+	t.Run("test update profile", func(t *testing.T) {
+		if err := client.UpdateProfile(context.Background(), "Jon Doe", "jon", "",
+			Auth{Token: user.SessionID}); err != nil {
+			t.Fatalf("update profile should succeed: %v", err)
+		}
+		profile, err := client.GetProfile(context.Background(), Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if profile.Name != "Jon Doe" || profile.Username != "jon" {
+			t.Fatalf("got wrong profile: %v", profile)
+		}
+	})
+
+	t.Run("test change email", func(t *testing.T) {
+		var err error
+		go func() {
+			err = client.UpdateProfile(context.Background(), "Jon Doe", "jon", "jon@doe.org",
+				Auth{Token: user.SessionID})
+		}()
+
+		// Ensure update request has processed
+		time.Sleep(time.Second)
+		url := fmt.Sprintf("%s/confirm/%s", conf.AddrGatewayUrl, sessionSecret)
+		if _, err := http.Get(url); err != nil {
+			t.Fatal(err)
+		}
+
+		// Ensure update response has been received
+		time.Sleep(time.Second)
+		if err != nil {
+			t.Fatalf("change email should succeed: %v", err)
+		}
+		profile, err := client.GetProfile(context.Background(), Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if profile.Email != "jon@doe.org" {
+			t.Fatalf("got wrong email %s, expected jon@doe.org", profile.Email)
+		}
+	})
+}
+
 func TestClient_AddTeam(t *testing.T) {
 	t.Parallel()
 	conf, client, done := setup(t)
@@ -185,6 +247,45 @@ func TestClient_GetTeam(t *testing.T) {
 	})
 }
 
+func TestClient_UpdateTeam(t *testing.T) {
+	t.Parallel()
+	conf, client, done := setup(t)
+	defer done()
+
+	user := login(t, client, conf, "jon@doe.com")
+	team, err := client.AddTeam(context.Background(), "foo", Auth{Token: user.SessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AddTeam(context.Background(), "bar", Auth{Token: user.SessionID}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test update team with taken name", func(t *testing.T) {
+		if _, err := client.UpdateTeam(context.Background(), team.ID, TeamInfo{Name: "bar"},
+			Auth{Token: user.SessionID}); err == nil {
+			t.Fatal("update team with taken name should fail")
+		}
+	})
+
+	t.Run("test update team", func(t *testing.T) {
+		if _, err := client.UpdateTeam(context.Background(), team.ID, TeamInfo{
+			Name:        "baz",
+			Description: "Baz team",
+			Website:     "https://baz.com",
+		}, Auth{Token: user.SessionID}); err != nil {
+			t.Fatalf("update team should succeed: %v", err)
+		}
+		got, err := client.GetTeam(context.Background(), team.ID, Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != "baz" || got.Description != "Baz team" || got.Website != "https://baz.com" {
+			t.Fatalf("got wrong team fields from get team: %v", got)
+		}
+	})
+}
+
 func TestClient_ListTeams(t *testing.T) {
 	t.Parallel()
 	conf, client, done := setup(t)
@@ -398,6 +499,32 @@ func TestClient_GetProject(t *testing.T) {
 	})
 }
 
+func TestClient_UpdateProject(t *testing.T) {
+	t.Parallel()
+	conf, client, done := setup(t)
+	defer done()
+
+	user := login(t, client, conf, "jon@doe.com")
+	project, err := client.AddProject(context.Background(), "foo", Auth{Token: user.SessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test rename project", func(t *testing.T) {
+		if err := client.UpdateProject(context.Background(), project.ID, "bar",
+			Auth{Token: user.SessionID}); err != nil {
+			t.Fatalf("rename project should succeed: %v", err)
+		}
+		got, err := client.GetProject(context.Background(), project.ID, Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != "bar" {
+			t.Fatalf("got wrong name from get project, expected %s, got %s", "bar", got.Name)
+		}
+	})
+}
+
 func TestClient_ListProjects(t *testing.T) {
 	t.Parallel()
 	conf, client, done := setup(t)
@@ -507,7 +634,7 @@ func TestClient_AddAppToken(t *testing.T) {
 	}
 
 	t.Run("test add app token", func(t *testing.T) {
-		token, err := client.AddAppToken(context.Background(), project.ID, Auth{Token: user.SessionID})
+		token, err := client.AddAppToken(context.Background(), project.ID, nil, Auth{Token: user.SessionID})
 		if err != nil {
 			t.Fatalf("add app token should succeed: %v", err)
 		}
@@ -539,10 +666,10 @@ func TestClient_ListAppTokens(t *testing.T) {
 		}
 	})
 
-	if _, err := client.AddAppToken(context.Background(), project.ID, Auth{Token: user.SessionID}); err != nil {
+	if _, err := client.AddAppToken(context.Background(), project.ID, nil, Auth{Token: user.SessionID}); err != nil {
 		t.Fatal(err)
 	}
-	if _, err := client.AddAppToken(context.Background(), project.ID, Auth{Token: user.SessionID}); err != nil {
+	if _, err := client.AddAppToken(context.Background(), project.ID, nil, Auth{Token: user.SessionID}); err != nil {
 		t.Fatal(err)
 	}
 
@@ -580,7 +707,7 @@ func TestClient_RemoveAppToken(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	token, err := client.AddAppToken(context.Background(), project.ID, Auth{Token: user.SessionID})
+	token, err := client.AddAppToken(context.Background(), project.ID, nil, Auth{Token: user.SessionID})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -654,7 +781,7 @@ func TestClient_RegisterAppUser(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	token, err := client.AddAppToken(context.Background(), project.ID, Auth{Token: user.SessionID})
+	token, err := client.AddAppToken(context.Background(), project.ID, nil, Auth{Token: user.SessionID})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -703,6 +830,116 @@ func TestClient_RegisterAppUser(t *testing.T) {
 	})
 }
 
+func TestClient_Webhooks(t *testing.T) {
+	t.Parallel()
+	conf, client, done := setup(t)
+	defer done()
+
+	received := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+	}))
+	defer server.Close()
+
+	user := login(t, client, conf, "jon@doe.com")
+	project, err := client.AddProject(context.Background(), "foo", Auth{Token: user.SessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hook *pb.AddWebhookReply
+	t.Run("test add webhook", func(t *testing.T) {
+		hook, err = client.AddWebhook(context.Background(), project.ID, server.URL, "secret",
+			[]string{"project.remove"}, Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatalf("add webhook should succeed: %v", err)
+		}
+		if hook.Secret != "secret" {
+			t.Fatal("add webhook should keep the given secret")
+		}
+	})
+
+	t.Run("test list webhooks", func(t *testing.T) {
+		hooks, err := client.ListWebhooks(context.Background(), project.ID, Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatalf("list webhooks should succeed: %v", err)
+		}
+		if len(hooks.List) != 1 || hooks.List[0].URL != server.URL {
+			t.Fatal("got bad webhooks from list webhooks")
+		}
+	})
+
+	t.Run("test test webhook", func(t *testing.T) {
+		if err := client.TestWebhook(context.Background(), hook.ID, Auth{Token: user.SessionID}); err != nil {
+			t.Fatalf("test webhook should succeed: %v", err)
+		}
+		r := <-received
+		if r.Header.Get("X-Textile-Event") != "ping" {
+			t.Fatal("test webhook should send a ping event")
+		}
+	})
+
+	t.Run("test remove webhook", func(t *testing.T) {
+		if err := client.RemoveWebhook(context.Background(), hook.ID, Auth{Token: user.SessionID}); err != nil {
+			t.Fatalf("remove webhook should succeed: %v", err)
+		}
+		hooks, err := client.ListWebhooks(context.Background(), project.ID, Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hooks.List) != 0 {
+			t.Fatal("webhook should be removed")
+		}
+	})
+
+	t.Run("test missing webhook", func(t *testing.T) {
+		if err := client.TestWebhook(context.Background(), hook.ID, Auth{Token: user.SessionID}); status.Code(err) != codes.NotFound {
+			t.Fatalf("test missing webhook should fail with NotFound, got: %v", err)
+		}
+		if err := client.RemoveWebhook(context.Background(), hook.ID, Auth{Token: user.SessionID}); status.Code(err) != codes.NotFound {
+			t.Fatalf("remove missing webhook should fail with NotFound, got: %v", err)
+		}
+	})
+}
+
+func TestClient_ListAuditEvents(t *testing.T) {
+	t.Parallel()
+	conf, client, done := setup(t)
+	defer done()
+
+	user := login(t, client, conf, "jon@doe.com")
+	project, err := client.AddProject(context.Background(), "foo", Auth{Token: user.SessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test list audit events", func(t *testing.T) {
+		events, err := client.ListAuditEvents(context.Background(), 0, 0, Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatalf("list audit events should succeed: %v", err)
+		}
+		var found bool
+		for _, e := range events.List {
+			if e.Action == "project.add" && e.TargetID == project.ID && e.ActorID == user.ID {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatal("add project should be audited")
+		}
+	})
+
+	t.Run("test list audit events before they happened", func(t *testing.T) {
+		events, err := client.ListAuditEvents(context.Background(), 0, 1, Auth{Token: user.SessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events.List) != 0 {
+			t.Fatal("got events outside the time range")
+		}
+	})
+}
+
 func TestClose(t *testing.T) {
 	t.Parallel()
 	conf, shutdown := makeTextile(t)
@@ -789,6 +1026,9 @@ func makeTextile(t *testing.T) (conf core.Config, shutdown func()) {
 		SessionSecret:        sessionSecret,
 		ThreadsInternalToken: uuid.New().String(),
 
+		// Test webhooks are served on loopback addresses.
+		Webhooks: webhooks.Config{AllowPrivateAddrs: true},
+
 		Debug: true,
 
 		// @todo: When we have a lotus docker image,
@@ -31,7 +31,7 @@ func TestThreadsServiceClient_CreateThread(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	token, err := client.AddAppToken(context.Background(), project.ID, Auth{Token: user.SessionID})
+	token, err := client.AddAppToken(context.Background(), project.ID, nil, Auth{Token: user.SessionID})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -270,6 +270,9 @@ type WhoamiReply struct {
 	Email                string   `protobuf:"bytes,2,opt,name=Email,proto3" json:"Email,omitempty"`
 	TeamID               string   `protobuf:"bytes,3,opt,name=teamID,proto3" json:"teamID,omitempty"`
 	TeamName             string   `protobuf:"bytes,4,opt,name=teamName,proto3" json:"teamName,omitempty"`
+	TeamDescription      string   `protobuf:"bytes,5,opt,name=teamDescription,proto3" json:"teamDescription,omitempty"`
+	TeamWebsite          string   `protobuf:"bytes,6,opt,name=teamWebsite,proto3" json:"teamWebsite,omitempty"`
+	TeamAvatar           string   `protobuf:"bytes,7,opt,name=teamAvatar,proto3" json:"teamAvatar,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -328,6 +331,215 @@ func (m *WhoamiReply) GetTeamName() string {
 	return ""
 }
 
+func (m *WhoamiReply) GetTeamDescription() string {
+	if m != nil {
+		return m.TeamDescription
+	}
+	return ""
+}
+
+func (m *WhoamiReply) GetTeamWebsite() string {
+	if m != nil {
+		return m.TeamWebsite
+	}
+	return ""
+}
+
+func (m *WhoamiReply) GetTeamAvatar() string {
+	if m != nil {
+		return m.TeamAvatar
+	}
+	return ""
+}
+
+type GetProfileRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetProfileRequest) Reset()         { *m = GetProfileRequest{} }
+func (m *GetProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProfileRequest) ProtoMessage()    {}
+func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+}
+
+func (m *GetProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProfileRequest.Unmarshal(m, b)
+}
+func (m *GetProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *GetProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProfileRequest.Merge(m, src)
+}
+func (m *GetProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_GetProfileRequest.Size(m)
+}
+func (m *GetProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProfileRequest proto.InternalMessageInfo
+
+type GetProfileReply struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Username             string   `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Created              int64    `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetProfileReply) Reset()         { *m = GetProfileReply{} }
+func (m *GetProfileReply) String() string { return proto.CompactTextString(m) }
+func (*GetProfileReply) ProtoMessage()    {}
+func (*GetProfileReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+}
+
+func (m *GetProfileReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProfileReply.Unmarshal(m, b)
+}
+func (m *GetProfileReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProfileReply.Marshal(b, m, deterministic)
+}
+func (m *GetProfileReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProfileReply.Merge(m, src)
+}
+func (m *GetProfileReply) XXX_Size() int {
+	return xxx_messageInfo_GetProfileReply.Size(m)
+}
+func (m *GetProfileReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProfileReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetProfileReply proto.InternalMessageInfo
+
+func (m *GetProfileReply) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *GetProfileReply) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *GetProfileReply) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetProfileReply) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *GetProfileReply) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type UpdateProfileRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Username             string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email                string   `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateProfileRequest) Reset()         { *m = UpdateProfileRequest{} }
+func (m *UpdateProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateProfileRequest) ProtoMessage()    {}
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+}
+
+func (m *UpdateProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProfileRequest.Unmarshal(m, b)
+}
+func (m *UpdateProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProfileRequest.Merge(m, src)
+}
+func (m *UpdateProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateProfileRequest.Size(m)
+}
+func (m *UpdateProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateProfileRequest proto.InternalMessageInfo
+
+func (m *UpdateProfileRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateProfileRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *UpdateProfileRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+type UpdateProfileReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateProfileReply) Reset()         { *m = UpdateProfileReply{} }
+func (m *UpdateProfileReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateProfileReply) ProtoMessage()    {}
+func (*UpdateProfileReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+
+func (m *UpdateProfileReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProfileReply.Unmarshal(m, b)
+}
+func (m *UpdateProfileReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProfileReply.Marshal(b, m, deterministic)
+}
+func (m *UpdateProfileReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProfileReply.Merge(m, src)
+}
+func (m *UpdateProfileReply) XXX_Size() int {
+	return xxx_messageInfo_UpdateProfileReply.Size(m)
+}
+func (m *UpdateProfileReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProfileReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateProfileReply proto.InternalMessageInfo
+
 type AddTeamRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -339,7 +551,7 @@ func (m *AddTeamRequest) Reset()         { *m = AddTeamRequest{} }
 func (m *AddTeamRequest) String() string { return proto.CompactTextString(m) }
 func (*AddTeamRequest) ProtoMessage()    {}
 func (*AddTeamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
 }
 
 func (m *AddTeamRequest) XXX_Unmarshal(b []byte) error {
@@ -378,7 +590,7 @@ func (m *AddTeamReply) Reset()         { *m = AddTeamReply{} }
 func (m *AddTeamReply) String() string { return proto.CompactTextString(m) }
 func (*AddTeamReply) ProtoMessage()    {}
 func (*AddTeamReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 
 func (m *AddTeamReply) XXX_Unmarshal(b []byte) error {
@@ -417,7 +629,7 @@ func (m *GetTeamRequest) Reset()         { *m = GetTeamRequest{} }
 func (m *GetTeamRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTeamRequest) ProtoMessage()    {}
 func (*GetTeamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
 }
 
 func (m *GetTeamRequest) XXX_Unmarshal(b []byte) error {
@@ -451,6 +663,9 @@ type GetTeamReply struct {
 	Name                 string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 	Created              int64                  `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
 	Members              []*GetTeamReply_Member `protobuf:"bytes,5,rep,name=members,proto3" json:"members,omitempty"`
+	Description          string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Website              string                 `protobuf:"bytes,7,opt,name=website,proto3" json:"website,omitempty"`
+	Avatar               string                 `protobuf:"bytes,8,opt,name=avatar,proto3" json:"avatar,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
@@ -460,7 +675,7 @@ func (m *GetTeamReply) Reset()         { *m = GetTeamReply{} }
 func (m *GetTeamReply) String() string { return proto.CompactTextString(m) }
 func (*GetTeamReply) ProtoMessage()    {}
 func (*GetTeamReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
 }
 
 func (m *GetTeamReply) XXX_Unmarshal(b []byte) error {
@@ -516,6 +731,27 @@ func (m *GetTeamReply) GetMembers() []*GetTeamReply_Member {
 	return nil
 }
 
+func (m *GetTeamReply) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *GetTeamReply) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *GetTeamReply) GetAvatar() string {
+	if m != nil {
+		return m.Avatar
+	}
+	return ""
+}
+
 type GetTeamReply_Member struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
@@ -528,7 +764,7 @@ func (m *GetTeamReply_Member) Reset()         { *m = GetTeamReply_Member{} }
 func (m *GetTeamReply_Member) String() string { return proto.CompactTextString(m) }
 func (*GetTeamReply_Member) ProtoMessage()    {}
 func (*GetTeamReply_Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15, 0}
 }
 
 func (m *GetTeamReply_Member) XXX_Unmarshal(b []byte) error {
@@ -573,7 +809,7 @@ func (m *ListTeamsRequest) Reset()         { *m = ListTeamsRequest{} }
 func (m *ListTeamsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListTeamsRequest) ProtoMessage()    {}
 func (*ListTeamsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
 }
 
 func (m *ListTeamsRequest) XXX_Unmarshal(b []byte) error {
@@ -605,7 +841,7 @@ func (m *ListTeamsReply) Reset()         { *m = ListTeamsReply{} }
 func (m *ListTeamsReply) String() string { return proto.CompactTextString(m) }
 func (*ListTeamsReply) ProtoMessage()    {}
 func (*ListTeamsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
 }
 
 func (m *ListTeamsReply) XXX_Unmarshal(b []byte) error {
@@ -633,6 +869,124 @@ func (m *ListTeamsReply) GetList() []*GetTeamReply {
 	return nil
 }
 
+type UpdateTeamRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Website              string   `protobuf:"bytes,4,opt,name=website,proto3" json:"website,omitempty"`
+	Avatar               []byte   `protobuf:"bytes,5,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	RemoveAvatar         bool     `protobuf:"varint,6,opt,name=removeAvatar,proto3" json:"removeAvatar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTeamRequest) Reset()         { *m = UpdateTeamRequest{} }
+func (m *UpdateTeamRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTeamRequest) ProtoMessage()    {}
+func (*UpdateTeamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+
+func (m *UpdateTeamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTeamRequest.Unmarshal(m, b)
+}
+func (m *UpdateTeamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTeamRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTeamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTeamRequest.Merge(m, src)
+}
+func (m *UpdateTeamRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTeamRequest.Size(m)
+}
+func (m *UpdateTeamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTeamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTeamRequest proto.InternalMessageInfo
+
+func (m *UpdateTeamRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *UpdateTeamRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateTeamRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *UpdateTeamRequest) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *UpdateTeamRequest) GetAvatar() []byte {
+	if m != nil {
+		return m.Avatar
+	}
+	return nil
+}
+
+func (m *UpdateTeamRequest) GetRemoveAvatar() bool {
+	if m != nil {
+		return m.RemoveAvatar
+	}
+	return false
+}
+
+type UpdateTeamReply struct {
+	Avatar               string   `protobuf:"bytes,1,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTeamReply) Reset()         { *m = UpdateTeamReply{} }
+func (m *UpdateTeamReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateTeamReply) ProtoMessage()    {}
+func (*UpdateTeamReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+}
+
+func (m *UpdateTeamReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTeamReply.Unmarshal(m, b)
+}
+func (m *UpdateTeamReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTeamReply.Marshal(b, m, deterministic)
+}
+func (m *UpdateTeamReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTeamReply.Merge(m, src)
+}
+func (m *UpdateTeamReply) XXX_Size() int {
+	return xxx_messageInfo_UpdateTeamReply.Size(m)
+}
+func (m *UpdateTeamReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTeamReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTeamReply proto.InternalMessageInfo
+
+func (m *UpdateTeamReply) GetAvatar() string {
+	if m != nil {
+		return m.Avatar
+	}
+	return ""
+}
+
 type RemoveTeamRequest struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -644,7 +998,7 @@ func (m *RemoveTeamRequest) Reset()         { *m = RemoveTeamRequest{} }
 func (m *RemoveTeamRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveTeamRequest) ProtoMessage()    {}
 func (*RemoveTeamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
 }
 
 func (m *RemoveTeamRequest) XXX_Unmarshal(b []byte) error {
@@ -682,7 +1036,7 @@ func (m *RemoveTeamReply) Reset()         { *m = RemoveTeamReply{} }
 func (m *RemoveTeamReply) String() string { return proto.CompactTextString(m) }
 func (*RemoveTeamReply) ProtoMessage()    {}
 func (*RemoveTeamReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
 }
 
 func (m *RemoveTeamReply) XXX_Unmarshal(b []byte) error {
@@ -715,7 +1069,7 @@ func (m *InviteToTeamRequest) Reset()         { *m = InviteToTeamRequest{} }
 func (m *InviteToTeamRequest) String() string { return proto.CompactTextString(m) }
 func (*InviteToTeamRequest) ProtoMessage()    {}
 func (*InviteToTeamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
 }
 
 func (m *InviteToTeamRequest) XXX_Unmarshal(b []byte) error {
@@ -761,7 +1115,7 @@ func (m *InviteToTeamReply) Reset()         { *m = InviteToTeamReply{} }
 func (m *InviteToTeamReply) String() string { return proto.CompactTextString(m) }
 func (*InviteToTeamReply) ProtoMessage()    {}
 func (*InviteToTeamReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
 }
 
 func (m *InviteToTeamReply) XXX_Unmarshal(b []byte) error {
@@ -800,7 +1154,7 @@ func (m *LeaveTeamRequest) Reset()         { *m = LeaveTeamRequest{} }
 func (m *LeaveTeamRequest) String() string { return proto.CompactTextString(m) }
 func (*LeaveTeamRequest) ProtoMessage()    {}
 func (*LeaveTeamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
 }
 
 func (m *LeaveTeamRequest) XXX_Unmarshal(b []byte) error {
@@ -838,7 +1192,7 @@ func (m *LeaveTeamReply) Reset()         { *m = LeaveTeamReply{} }
 func (m *LeaveTeamReply) String() string { return proto.CompactTextString(m) }
 func (*LeaveTeamReply) ProtoMessage()    {}
 func (*LeaveTeamReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
 }
 
 func (m *LeaveTeamReply) XXX_Unmarshal(b []byte) error {
@@ -870,7 +1224,7 @@ func (m *AddProjectRequest) Reset()         { *m = AddProjectRequest{} }
 func (m *AddProjectRequest) String() string { return proto.CompactTextString(m) }
 func (*AddProjectRequest) ProtoMessage()    {}
 func (*AddProjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
 }
 
 func (m *AddProjectRequest) XXX_Unmarshal(b []byte) error {
@@ -910,7 +1264,7 @@ func (m *AddProjectReply) Reset()         { *m = AddProjectReply{} }
 func (m *AddProjectReply) String() string { return proto.CompactTextString(m) }
 func (*AddProjectReply) ProtoMessage()    {}
 func (*AddProjectReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
 }
 
 func (m *AddProjectReply) XXX_Unmarshal(b []byte) error {
@@ -956,7 +1310,7 @@ func (m *GetProjectRequest) Reset()         { *m = GetProjectRequest{} }
 func (m *GetProjectRequest) String() string { return proto.CompactTextString(m) }
 func (*GetProjectRequest) ProtoMessage()    {}
 func (*GetProjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
 }
 
 func (m *GetProjectRequest) XXX_Unmarshal(b []byte) error {
@@ -1000,7 +1354,7 @@ func (m *GetProjectReply) Reset()         { *m = GetProjectReply{} }
 func (m *GetProjectReply) String() string { return proto.CompactTextString(m) }
 func (*GetProjectReply) ProtoMessage()    {}
 func (*GetProjectReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
 }
 
 func (m *GetProjectReply) XXX_Unmarshal(b []byte) error {
@@ -1073,7 +1427,7 @@ func (m *ListProjectsRequest) Reset()         { *m = ListProjectsRequest{} }
 func (m *ListProjectsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListProjectsRequest) ProtoMessage()    {}
 func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
 }
 
 func (m *ListProjectsRequest) XXX_Unmarshal(b []byte) error {
@@ -1105,7 +1459,7 @@ func (m *ListProjectsReply) Reset()         { *m = ListProjectsReply{} }
 func (m *ListProjectsReply) String() string { return proto.CompactTextString(m) }
 func (*ListProjectsReply) ProtoMessage()    {}
 func (*ListProjectsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
 }
 
 func (m *ListProjectsReply) XXX_Unmarshal(b []byte) error {
@@ -1133,27 +1487,105 @@ func (m *ListProjectsReply) GetList() []*GetProjectReply {
 	return nil
 }
 
-type RemoveProjectRequest struct {
+type UpdateProjectRequest struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RemoveProjectRequest) Reset()         { *m = RemoveProjectRequest{} }
-func (m *RemoveProjectRequest) String() string { return proto.CompactTextString(m) }
-func (*RemoveProjectRequest) ProtoMessage()    {}
-func (*RemoveProjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+func (m *UpdateProjectRequest) Reset()         { *m = UpdateProjectRequest{} }
+func (m *UpdateProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectRequest) ProtoMessage()    {}
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
 }
 
-func (m *RemoveProjectRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveProjectRequest.Unmarshal(m, b)
+func (m *UpdateProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectRequest.Unmarshal(m, b)
 }
-func (m *RemoveProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveProjectRequest.Marshal(b, m, deterministic)
+func (m *UpdateProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectRequest.Marshal(b, m, deterministic)
 }
-func (m *RemoveProjectRequest) XXX_Merge(src proto.Message) {
+func (m *UpdateProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectRequest.Merge(m, src)
+}
+func (m *UpdateProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectRequest.Size(m)
+}
+func (m *UpdateProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateProjectRequest proto.InternalMessageInfo
+
+func (m *UpdateProjectRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *UpdateProjectRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UpdateProjectReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateProjectReply) Reset()         { *m = UpdateProjectReply{} }
+func (m *UpdateProjectReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectReply) ProtoMessage()    {}
+func (*UpdateProjectReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+}
+
+func (m *UpdateProjectReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectReply.Unmarshal(m, b)
+}
+func (m *UpdateProjectReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectReply.Marshal(b, m, deterministic)
+}
+func (m *UpdateProjectReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectReply.Merge(m, src)
+}
+func (m *UpdateProjectReply) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectReply.Size(m)
+}
+func (m *UpdateProjectReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateProjectReply proto.InternalMessageInfo
+
+type RemoveProjectRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveProjectRequest) Reset()         { *m = RemoveProjectRequest{} }
+func (m *RemoveProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveProjectRequest) ProtoMessage()    {}
+func (*RemoveProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+}
+
+func (m *RemoveProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveProjectRequest.Unmarshal(m, b)
+}
+func (m *RemoveProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveProjectRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveProjectRequest) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_RemoveProjectRequest.Merge(m, src)
 }
 func (m *RemoveProjectRequest) XXX_Size() int {
@@ -1182,7 +1614,7 @@ func (m *RemoveProjectReply) Reset()         { *m = RemoveProjectReply{} }
 func (m *RemoveProjectReply) String() string { return proto.CompactTextString(m) }
 func (*RemoveProjectReply) ProtoMessage()    {}
 func (*RemoveProjectReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
 }
 
 func (m *RemoveProjectReply) XXX_Unmarshal(b []byte) error {
@@ -1205,6 +1637,7 @@ var xxx_messageInfo_RemoveProjectReply proto.InternalMessageInfo
 
 type AddAppTokenRequest struct {
 	ProjectID            string   `protobuf:"bytes,1,opt,name=projectID,proto3" json:"projectID,omitempty"`
+	AllowedOrigins       []string `protobuf:"bytes,2,rep,name=allowedOrigins,proto3" json:"allowedOrigins,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1214,7 +1647,7 @@ func (m *AddAppTokenRequest) Reset()         { *m = AddAppTokenRequest{} }
 func (m *AddAppTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*AddAppTokenRequest) ProtoMessage()    {}
 func (*AddAppTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
 }
 
 func (m *AddAppTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -1242,6 +1675,13 @@ func (m *AddAppTokenRequest) GetProjectID() string {
 	return ""
 }
 
+func (m *AddAppTokenRequest) GetAllowedOrigins() []string {
+	if m != nil {
+		return m.AllowedOrigins
+	}
+	return nil
+}
+
 type AddAppTokenReply struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1253,7 +1693,7 @@ func (m *AddAppTokenReply) Reset()         { *m = AddAppTokenReply{} }
 func (m *AddAppTokenReply) String() string { return proto.CompactTextString(m) }
 func (*AddAppTokenReply) ProtoMessage()    {}
 func (*AddAppTokenReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
 }
 
 func (m *AddAppTokenReply) XXX_Unmarshal(b []byte) error {
@@ -1292,7 +1732,7 @@ func (m *ListAppTokensRequest) Reset()         { *m = ListAppTokensRequest{} }
 func (m *ListAppTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAppTokensRequest) ProtoMessage()    {}
 func (*ListAppTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
 }
 
 func (m *ListAppTokensRequest) XXX_Unmarshal(b []byte) error {
@@ -1331,7 +1771,7 @@ func (m *ListAppTokensReply) Reset()         { *m = ListAppTokensReply{} }
 func (m *ListAppTokensReply) String() string { return proto.CompactTextString(m) }
 func (*ListAppTokensReply) ProtoMessage()    {}
 func (*ListAppTokensReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
 }
 
 func (m *ListAppTokensReply) XXX_Unmarshal(b []byte) error {
@@ -1370,7 +1810,7 @@ func (m *RemoveAppTokenRequest) Reset()         { *m = RemoveAppTokenRequest{} }
 func (m *RemoveAppTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveAppTokenRequest) ProtoMessage()    {}
 func (*RemoveAppTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
 }
 
 func (m *RemoveAppTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -1408,7 +1848,7 @@ func (m *RemoveAppTokenReply) Reset()         { *m = RemoveAppTokenReply{} }
 func (m *RemoveAppTokenReply) String() string { return proto.CompactTextString(m) }
 func (*RemoveAppTokenReply) ProtoMessage()    {}
 func (*RemoveAppTokenReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
 }
 
 func (m *RemoveAppTokenReply) XXX_Unmarshal(b []byte) error {
@@ -1423,11 +1863,787 @@ func (m *RemoveAppTokenReply) XXX_Merge(src proto.Message) {
 func (m *RemoveAppTokenReply) XXX_Size() int {
 	return xxx_messageInfo_RemoveAppTokenReply.Size(m)
 }
-func (m *RemoveAppTokenReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveAppTokenReply.DiscardUnknown(m)
+func (m *RemoveAppTokenReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveAppTokenReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveAppTokenReply proto.InternalMessageInfo
+
+type ListAppUsersRequest struct {
+	ProjectID            string   `protobuf:"bytes,1,opt,name=projectID,proto3" json:"projectID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAppUsersRequest) Reset()         { *m = ListAppUsersRequest{} }
+func (m *ListAppUsersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAppUsersRequest) ProtoMessage()    {}
+func (*ListAppUsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+}
+
+func (m *ListAppUsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAppUsersRequest.Unmarshal(m, b)
+}
+func (m *ListAppUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAppUsersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAppUsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAppUsersRequest.Merge(m, src)
+}
+func (m *ListAppUsersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAppUsersRequest.Size(m)
+}
+func (m *ListAppUsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAppUsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAppUsersRequest proto.InternalMessageInfo
+
+func (m *ListAppUsersRequest) GetProjectID() string {
+	if m != nil {
+		return m.ProjectID
+	}
+	return ""
+}
+
+type ListAppUsersReply struct {
+	List                 []*ListAppUsersReply_AppUser `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ListAppUsersReply) Reset()         { *m = ListAppUsersReply{} }
+func (m *ListAppUsersReply) String() string { return proto.CompactTextString(m) }
+func (*ListAppUsersReply) ProtoMessage()    {}
+func (*ListAppUsersReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+}
+
+func (m *ListAppUsersReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAppUsersReply.Unmarshal(m, b)
+}
+func (m *ListAppUsersReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAppUsersReply.Marshal(b, m, deterministic)
+}
+func (m *ListAppUsersReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAppUsersReply.Merge(m, src)
+}
+func (m *ListAppUsersReply) XXX_Size() int {
+	return xxx_messageInfo_ListAppUsersReply.Size(m)
+}
+func (m *ListAppUsersReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAppUsersReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAppUsersReply proto.InternalMessageInfo
+
+func (m *ListAppUsersReply) GetList() []*ListAppUsersReply_AppUser {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type ListAppUsersReply_AppUser struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	StoreID              string   `protobuf:"bytes,2,opt,name=storeID,proto3" json:"storeID,omitempty"`
+	Created              int64    `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAppUsersReply_AppUser) Reset()         { *m = ListAppUsersReply_AppUser{} }
+func (m *ListAppUsersReply_AppUser) String() string { return proto.CompactTextString(m) }
+func (*ListAppUsersReply_AppUser) ProtoMessage()    {}
+func (*ListAppUsersReply_AppUser) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43, 0}
+}
+
+func (m *ListAppUsersReply_AppUser) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAppUsersReply_AppUser.Unmarshal(m, b)
+}
+func (m *ListAppUsersReply_AppUser) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAppUsersReply_AppUser.Marshal(b, m, deterministic)
+}
+func (m *ListAppUsersReply_AppUser) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAppUsersReply_AppUser.Merge(m, src)
+}
+func (m *ListAppUsersReply_AppUser) XXX_Size() int {
+	return xxx_messageInfo_ListAppUsersReply_AppUser.Size(m)
+}
+func (m *ListAppUsersReply_AppUser) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAppUsersReply_AppUser.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAppUsersReply_AppUser proto.InternalMessageInfo
+
+func (m *ListAppUsersReply_AppUser) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *ListAppUsersReply_AppUser) GetStoreID() string {
+	if m != nil {
+		return m.StoreID
+	}
+	return ""
+}
+
+func (m *ListAppUsersReply_AppUser) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type RemoveAppUserRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveAppUserRequest) Reset()         { *m = RemoveAppUserRequest{} }
+func (m *RemoveAppUserRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveAppUserRequest) ProtoMessage()    {}
+func (*RemoveAppUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+}
+
+func (m *RemoveAppUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveAppUserRequest.Unmarshal(m, b)
+}
+func (m *RemoveAppUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveAppUserRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveAppUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveAppUserRequest.Merge(m, src)
+}
+func (m *RemoveAppUserRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveAppUserRequest.Size(m)
+}
+func (m *RemoveAppUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveAppUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveAppUserRequest proto.InternalMessageInfo
+
+func (m *RemoveAppUserRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type RemoveAppUserReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveAppUserReply) Reset()         { *m = RemoveAppUserReply{} }
+func (m *RemoveAppUserReply) String() string { return proto.CompactTextString(m) }
+func (*RemoveAppUserReply) ProtoMessage()    {}
+func (*RemoveAppUserReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+}
+
+func (m *RemoveAppUserReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveAppUserReply.Unmarshal(m, b)
+}
+func (m *RemoveAppUserReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveAppUserReply.Marshal(b, m, deterministic)
+}
+func (m *RemoveAppUserReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveAppUserReply.Merge(m, src)
+}
+func (m *RemoveAppUserReply) XXX_Size() int {
+	return xxx_messageInfo_RemoveAppUserReply.Size(m)
+}
+func (m *RemoveAppUserReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveAppUserReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveAppUserReply proto.InternalMessageInfo
+
+type AddWebhookRequest struct {
+	ProjectID            string   `protobuf:"bytes,1,opt,name=projectID,proto3" json:"projectID,omitempty"`
+	URL                  string   `protobuf:"bytes,2,opt,name=URL,proto3" json:"URL,omitempty"`
+	Secret               string   `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Events               []string `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddWebhookRequest) Reset()         { *m = AddWebhookRequest{} }
+func (m *AddWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*AddWebhookRequest) ProtoMessage()    {}
+func (*AddWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+}
+
+func (m *AddWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddWebhookRequest.Unmarshal(m, b)
+}
+func (m *AddWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *AddWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddWebhookRequest.Merge(m, src)
+}
+func (m *AddWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_AddWebhookRequest.Size(m)
+}
+func (m *AddWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddWebhookRequest proto.InternalMessageInfo
+
+func (m *AddWebhookRequest) GetProjectID() string {
+	if m != nil {
+		return m.ProjectID
+	}
+	return ""
+}
+
+func (m *AddWebhookRequest) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *AddWebhookRequest) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *AddWebhookRequest) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type AddWebhookReply struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Secret               string   `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddWebhookReply) Reset()         { *m = AddWebhookReply{} }
+func (m *AddWebhookReply) String() string { return proto.CompactTextString(m) }
+func (*AddWebhookReply) ProtoMessage()    {}
+func (*AddWebhookReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
+}
+
+func (m *AddWebhookReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddWebhookReply.Unmarshal(m, b)
+}
+func (m *AddWebhookReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddWebhookReply.Marshal(b, m, deterministic)
+}
+func (m *AddWebhookReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddWebhookReply.Merge(m, src)
+}
+func (m *AddWebhookReply) XXX_Size() int {
+	return xxx_messageInfo_AddWebhookReply.Size(m)
+}
+func (m *AddWebhookReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddWebhookReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddWebhookReply proto.InternalMessageInfo
+
+func (m *AddWebhookReply) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *AddWebhookReply) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+type ListWebhooksRequest struct {
+	ProjectID            string   `protobuf:"bytes,1,opt,name=projectID,proto3" json:"projectID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+func (m *ListWebhooksRequest) GetProjectID() string {
+	if m != nil {
+		return m.ProjectID
+	}
+	return ""
+}
+
+type ListWebhooksReply struct {
+	List                 []*ListWebhooksReply_Webhook `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ListWebhooksReply) Reset()         { *m = ListWebhooksReply{} }
+func (m *ListWebhooksReply) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksReply) ProtoMessage()    {}
+func (*ListWebhooksReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+}
+
+func (m *ListWebhooksReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksReply.Unmarshal(m, b)
+}
+func (m *ListWebhooksReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksReply.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksReply.Merge(m, src)
+}
+func (m *ListWebhooksReply) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksReply.Size(m)
+}
+func (m *ListWebhooksReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksReply proto.InternalMessageInfo
+
+func (m *ListWebhooksReply) GetList() []*ListWebhooksReply_Webhook {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type ListWebhooksReply_Webhook struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	URL                  string   `protobuf:"bytes,2,opt,name=URL,proto3" json:"URL,omitempty"`
+	Events               []string `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Created              int64    `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksReply_Webhook) Reset()         { *m = ListWebhooksReply_Webhook{} }
+func (m *ListWebhooksReply_Webhook) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksReply_Webhook) ProtoMessage()    {}
+func (*ListWebhooksReply_Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49, 0}
+}
+
+func (m *ListWebhooksReply_Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksReply_Webhook.Unmarshal(m, b)
+}
+func (m *ListWebhooksReply_Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksReply_Webhook.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksReply_Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksReply_Webhook.Merge(m, src)
+}
+func (m *ListWebhooksReply_Webhook) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksReply_Webhook.Size(m)
+}
+func (m *ListWebhooksReply_Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksReply_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksReply_Webhook proto.InternalMessageInfo
+
+func (m *ListWebhooksReply_Webhook) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *ListWebhooksReply_Webhook) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *ListWebhooksReply_Webhook) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListWebhooksReply_Webhook) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type RemoveWebhookRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveWebhookRequest) Reset()         { *m = RemoveWebhookRequest{} }
+func (m *RemoveWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveWebhookRequest) ProtoMessage()    {}
+func (*RemoveWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
+}
+
+func (m *RemoveWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveWebhookRequest.Unmarshal(m, b)
+}
+func (m *RemoveWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveWebhookRequest.Merge(m, src)
+}
+func (m *RemoveWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveWebhookRequest.Size(m)
+}
+func (m *RemoveWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveWebhookRequest proto.InternalMessageInfo
+
+func (m *RemoveWebhookRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type RemoveWebhookReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveWebhookReply) Reset()         { *m = RemoveWebhookReply{} }
+func (m *RemoveWebhookReply) String() string { return proto.CompactTextString(m) }
+func (*RemoveWebhookReply) ProtoMessage()    {}
+func (*RemoveWebhookReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
+}
+
+func (m *RemoveWebhookReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveWebhookReply.Unmarshal(m, b)
+}
+func (m *RemoveWebhookReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveWebhookReply.Marshal(b, m, deterministic)
+}
+func (m *RemoveWebhookReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveWebhookReply.Merge(m, src)
+}
+func (m *RemoveWebhookReply) XXX_Size() int {
+	return xxx_messageInfo_RemoveWebhookReply.Size(m)
+}
+func (m *RemoveWebhookReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveWebhookReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveWebhookReply proto.InternalMessageInfo
+
+type TestWebhookRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestWebhookRequest) Reset()         { *m = TestWebhookRequest{} }
+func (m *TestWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*TestWebhookRequest) ProtoMessage()    {}
+func (*TestWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
+}
+
+func (m *TestWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestWebhookRequest.Unmarshal(m, b)
+}
+func (m *TestWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *TestWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestWebhookRequest.Merge(m, src)
+}
+func (m *TestWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_TestWebhookRequest.Size(m)
+}
+func (m *TestWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestWebhookRequest proto.InternalMessageInfo
+
+func (m *TestWebhookRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type TestWebhookReply struct {
+	// Deprecated: the response status is no longer reported, this is always 0.
+	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestWebhookReply) Reset()         { *m = TestWebhookReply{} }
+func (m *TestWebhookReply) String() string { return proto.CompactTextString(m) }
+func (*TestWebhookReply) ProtoMessage()    {}
+func (*TestWebhookReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
+}
+
+func (m *TestWebhookReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestWebhookReply.Unmarshal(m, b)
+}
+func (m *TestWebhookReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestWebhookReply.Marshal(b, m, deterministic)
+}
+func (m *TestWebhookReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestWebhookReply.Merge(m, src)
+}
+func (m *TestWebhookReply) XXX_Size() int {
+	return xxx_messageInfo_TestWebhookReply.Size(m)
+}
+func (m *TestWebhookReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestWebhookReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestWebhookReply proto.InternalMessageInfo
+
+func (m *TestWebhookReply) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+type ListAuditEventsRequest struct {
+	Since                int64    `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until                int64    `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+type ListAuditEventsReply struct {
+	List                 []*ListAuditEventsReply_AuditEvent `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *ListAuditEventsReply) Reset()         { *m = ListAuditEventsReply{} }
+func (m *ListAuditEventsReply) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsReply) ProtoMessage()    {}
+func (*ListAuditEventsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
+}
+
+func (m *ListAuditEventsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsReply.Unmarshal(m, b)
+}
+func (m *ListAuditEventsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsReply.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsReply.Merge(m, src)
+}
+func (m *ListAuditEventsReply) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsReply.Size(m)
+}
+func (m *ListAuditEventsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsReply proto.InternalMessageInfo
+
+func (m *ListAuditEventsReply) GetList() []*ListAuditEventsReply_AuditEvent {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+type ListAuditEventsReply_AuditEvent struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ActorID              string   `protobuf:"bytes,2,opt,name=actorID,proto3" json:"actorID,omitempty"`
+	Scope                string   `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Action               string   `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	TargetID             string   `protobuf:"bytes,5,opt,name=targetID,proto3" json:"targetID,omitempty"`
+	IP                   string   `protobuf:"bytes,6,opt,name=IP,proto3" json:"IP,omitempty"`
+	Created              int64    `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsReply_AuditEvent) Reset()         { *m = ListAuditEventsReply_AuditEvent{} }
+func (m *ListAuditEventsReply_AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsReply_AuditEvent) ProtoMessage()    {}
+func (*ListAuditEventsReply_AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55, 0}
+}
+
+func (m *ListAuditEventsReply_AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsReply_AuditEvent.Unmarshal(m, b)
+}
+func (m *ListAuditEventsReply_AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsReply_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsReply_AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsReply_AuditEvent.Merge(m, src)
+}
+func (m *ListAuditEventsReply_AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsReply_AuditEvent.Size(m)
+}
+func (m *ListAuditEventsReply_AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsReply_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsReply_AuditEvent proto.InternalMessageInfo
+
+func (m *ListAuditEventsReply_AuditEvent) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReply_AuditEvent) GetActorID() string {
+	if m != nil {
+		return m.ActorID
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReply_AuditEvent) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReply_AuditEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReply_AuditEvent) GetTargetID() string {
+	if m != nil {
+		return m.TargetID
+	}
+	return ""
+}
+
+func (m *ListAuditEventsReply_AuditEvent) GetIP() string {
+	if m != nil {
+		return m.IP
+	}
+	return ""
 }
 
-var xxx_messageInfo_RemoveAppTokenReply proto.InternalMessageInfo
+func (m *ListAuditEventsReply_AuditEvent) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
 
 func init() {
 	proto.RegisterType((*LoginRequest)(nil), "pb.LoginRequest")
@@ -1438,6 +2654,10 @@ func init() {
 	proto.RegisterType((*LogoutReply)(nil), "pb.LogoutReply")
 	proto.RegisterType((*WhoamiRequest)(nil), "pb.WhoamiRequest")
 	proto.RegisterType((*WhoamiReply)(nil), "pb.WhoamiReply")
+	proto.RegisterType((*GetProfileRequest)(nil), "pb.GetProfileRequest")
+	proto.RegisterType((*GetProfileReply)(nil), "pb.GetProfileReply")
+	proto.RegisterType((*UpdateProfileRequest)(nil), "pb.UpdateProfileRequest")
+	proto.RegisterType((*UpdateProfileReply)(nil), "pb.UpdateProfileReply")
 	proto.RegisterType((*AddTeamRequest)(nil), "pb.AddTeamRequest")
 	proto.RegisterType((*AddTeamReply)(nil), "pb.AddTeamReply")
 	proto.RegisterType((*GetTeamRequest)(nil), "pb.GetTeamRequest")
@@ -1445,6 +2665,8 @@ func init() {
 	proto.RegisterType((*GetTeamReply_Member)(nil), "pb.GetTeamReply.Member")
 	proto.RegisterType((*ListTeamsRequest)(nil), "pb.ListTeamsRequest")
 	proto.RegisterType((*ListTeamsReply)(nil), "pb.ListTeamsReply")
+	proto.RegisterType((*UpdateTeamRequest)(nil), "pb.UpdateTeamRequest")
+	proto.RegisterType((*UpdateTeamReply)(nil), "pb.UpdateTeamReply")
 	proto.RegisterType((*RemoveTeamRequest)(nil), "pb.RemoveTeamRequest")
 	proto.RegisterType((*RemoveTeamReply)(nil), "pb.RemoveTeamReply")
 	proto.RegisterType((*InviteToTeamRequest)(nil), "pb.InviteToTeamRequest")
@@ -1457,6 +2679,8 @@ func init() {
 	proto.RegisterType((*GetProjectReply)(nil), "pb.GetProjectReply")
 	proto.RegisterType((*ListProjectsRequest)(nil), "pb.ListProjectsRequest")
 	proto.RegisterType((*ListProjectsReply)(nil), "pb.ListProjectsReply")
+	proto.RegisterType((*UpdateProjectRequest)(nil), "pb.UpdateProjectRequest")
+	proto.RegisterType((*UpdateProjectReply)(nil), "pb.UpdateProjectReply")
 	proto.RegisterType((*RemoveProjectRequest)(nil), "pb.RemoveProjectRequest")
 	proto.RegisterType((*RemoveProjectReply)(nil), "pb.RemoveProjectReply")
 	proto.RegisterType((*AddAppTokenRequest)(nil), "pb.AddAppTokenRequest")
@@ -1465,69 +2689,127 @@ func init() {
 	proto.RegisterType((*ListAppTokensReply)(nil), "pb.ListAppTokensReply")
 	proto.RegisterType((*RemoveAppTokenRequest)(nil), "pb.RemoveAppTokenRequest")
 	proto.RegisterType((*RemoveAppTokenReply)(nil), "pb.RemoveAppTokenReply")
+	proto.RegisterType((*ListAppUsersRequest)(nil), "pb.ListAppUsersRequest")
+	proto.RegisterType((*ListAppUsersReply)(nil), "pb.ListAppUsersReply")
+	proto.RegisterType((*ListAppUsersReply_AppUser)(nil), "pb.ListAppUsersReply.AppUser")
+	proto.RegisterType((*RemoveAppUserRequest)(nil), "pb.RemoveAppUserRequest")
+	proto.RegisterType((*RemoveAppUserReply)(nil), "pb.RemoveAppUserReply")
+	proto.RegisterType((*AddWebhookRequest)(nil), "pb.AddWebhookRequest")
+	proto.RegisterType((*AddWebhookReply)(nil), "pb.AddWebhookReply")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "pb.ListWebhooksRequest")
+	proto.RegisterType((*ListWebhooksReply)(nil), "pb.ListWebhooksReply")
+	proto.RegisterType((*ListWebhooksReply_Webhook)(nil), "pb.ListWebhooksReply.Webhook")
+	proto.RegisterType((*RemoveWebhookRequest)(nil), "pb.RemoveWebhookRequest")
+	proto.RegisterType((*RemoveWebhookReply)(nil), "pb.RemoveWebhookReply")
+	proto.RegisterType((*TestWebhookRequest)(nil), "pb.TestWebhookRequest")
+	proto.RegisterType((*TestWebhookReply)(nil), "pb.TestWebhookReply")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "pb.ListAuditEventsRequest")
+	proto.RegisterType((*ListAuditEventsReply)(nil), "pb.ListAuditEventsReply")
+	proto.RegisterType((*ListAuditEventsReply_AuditEvent)(nil), "pb.ListAuditEventsReply.AuditEvent")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 902 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xed, 0x6e, 0xdb, 0x36,
-	0x14, 0xf5, 0xb7, 0xe3, 0xeb, 0x6f, 0xda, 0x4e, 0x34, 0x21, 0x18, 0x02, 0xcd, 0x58, 0x02, 0x04,
-	0xf0, 0xb0, 0x6c, 0xd8, 0x80, 0x64, 0x03, 0xe6, 0xcc, 0x5b, 0x60, 0xc0, 0x1b, 0x02, 0xd7, 0x40,
-	0xfb, 0xaf, 0x90, 0x2d, 0xc2, 0x51, 0x2b, 0x59, 0xaa, 0xa4, 0x24, 0xcd, 0xeb, 0xf4, 0x1d, 0xfa,
-	0x10, 0x7d, 0x8e, 0xbe, 0x48, 0xc1, 0x0f, 0x49, 0x24, 0xa5, 0xb8, 0xfd, 0x15, 0xf3, 0xdc, 0x43,
-	0xf2, 0xdc, 0xab, 0x7b, 0x0f, 0x03, 0x0d, 0xd3, 0xb7, 0x27, 0x7e, 0xe0, 0x45, 0x1e, 0x2a, 0xf9,
-	0x6b, 0x63, 0x0c, 0xad, 0x85, 0xb7, 0xb5, 0x77, 0x4b, 0xfc, 0xee, 0x1e, 0x87, 0x11, 0x1a, 0x42,
-	0x15, 0xbb, 0xa6, 0xed, 0x68, 0xc5, 0x93, 0xe2, 0x59, 0x63, 0xc9, 0x16, 0xc6, 0x25, 0x00, 0x67,
-	0xf9, 0xce, 0x13, 0xea, 0x40, 0x69, 0x3e, 0xe3, 0x84, 0xd2, 0x7c, 0x86, 0x8e, 0xa1, 0x11, 0xe2,
-	0x30, 0xb4, 0xbd, 0xdd, 0x7c, 0xa6, 0x95, 0x28, 0x9c, 0x02, 0x46, 0x17, 0xda, 0x2f, 0x1e, 0xed,
-	0x68, 0x73, 0xc7, 0xaf, 0x30, 0xda, 0xd0, 0x8c, 0x01, 0xdf, 0x79, 0x22, 0xf1, 0x85, 0xb7, 0xf5,
-	0xee, 0x23, 0x21, 0x1e, 0x03, 0x3c, 0xfe, 0xf2, 0xce, 0x33, 0x5d, 0x3b, 0x8e, 0x6f, 0xa1, 0x19,
-	0x03, 0x79, 0x6a, 0x86, 0x50, 0xfd, 0x87, 0x66, 0xc0, 0x94, 0xb0, 0x05, 0x3a, 0x84, 0x5a, 0x84,
-	0x4d, 0x77, 0x3e, 0xd3, 0xca, 0x14, 0xe6, 0x2b, 0xa4, 0xc3, 0x01, 0xf9, 0xf5, 0xbf, 0xe9, 0x62,
-	0xad, 0x42, 0x23, 0xc9, 0xda, 0x18, 0x43, 0x67, 0x6a, 0x59, 0x2b, 0x6c, 0xba, 0x71, 0x75, 0x10,
-	0x54, 0x76, 0x84, 0xc9, 0x6e, 0xa3, 0xbf, 0x8d, 0xef, 0xa1, 0x95, 0xb0, 0x72, 0xf4, 0x18, 0x27,
-	0xd0, 0xb9, 0xc1, 0x91, 0x78, 0x8a, 0xca, 0xf8, 0x54, 0x84, 0x56, 0x42, 0xc9, 0x4b, 0x49, 0x83,
-	0xba, 0xf7, 0xb8, 0xc3, 0x41, 0x52, 0xde, 0x78, 0x99, 0x08, 0x2a, 0xa7, 0x82, 0x08, 0x7b, 0x13,
-	0x60, 0x33, 0xc2, 0x16, 0xcd, 0xa8, 0xbc, 0x8c, 0x97, 0xe8, 0x67, 0xa8, 0xbb, 0xd8, 0x5d, 0xe3,
-	0x20, 0xd4, 0xaa, 0x27, 0xe5, 0xb3, 0xe6, 0xc5, 0xd1, 0xc4, 0x5f, 0x4f, 0xc4, 0xab, 0x27, 0xff,
-	0xd1, 0xf8, 0x32, 0xe6, 0xe9, 0x13, 0xa8, 0x31, 0x28, 0xaf, 0xce, 0x58, 0xac, 0x33, 0xeb, 0x14,
-	0x04, 0xbd, 0x85, 0x1d, 0xd2, 0x03, 0xc3, 0xf8, 0x83, 0xfd, 0x06, 0x1d, 0x01, 0x23, 0x09, 0x8e,
-	0xa1, 0xe2, 0xd8, 0x61, 0xa4, 0x15, 0xa9, 0x8a, 0x9e, 0xaa, 0x62, 0x49, 0xa3, 0xc6, 0x0f, 0xd0,
-	0x5f, 0x62, 0xd7, 0x7b, 0xc0, 0xfb, 0x8a, 0xd7, 0x87, 0xae, 0x48, 0x22, 0x1d, 0x73, 0x05, 0x83,
-	0xf9, 0xee, 0xc1, 0x8e, 0xf0, 0xca, 0xdb, 0xb3, 0xf3, 0x99, 0x04, 0x7e, 0x82, 0xbe, 0xbc, 0x99,
-	0xe8, 0xd5, 0xe1, 0xc0, 0xa6, 0x60, 0x72, 0x40, 0xb2, 0x36, 0x0c, 0xe8, 0x2d, 0xb0, 0xb9, 0x5f,
-	0x64, 0x0f, 0x3a, 0x02, 0x87, 0x68, 0x3c, 0x85, 0xfe, 0xd4, 0xb2, 0x6e, 0x03, 0xef, 0x0d, 0xde,
-	0x44, 0xfb, 0xda, 0xeb, 0x0a, 0xba, 0x22, 0xf1, 0x99, 0xf6, 0x08, 0x23, 0x2f, 0xc0, 0x69, 0x7b,
-	0xf0, 0x25, 0xa9, 0xe0, 0x0d, 0x8e, 0x94, 0x5b, 0x54, 0x71, 0x1f, 0x8b, 0xd0, 0x15, 0x59, 0x79,
-	0x57, 0xc4, 0xca, 0x4a, 0x72, 0x9f, 0xc5, 0xd7, 0x96, 0xa5, 0x6b, 0xd1, 0x18, 0xda, 0x8f, 0xa6,
-	0xe3, 0xe0, 0x68, 0x6a, 0x59, 0x01, 0x0e, 0x43, 0x3e, 0x59, 0x32, 0x98, 0xb2, 0xae, 0x4d, 0xc7,
-	0xdc, 0x6d, 0xb0, 0x56, 0xa5, 0xdd, 0x2a, 0x83, 0x62, 0x37, 0xd7, 0xa4, 0x6e, 0x36, 0x46, 0x30,
-	0x20, 0x6d, 0xc5, 0x75, 0x27, 0xdd, 0xf6, 0x07, 0xf4, 0x65, 0x98, 0xe4, 0x73, 0x2a, 0x35, 0xdc,
-	0x80, 0x37, 0x9c, 0x98, 0x32, 0xef, 0xb9, 0x1f, 0x61, 0xc8, 0xda, 0xe9, 0x2b, 0x45, 0x1b, 0x02,
-	0x52, 0x78, 0xe4, 0xab, 0x5e, 0x00, 0x9a, 0x5a, 0xd6, 0xd4, 0xf7, 0x57, 0xde, 0x5b, 0x9c, 0x78,
-	0xea, 0x31, 0x34, 0x7c, 0xc6, 0x4a, 0x8e, 0x48, 0x01, 0xd2, 0x3f, 0xd2, 0x9e, 0x3c, 0x0f, 0xf9,
-	0x15, 0x86, 0x24, 0xa7, 0x98, 0x14, 0x7e, 0xdb, 0xc9, 0x67, 0x80, 0x94, 0x5d, 0xe4, 0x6c, 0x24,
-	0x94, 0xa2, 0xc1, 0xb3, 0x3e, 0x85, 0x11, 0xcb, 0x46, 0x95, 0xae, 0x0a, 0x19, 0xc1, 0x40, 0x25,
-	0xfa, 0xce, 0xd3, 0xc5, 0xe7, 0x3a, 0x94, 0xa7, 0xb7, 0x73, 0x74, 0x0e, 0x55, 0xfa, 0x4e, 0x20,
-	0x3a, 0xd2, 0xe2, 0xc3, 0xa2, 0x77, 0x04, 0x84, 0x94, 0xaa, 0x80, 0x26, 0x50, 0x63, 0x3e, 0x8f,
-	0xfa, 0x3c, 0x96, 0x3e, 0x02, 0x7a, 0x57, 0x84, 0x12, 0x3e, 0x7b, 0x37, 0x18, 0x5f, 0x7a, 0x54,
-	0x18, 0x5f, 0x7c, 0x56, 0x28, 0x9f, 0xbd, 0x13, 0x8c, 0x2f, 0x3d, 0x22, 0x8c, 0x2f, 0x3c, 0x23,
-	0x46, 0x81, 0xb8, 0x23, 0x37, 0x72, 0x84, 0x48, 0x54, 0xf6, 0x7e, 0xbd, 0x27, 0x61, 0xc9, 0x16,
-	0xee, 0x5b, 0x6c, 0x8b, 0x6c, 0xf4, 0x7a, 0xc6, 0xd8, 0x8c, 0x02, 0xfa, 0x1d, 0x1a, 0x89, 0x19,
-	0xa2, 0x21, 0xcd, 0x52, 0xf1, 0x4b, 0x1d, 0x29, 0x28, 0xdb, 0x78, 0x09, 0x90, 0x1a, 0x1d, 0x1a,
-	0x11, 0x4e, 0xc6, 0x1d, 0xf5, 0x81, 0x0a, 0xb3, 0xbd, 0x7f, 0x41, 0x4b, 0x34, 0x35, 0x44, 0x7d,
-	0x3f, 0xc7, 0x23, 0xf5, 0x51, 0x36, 0x90, 0xca, 0x8e, 0x1d, 0x8c, 0xcb, 0x56, 0x4c, 0x8f, 0xcb,
-	0x96, 0x6d, 0x8e, 0xca, 0x4e, 0xfd, 0x8b, 0xc9, 0xce, 0x18, 0x1f, 0x93, 0xad, 0xd8, 0x1c, 0xdb,
-	0x9b, 0x4e, 0x29, 0xdb, 0x9b, 0xb1, 0x33, 0x3d, 0x6f, 0x98, 0x59, 0xca, 0xa2, 0x0d, 0xb0, 0x94,
-	0x73, 0xfc, 0x82, 0xa5, 0x9c, 0x71, 0x0c, 0xa3, 0x80, 0xfe, 0x86, 0xb6, 0x34, 0xe2, 0x48, 0x4b,
-	0x8b, 0xab, 0x68, 0x38, 0xcc, 0x89, 0xb0, 0x43, 0xfe, 0x84, 0xa6, 0x30, 0xdd, 0xe8, 0x90, 0x27,
-	0xaa, 0xcc, 0x99, 0x3e, 0xcc, 0xe0, 0x89, 0x06, 0x69, 0x84, 0x99, 0x86, 0x3c, 0x2f, 0x60, 0x1a,
-	0xb2, 0xf3, 0x6e, 0x14, 0xd0, 0xbf, 0xd0, 0x91, 0x87, 0x16, 0x7d, 0x97, 0xea, 0x55, 0x95, 0x1c,
-	0xe5, 0x85, 0xe8, 0x39, 0xd7, 0xe7, 0x70, 0x64, 0x7b, 0x93, 0x08, 0xbf, 0x8f, 0x6c, 0x07, 0xc7,
-	0x7f, 0x5f, 0x6f, 0x03, 0x7f, 0x73, 0x5d, 0x5f, 0xb1, 0xd5, 0x6d, 0xf1, 0x43, 0xa9, 0xb2, 0x7a,
-	0xb5, 0x5a, 0xac, 0x6b, 0xf4, 0x7f, 0xcc, 0x5f, 0xbe, 0x04, 0x00, 0x00, 0xff, 0xff, 0x65, 0xfe,
-	0xde, 0x62, 0x70, 0x0a, 0x00, 0x00,
+	// 1567 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0x5b, 0x6f, 0x1b, 0xc5,
+	0x17, 0xef, 0xfa, 0x12, 0xc7, 0x27, 0x89, 0x2f, 0xe3, 0x4b, 0xf7, 0x3f, 0xea, 0xbf, 0x8a, 0xb6,
+	0x51, 0x1b, 0xa8, 0x64, 0xd4, 0x16, 0x51, 0xd1, 0x82, 0x84, 0x4b, 0x4a, 0x65, 0x29, 0x85, 0xc8,
+	0xb8, 0x2a, 0x42, 0x20, 0xb4, 0xf1, 0x0e, 0xe9, 0x52, 0xdb, 0xbb, 0xec, 0x4e, 0x12, 0xfa, 0xc8,
+	0x87, 0xe0, 0x91, 0x17, 0x24, 0x5e, 0x78, 0xe1, 0x89, 0x07, 0x3e, 0x07, 0x5f, 0x87, 0x07, 0x34,
+	0x97, 0x9d, 0x9d, 0x99, 0x5d, 0xbb, 0x79, 0x8a, 0xcf, 0xef, 0x9c, 0x99, 0x39, 0xb7, 0x99, 0xfd,
+	0x9d, 0x40, 0xd3, 0x8f, 0xc3, 0x51, 0x9c, 0x44, 0x34, 0x42, 0x95, 0xf8, 0xd4, 0x3b, 0x80, 0xdd,
+	0xe3, 0xe8, 0x2c, 0x5c, 0x4d, 0xc9, 0x8f, 0xe7, 0x24, 0xa5, 0xa8, 0x0f, 0x75, 0xb2, 0xf4, 0xc3,
+	0x85, 0xeb, 0xec, 0x3b, 0x87, 0xcd, 0xa9, 0x10, 0xbc, 0x47, 0x00, 0xd2, 0x2a, 0x5e, 0xbc, 0x41,
+	0x2d, 0xa8, 0x4c, 0x8e, 0xa4, 0x41, 0x65, 0x72, 0x84, 0x6e, 0x40, 0x33, 0x25, 0x69, 0x1a, 0x46,
+	0xab, 0xc9, 0x91, 0x5b, 0xe1, 0x70, 0x0e, 0x78, 0x6d, 0xd8, 0xfb, 0xf2, 0x32, 0xa4, 0xf3, 0x57,
+	0xf2, 0x08, 0x6f, 0x0f, 0x76, 0x32, 0x20, 0x5e, 0xbc, 0x61, 0xfa, 0xe3, 0xe8, 0x2c, 0x3a, 0xa7,
+	0x9a, 0x3e, 0x03, 0xa4, 0xfe, 0xe5, 0xab, 0xc8, 0x5f, 0x86, 0x99, 0xfe, 0x1f, 0x07, 0x76, 0x32,
+	0xa4, 0xcc, 0x9d, 0x3e, 0xd4, 0x9f, 0xf2, 0x10, 0x84, 0x2b, 0x42, 0x40, 0x43, 0xd8, 0xa2, 0xc4,
+	0x5f, 0x4e, 0x8e, 0xdc, 0x2a, 0x87, 0xa5, 0x84, 0x30, 0x6c, 0xb3, 0x5f, 0x9f, 0xfb, 0x4b, 0xe2,
+	0xd6, 0xb8, 0x46, 0xc9, 0xe8, 0x10, 0xda, 0xec, 0xf7, 0x11, 0x49, 0xe7, 0x49, 0x18, 0xd3, 0x30,
+	0x5a, 0xb9, 0x75, 0x6e, 0x62, 0xc3, 0x68, 0x1f, 0x76, 0x18, 0xf4, 0x92, 0x9c, 0xa6, 0x21, 0x25,
+	0xee, 0x16, 0xb7, 0xd2, 0x21, 0x74, 0x13, 0x80, 0x89, 0xe3, 0x0b, 0x9f, 0xfa, 0x89, 0xdb, 0xe0,
+	0x06, 0x1a, 0xe2, 0xf5, 0xa0, 0xfb, 0x8c, 0xd0, 0x93, 0x24, 0xfa, 0x3e, 0x5c, 0x90, 0x2c, 0xd4,
+	0x9f, 0x1d, 0x68, 0xeb, 0xe8, 0x9a, 0x70, 0x89, 0x1e, 0x2e, 0x17, 0x10, 0x82, 0xda, 0x8a, 0x85,
+	0x24, 0x82, 0xe5, 0xbf, 0x59, 0xa8, 0xe7, 0x29, 0x49, 0x56, 0x5a, 0xa8, 0x99, 0x8c, 0x5c, 0x68,
+	0xcc, 0x13, 0xe2, 0x53, 0x12, 0xf0, 0x10, 0xab, 0xd3, 0x4c, 0xf4, 0xbe, 0x81, 0xfe, 0x8b, 0x38,
+	0xf0, 0x29, 0x31, 0x7d, 0x53, 0x27, 0x38, 0x6b, 0x4e, 0xa8, 0x58, 0x27, 0x28, 0x3f, 0xab, 0x7a,
+	0x67, 0xf5, 0x01, 0x59, 0xbb, 0xb3, 0x9a, 0x1f, 0x40, 0x6b, 0x1c, 0x04, 0x33, 0xe2, 0x2f, 0x37,
+	0x9c, 0xe6, 0xdd, 0x84, 0x5d, 0x65, 0x55, 0x92, 0x19, 0x6f, 0x1f, 0x5a, 0xcf, 0x08, 0xd5, 0x77,
+	0xb1, 0x2d, 0x7e, 0xad, 0xc0, 0xae, 0x32, 0x29, 0x4b, 0xae, 0x0b, 0x8d, 0xe8, 0x72, 0x45, 0x12,
+	0xd5, 0xd8, 0x99, 0x58, 0x9a, 0x60, 0x2d, 0x89, 0x35, 0x23, 0x89, 0xe8, 0x1e, 0x34, 0x96, 0x64,
+	0x79, 0x4a, 0x92, 0xd4, 0xad, 0xef, 0x57, 0x0f, 0x77, 0xee, 0x5f, 0x1f, 0xc5, 0xa7, 0x23, 0xfd,
+	0xe8, 0xd1, 0x73, 0xae, 0x9f, 0x66, 0x76, 0xac, 0xa5, 0x02, 0xad, 0xf1, 0x64, 0x4b, 0x69, 0x10,
+	0x3b, 0xee, 0x52, 0x36, 0x9c, 0xe8, 0xa7, 0x4c, 0x64, 0xcd, 0xee, 0x8b, 0x46, 0xdb, 0x16, 0xcd,
+	0x2e, 0x24, 0x3c, 0x82, 0x2d, 0x71, 0xcc, 0xd5, 0xba, 0xc8, 0x43, 0xd0, 0x39, 0x0e, 0x53, 0xee,
+	0x64, 0x9a, 0xf5, 0xe4, 0x07, 0xd0, 0xd2, 0x30, 0x96, 0xb4, 0x03, 0xa8, 0x2d, 0xc2, 0x94, 0xba,
+	0x0e, 0x8f, 0xac, 0x63, 0x47, 0x36, 0xe5, 0x5a, 0xef, 0x4f, 0x07, 0xba, 0xa2, 0xd4, 0x1b, 0x2a,
+	0xa2, 0xd2, 0x5a, 0xd1, 0xd2, 0x6a, 0x65, 0xa2, 0xba, 0x31, 0x13, 0xb5, 0x75, 0x99, 0x60, 0x6d,
+	0xbd, 0x9b, 0x65, 0x02, 0x79, 0xb0, 0x9b, 0x90, 0x65, 0x74, 0x41, 0xe4, 0x85, 0x64, 0xe9, 0xdd,
+	0x9e, 0x1a, 0x98, 0xf7, 0x0e, 0xb4, 0x75, 0x87, 0x59, 0xa8, 0xf9, 0x76, 0x8e, 0x9e, 0x58, 0xef,
+	0x16, 0x74, 0xa7, 0x7c, 0xe9, 0xa6, 0x6e, 0xeb, 0x42, 0x5b, 0x37, 0x62, 0x8d, 0xfe, 0x18, 0x7a,
+	0x93, 0xd5, 0x45, 0x48, 0xc9, 0x2c, 0xda, 0x94, 0x95, 0xf2, 0xea, 0xbc, 0x07, 0x5d, 0x73, 0x31,
+	0xf3, 0x10, 0xc3, 0x76, 0xc8, 0x41, 0xb5, 0x81, 0x92, 0x3d, 0x0f, 0x3a, 0xc7, 0xc4, 0xdf, 0xec,
+	0x64, 0x07, 0x5a, 0x9a, 0x0d, 0xf3, 0xf1, 0x0e, 0x74, 0xc7, 0x41, 0x70, 0x92, 0x44, 0x3f, 0x90,
+	0x39, 0xdd, 0x74, 0x1f, 0x1f, 0x43, 0x5b, 0x37, 0x5c, 0x73, 0x9f, 0x52, 0x1a, 0x25, 0x24, 0xbf,
+	0x4f, 0x52, 0x64, 0x19, 0x14, 0x2f, 0x9d, 0x7e, 0x8a, 0xed, 0xdc, 0x5f, 0xea, 0x3d, 0x5c, 0x7f,
+	0x44, 0x59, 0x07, 0x69, 0xc7, 0x56, 0x8d, 0x63, 0xd1, 0x01, 0xec, 0x5d, 0xfa, 0x8b, 0x05, 0xa1,
+	0xe3, 0x20, 0x48, 0x48, 0x9a, 0xca, 0xfe, 0x31, 0xc1, 0xdc, 0xea, 0x89, 0xbf, 0xf0, 0x57, 0x73,
+	0x22, 0xdf, 0x48, 0x13, 0xd4, 0xaf, 0xff, 0x96, 0xf9, 0x86, 0x0e, 0xa0, 0xc7, 0xee, 0x8c, 0xf4,
+	0x5b, 0x5d, 0xa5, 0x8f, 0xa0, 0x6b, 0xc2, 0x2c, 0x9e, 0x3b, 0xc6, 0x6d, 0xea, 0xc9, 0xdb, 0xa4,
+	0x87, 0x2c, 0x2f, 0xd4, 0x23, 0xed, 0x61, 0xde, 0x90, 0xb4, 0xb2, 0x84, 0x18, 0xcf, 0xae, 0xda,
+	0xd7, 0xbb, 0x0d, 0x7d, 0xd1, 0xa0, 0x6f, 0x29, 0x43, 0x1f, 0x90, 0x65, 0xc7, 0x56, 0x7f, 0x0d,
+	0x68, 0x1c, 0x04, 0xe3, 0x38, 0x9e, 0x45, 0xaf, 0x89, 0x22, 0x14, 0x37, 0xa0, 0x19, 0x0b, 0x2b,
+	0xb5, 0x45, 0x0e, 0xa0, 0xdb, 0xd0, 0xf2, 0x17, 0x8b, 0xe8, 0x92, 0x04, 0x5f, 0x24, 0xe1, 0x59,
+	0xb8, 0x4a, 0xdd, 0xca, 0x7e, 0xf5, 0xb0, 0x39, 0xb5, 0x50, 0xd6, 0xb9, 0xc6, 0xde, 0x65, 0xcf,
+	0xfd, 0xfb, 0xd0, 0x67, 0xd9, 0xcc, 0x8c, 0xd2, 0x2b, 0x79, 0xe0, 0x1d, 0x02, 0xb2, 0x56, 0xb1,
+	0xbd, 0x91, 0x56, 0x84, 0xa6, 0xcc, 0xf7, 0x1d, 0x18, 0x88, 0xa8, 0xed, 0x10, 0x6d, 0x47, 0x06,
+	0xd0, 0xb3, 0x0d, 0x59, 0x7e, 0x1e, 0x88, 0x26, 0x18, 0xc7, 0xf1, 0x8b, 0x94, 0x24, 0x57, 0x74,
+	0xef, 0x17, 0x07, 0xba, 0xe6, 0x2a, 0xe6, 0xde, 0x3d, 0xa3, 0x47, 0xfe, 0xcf, 0x7a, 0xa4, 0x60,
+	0x34, 0x92, 0x92, 0xf0, 0x1e, 0x3f, 0x87, 0x86, 0x04, 0xae, 0x7e, 0x29, 0xf5, 0x8e, 0xae, 0x9a,
+	0x1d, 0xad, 0x5a, 0x25, 0x3b, 0xe5, 0x6d, 0xad, 0xa2, 0xec, 0x58, 0x2a, 0x52, 0xfe, 0xa4, 0xbc,
+	0x24, 0xa7, 0xaf, 0xa2, 0xe8, 0xf5, 0xd5, 0x3a, 0xa5, 0x03, 0xd5, 0x17, 0xd3, 0x63, 0xe9, 0x20,
+	0xfb, 0xc9, 0xde, 0xe2, 0x94, 0xcc, 0x13, 0x42, 0x33, 0x46, 0x27, 0x24, 0x86, 0x93, 0x0b, 0xb2,
+	0xa2, 0xec, 0x2e, 0xb3, 0xea, 0x49, 0xc9, 0xfb, 0x10, 0xda, 0xfa, 0xa1, 0x65, 0x6f, 0x47, 0xbe,
+	0x65, 0x45, 0xdf, 0x32, 0x2b, 0x9d, 0x5c, 0x7b, 0xc5, 0xd2, 0xfd, 0x2e, 0x4b, 0x97, 0xaf, 0xda,
+	0x50, 0x3a, 0xc3, 0x68, 0x94, 0x39, 0x29, 0x4a, 0xf7, 0x2d, 0x34, 0x24, 0x50, 0x70, 0xb8, 0x34,
+	0x2b, 0x32, 0xfa, 0xaa, 0x1e, 0xfd, 0x7a, 0x6e, 0x92, 0x97, 0xd2, 0xaa, 0xc7, 0xda, 0x52, 0xea,
+	0x29, 0xf4, 0x0e, 0x00, 0xcd, 0x48, 0x4a, 0xdf, 0xb2, 0xf6, 0x5d, 0xe8, 0x18, 0x56, 0xf2, 0x5b,
+	0x9a, 0x52, 0x9f, 0x9e, 0xa7, 0xdc, 0xae, 0x3e, 0x95, 0x92, 0x77, 0x04, 0x43, 0xde, 0xcc, 0xe7,
+	0x41, 0x48, 0x9f, 0x72, 0xe7, 0xb5, 0xe1, 0x24, 0x0d, 0xd9, 0xf3, 0xeb, 0xf0, 0x08, 0x84, 0xc0,
+	0xd0, 0xf3, 0x15, 0x95, 0x1f, 0xc7, 0xea, 0x54, 0x08, 0xde, 0xbf, 0x0e, 0xf4, 0x0b, 0xdb, 0xb0,
+	0x63, 0x1f, 0x1a, 0x05, 0xb8, 0xa5, 0xee, 0x8e, 0x65, 0x37, 0xca, 0x01, 0x59, 0x86, 0x3f, 0x1c,
+	0x80, 0x1c, 0x2c, 0xbb, 0x45, 0xfe, 0x9c, 0x46, 0x1a, 0x55, 0x94, 0x22, 0x77, 0x7b, 0x1e, 0xc5,
+	0x19, 0x57, 0x14, 0x02, 0xa7, 0x12, 0x73, 0x4e, 0x68, 0x6a, 0x92, 0x4a, 0x70, 0x89, 0x0f, 0x24,
+	0x7e, 0x72, 0x46, 0x58, 0x4f, 0xd5, 0xe5, 0x40, 0x22, 0x65, 0x7e, 0xe6, 0x89, 0xa4, 0x82, 0x95,
+	0xc9, 0x89, 0x5e, 0xd4, 0x86, 0x51, 0xd4, 0xfb, 0x7f, 0xef, 0x41, 0x75, 0x7c, 0x32, 0x41, 0x77,
+	0xa1, 0xce, 0x27, 0x37, 0xc4, 0x69, 0x99, 0x3e, 0xea, 0xe1, 0x96, 0x86, 0xb0, 0x4a, 0x5e, 0x43,
+	0x23, 0xd8, 0x12, 0x93, 0x17, 0xea, 0x4a, 0x5d, 0x3e, 0x96, 0xe1, 0xb6, 0x0e, 0x29, 0x7b, 0x31,
+	0xc9, 0x09, 0x7b, 0x63, 0xcc, 0xc3, 0x6d, 0x1d, 0x52, 0xf6, 0x62, 0x70, 0x13, 0xf6, 0xc6, 0x58,
+	0x87, 0xdb, 0x3a, 0x24, 0xec, 0x1f, 0x01, 0xe4, 0xd3, 0x0f, 0x1a, 0xe4, 0x9f, 0x42, 0x6d, 0x0e,
+	0xc1, 0x3d, 0x1b, 0x16, 0x6b, 0x3f, 0x85, 0x3d, 0x63, 0xb0, 0x40, 0x2e, 0xb3, 0x2b, 0x9b, 0x64,
+	0xf0, 0xb0, 0x44, 0x23, 0x36, 0xb9, 0x07, 0x0d, 0x39, 0x61, 0x20, 0xc4, 0x8c, 0xcc, 0xa1, 0x04,
+	0x77, 0x0c, 0x4c, 0x2d, 0x91, 0xe4, 0x57, 0x2c, 0x31, 0x27, 0x10, 0x5c, 0x60, 0xc7, 0xde, 0x35,
+	0xf4, 0x10, 0x9a, 0x8a, 0x51, 0xa3, 0x7e, 0xd6, 0x90, 0x3a, 0xe9, 0xc6, 0xc8, 0x42, 0x55, 0x7e,
+	0x72, 0x82, 0x2a, 0xf2, 0x53, 0x60, 0xd8, 0xb8, 0x67, 0xc3, 0x6a, 0x6d, 0x4e, 0x46, 0xc5, 0xda,
+	0x02, 0x83, 0xc5, 0x3d, 0x1b, 0x16, 0x6b, 0x3f, 0x81, 0x5d, 0x9d, 0x78, 0x22, 0x3e, 0xcc, 0x94,
+	0xf0, 0x58, 0x3c, 0x28, 0x2a, 0xf2, 0x90, 0x33, 0x96, 0x29, 0x43, 0xb6, 0x88, 0x29, 0x46, 0x16,
+	0xaa, 0xdc, 0xce, 0x39, 0xa6, 0x70, 0xbb, 0x40, 0x4e, 0x71, 0xcf, 0x86, 0xad, 0x76, 0xca, 0xd7,
+	0x16, 0x28, 0x27, 0x2e, 0x23, 0x5c, 0x22, 0x64, 0x9d, 0xaa, 0x89, 0x90, 0x4b, 0x38, 0x1d, 0x1e,
+	0x14, 0x15, 0xc5, 0x86, 0xe4, 0x0e, 0x98, 0x0d, 0xa9, 0xfb, 0x30, 0x2c, 0xd1, 0xa8, 0x4d, 0x0c,
+	0xe6, 0x25, 0x36, 0x29, 0x23, 0x6d, 0x78, 0x58, 0xa2, 0x11, 0x9b, 0x7c, 0x0c, 0x3b, 0x1a, 0x99,
+	0x42, 0x43, 0x99, 0x2d, 0x8b, 0xd6, 0xe0, 0x7e, 0x01, 0x57, 0x3e, 0x18, 0x8c, 0x49, 0xf8, 0x50,
+	0x46, 0xbd, 0xf0, 0xb0, 0x44, 0x23, 0x36, 0xf9, 0x0c, 0x5a, 0x26, 0x47, 0x42, 0xff, 0xcb, 0xfd,
+	0xb5, 0x3d, 0xb9, 0x5e, 0xa6, 0x32, 0xea, 0x92, 0x31, 0x9f, 0xbc, 0x2e, 0x16, 0xcd, 0xc2, 0x83,
+	0xa2, 0xc2, 0x4a, 0xa9, 0x54, 0xe8, 0x29, 0x35, 0xc9, 0x0d, 0x1e, 0x96, 0x68, 0xf4, 0xb6, 0xcc,
+	0xbe, 0xd2, 0x59, 0x5b, 0x9a, 0x1f, 0x45, 0xdc, 0xb3, 0x61, 0x23, 0x04, 0x89, 0x6a, 0x21, 0x58,
+	0x74, 0x03, 0x0f, 0x8a, 0x0a, 0x2b, 0x84, 0xcc, 0x01, 0x2d, 0x04, 0xcb, 0x87, 0x61, 0x89, 0x46,
+	0x75, 0x85, 0xf6, 0x89, 0x16, 0x5d, 0x51, 0xfc, 0xb2, 0xe3, 0x7e, 0x01, 0x17, 0xcb, 0x27, 0xd0,
+	0xb6, 0x3e, 0xa3, 0x08, 0x97, 0x7e, 0x5b, 0xc5, 0x36, 0xee, 0xba, 0xef, 0xae, 0x77, 0xed, 0xc9,
+	0x5d, 0xb8, 0x1e, 0x46, 0x23, 0x4a, 0x7e, 0xa2, 0xe1, 0x82, 0x64, 0x7f, 0xbf, 0x3b, 0x4b, 0xe2,
+	0xf9, 0x93, 0xc6, 0x4c, 0x48, 0x27, 0xce, 0x6f, 0x95, 0xda, 0xec, 0xab, 0xd9, 0xf1, 0xe9, 0x16,
+	0xff, 0x5f, 0xe6, 0x83, 0xff, 0x06, 0x00, 0xbf, 0xdc, 0x71, 0xd0, 0xd8, 0x14, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1546,19 +2828,30 @@ type APIClient interface {
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutReply, error)
 	Switch(ctx context.Context, in *SwitchRequest, opts ...grpc.CallOption) (*SwitchReply, error)
 	Whoami(ctx context.Context, in *WhoamiRequest, opts ...grpc.CallOption) (*WhoamiReply, error)
+	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*GetProfileReply, error)
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileReply, error)
 	AddTeam(ctx context.Context, in *AddTeamRequest, opts ...grpc.CallOption) (*AddTeamReply, error)
 	GetTeam(ctx context.Context, in *GetTeamRequest, opts ...grpc.CallOption) (*GetTeamReply, error)
 	ListTeams(ctx context.Context, in *ListTeamsRequest, opts ...grpc.CallOption) (*ListTeamsReply, error)
+	UpdateTeam(ctx context.Context, in *UpdateTeamRequest, opts ...grpc.CallOption) (*UpdateTeamReply, error)
 	RemoveTeam(ctx context.Context, in *RemoveTeamRequest, opts ...grpc.CallOption) (*RemoveTeamReply, error)
 	InviteToTeam(ctx context.Context, in *InviteToTeamRequest, opts ...grpc.CallOption) (*InviteToTeamReply, error)
 	LeaveTeam(ctx context.Context, in *LeaveTeamRequest, opts ...grpc.CallOption) (*LeaveTeamReply, error)
 	AddProject(ctx context.Context, in *AddProjectRequest, opts ...grpc.CallOption) (*AddProjectReply, error)
 	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectReply, error)
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsReply, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectReply, error)
 	RemoveProject(ctx context.Context, in *RemoveProjectRequest, opts ...grpc.CallOption) (*RemoveProjectReply, error)
 	AddAppToken(ctx context.Context, in *AddAppTokenRequest, opts ...grpc.CallOption) (*AddAppTokenReply, error)
 	ListAppTokens(ctx context.Context, in *ListAppTokensRequest, opts ...grpc.CallOption) (*ListAppTokensReply, error)
 	RemoveAppToken(ctx context.Context, in *RemoveAppTokenRequest, opts ...grpc.CallOption) (*RemoveAppTokenReply, error)
+	ListAppUsers(ctx context.Context, in *ListAppUsersRequest, opts ...grpc.CallOption) (*ListAppUsersReply, error)
+	RemoveAppUser(ctx context.Context, in *RemoveAppUserRequest, opts ...grpc.CallOption) (*RemoveAppUserReply, error)
+	AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*AddWebhookReply, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksReply, error)
+	RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*RemoveWebhookReply, error)
+	TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookReply, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsReply, error)
 }
 
 type aPIClient struct {
@@ -1605,6 +2898,24 @@ func (c *aPIClient) Whoami(ctx context.Context, in *WhoamiRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *aPIClient) GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*GetProfileReply, error) {
+	out := new(GetProfileReply)
+	err := c.cc.Invoke(ctx, "/pb.API/GetProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileReply, error) {
+	out := new(UpdateProfileReply)
+	err := c.cc.Invoke(ctx, "/pb.API/UpdateProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) AddTeam(ctx context.Context, in *AddTeamRequest, opts ...grpc.CallOption) (*AddTeamReply, error) {
 	out := new(AddTeamReply)
 	err := c.cc.Invoke(ctx, "/pb.API/AddTeam", in, out, opts...)
@@ -1632,6 +2943,15 @@ func (c *aPIClient) ListTeams(ctx context.Context, in *ListTeamsRequest, opts ..
 	return out, nil
 }
 
+func (c *aPIClient) UpdateTeam(ctx context.Context, in *UpdateTeamRequest, opts ...grpc.CallOption) (*UpdateTeamReply, error) {
+	out := new(UpdateTeamReply)
+	err := c.cc.Invoke(ctx, "/pb.API/UpdateTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) RemoveTeam(ctx context.Context, in *RemoveTeamRequest, opts ...grpc.CallOption) (*RemoveTeamReply, error) {
 	out := new(RemoveTeamReply)
 	err := c.cc.Invoke(ctx, "/pb.API/RemoveTeam", in, out, opts...)
@@ -1686,6 +3006,15 @@ func (c *aPIClient) ListProjects(ctx context.Context, in *ListProjectsRequest, o
 	return out, nil
 }
 
+func (c *aPIClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectReply, error) {
+	out := new(UpdateProjectReply)
+	err := c.cc.Invoke(ctx, "/pb.API/UpdateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) RemoveProject(ctx context.Context, in *RemoveProjectRequest, opts ...grpc.CallOption) (*RemoveProjectReply, error) {
 	out := new(RemoveProjectReply)
 	err := c.cc.Invoke(ctx, "/pb.API/RemoveProject", in, out, opts...)
@@ -1722,25 +3051,99 @@ func (c *aPIClient) RemoveAppToken(ctx context.Context, in *RemoveAppTokenReques
 	return out, nil
 }
 
+func (c *aPIClient) ListAppUsers(ctx context.Context, in *ListAppUsersRequest, opts ...grpc.CallOption) (*ListAppUsersReply, error) {
+	out := new(ListAppUsersReply)
+	err := c.cc.Invoke(ctx, "/pb.API/ListAppUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RemoveAppUser(ctx context.Context, in *RemoveAppUserRequest, opts ...grpc.CallOption) (*RemoveAppUserReply, error) {
+	out := new(RemoveAppUserReply)
+	err := c.cc.Invoke(ctx, "/pb.API/RemoveAppUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*AddWebhookReply, error) {
+	out := new(AddWebhookReply)
+	err := c.cc.Invoke(ctx, "/pb.API/AddWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksReply, error) {
+	out := new(ListWebhooksReply)
+	err := c.cc.Invoke(ctx, "/pb.API/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*RemoveWebhookReply, error) {
+	out := new(RemoveWebhookReply)
+	err := c.cc.Invoke(ctx, "/pb.API/RemoveWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookReply, error) {
+	out := new(TestWebhookReply)
+	err := c.cc.Invoke(ctx, "/pb.API/TestWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsReply, error) {
+	out := new(ListAuditEventsReply)
+	err := c.cc.Invoke(ctx, "/pb.API/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServer is the server API for API service.
 type APIServer interface {
 	Login(context.Context, *LoginRequest) (*LoginReply, error)
 	Logout(context.Context, *LogoutRequest) (*LogoutReply, error)
 	Switch(context.Context, *SwitchRequest) (*SwitchReply, error)
 	Whoami(context.Context, *WhoamiRequest) (*WhoamiReply, error)
+	GetProfile(context.Context, *GetProfileRequest) (*GetProfileReply, error)
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileReply, error)
 	AddTeam(context.Context, *AddTeamRequest) (*AddTeamReply, error)
 	GetTeam(context.Context, *GetTeamRequest) (*GetTeamReply, error)
 	ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsReply, error)
+	UpdateTeam(context.Context, *UpdateTeamRequest) (*UpdateTeamReply, error)
 	RemoveTeam(context.Context, *RemoveTeamRequest) (*RemoveTeamReply, error)
 	InviteToTeam(context.Context, *InviteToTeamRequest) (*InviteToTeamReply, error)
 	LeaveTeam(context.Context, *LeaveTeamRequest) (*LeaveTeamReply, error)
 	AddProject(context.Context, *AddProjectRequest) (*AddProjectReply, error)
 	GetProject(context.Context, *GetProjectRequest) (*GetProjectReply, error)
 	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsReply, error)
+	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectReply, error)
 	RemoveProject(context.Context, *RemoveProjectRequest) (*RemoveProjectReply, error)
 	AddAppToken(context.Context, *AddAppTokenRequest) (*AddAppTokenReply, error)
 	ListAppTokens(context.Context, *ListAppTokensRequest) (*ListAppTokensReply, error)
 	RemoveAppToken(context.Context, *RemoveAppTokenRequest) (*RemoveAppTokenReply, error)
+	ListAppUsers(context.Context, *ListAppUsersRequest) (*ListAppUsersReply, error)
+	RemoveAppUser(context.Context, *RemoveAppUserRequest) (*RemoveAppUserReply, error)
+	AddWebhook(context.Context, *AddWebhookRequest) (*AddWebhookReply, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksReply, error)
+	RemoveWebhook(context.Context, *RemoveWebhookRequest) (*RemoveWebhookReply, error)
+	TestWebhook(context.Context, *TestWebhookRequest) (*TestWebhookReply, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsReply, error)
 }
 
 // UnimplementedAPIServer can be embedded to have forward compatible implementations.
@@ -1759,6 +3162,12 @@ func (*UnimplementedAPIServer) Switch(ctx context.Context, req *SwitchRequest) (
 func (*UnimplementedAPIServer) Whoami(ctx context.Context, req *WhoamiRequest) (*WhoamiReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Whoami not implemented")
 }
+func (*UnimplementedAPIServer) GetProfile(ctx context.Context, req *GetProfileRequest) (*GetProfileReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (*UnimplementedAPIServer) UpdateProfile(ctx context.Context, req *UpdateProfileRequest) (*UpdateProfileReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
+}
 func (*UnimplementedAPIServer) AddTeam(ctx context.Context, req *AddTeamRequest) (*AddTeamReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTeam not implemented")
 }
@@ -1768,6 +3177,9 @@ func (*UnimplementedAPIServer) GetTeam(ctx context.Context, req *GetTeamRequest)
 func (*UnimplementedAPIServer) ListTeams(ctx context.Context, req *ListTeamsRequest) (*ListTeamsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTeams not implemented")
 }
+func (*UnimplementedAPIServer) UpdateTeam(ctx context.Context, req *UpdateTeamRequest) (*UpdateTeamReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTeam not implemented")
+}
 func (*UnimplementedAPIServer) RemoveTeam(ctx context.Context, req *RemoveTeamRequest) (*RemoveTeamReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTeam not implemented")
 }
@@ -1786,6 +3198,9 @@ func (*UnimplementedAPIServer) GetProject(ctx context.Context, req *GetProjectRe
 func (*UnimplementedAPIServer) ListProjects(ctx context.Context, req *ListProjectsRequest) (*ListProjectsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
 }
+func (*UnimplementedAPIServer) UpdateProject(ctx context.Context, req *UpdateProjectRequest) (*UpdateProjectReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
 func (*UnimplementedAPIServer) RemoveProject(ctx context.Context, req *RemoveProjectRequest) (*RemoveProjectReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveProject not implemented")
 }
@@ -1798,6 +3213,27 @@ func (*UnimplementedAPIServer) ListAppTokens(ctx context.Context, req *ListAppTo
 func (*UnimplementedAPIServer) RemoveAppToken(ctx context.Context, req *RemoveAppTokenRequest) (*RemoveAppTokenReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveAppToken not implemented")
 }
+func (*UnimplementedAPIServer) ListAppUsers(ctx context.Context, req *ListAppUsersRequest) (*ListAppUsersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAppUsers not implemented")
+}
+func (*UnimplementedAPIServer) RemoveAppUser(ctx context.Context, req *RemoveAppUserRequest) (*RemoveAppUserReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAppUser not implemented")
+}
+func (*UnimplementedAPIServer) AddWebhook(ctx context.Context, req *AddWebhookRequest) (*AddWebhookReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWebhook not implemented")
+}
+func (*UnimplementedAPIServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (*UnimplementedAPIServer) RemoveWebhook(ctx context.Context, req *RemoveWebhookRequest) (*RemoveWebhookReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWebhook not implemented")
+}
+func (*UnimplementedAPIServer) TestWebhook(ctx context.Context, req *TestWebhookRequest) (*TestWebhookReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestWebhook not implemented")
+}
+func (*UnimplementedAPIServer) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 
 func RegisterAPIServer(s *grpc.Server, srv APIServer) {
 	s.RegisterService(&_API_serviceDesc, srv)
@@ -1875,6 +3311,42 @@ func _API_Whoami_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/GetProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetProfile(ctx, req.(*GetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/UpdateProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_AddTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddTeamRequest)
 	if err := dec(in); err != nil {
@@ -1929,6 +3401,24 @@ func _API_ListTeams_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_UpdateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/UpdateTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateTeam(ctx, req.(*UpdateTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_RemoveTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveTeamRequest)
 	if err := dec(in); err != nil {
@@ -2037,6 +3527,24 @@ func _API_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/UpdateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_RemoveProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveProjectRequest)
 	if err := dec(in); err != nil {
@@ -2109,6 +3617,132 @@ func _API_RemoveAppToken_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_ListAppUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListAppUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/ListAppUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListAppUsers(ctx, req.(*ListAppUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RemoveAppUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAppUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RemoveAppUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/RemoveAppUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RemoveAppUser(ctx, req.(*RemoveAppUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AddWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AddWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/AddWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AddWebhook(ctx, req.(*AddWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RemoveWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RemoveWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/RemoveWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RemoveWebhook(ctx, req.(*RemoveWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_TestWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).TestWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/TestWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).TestWebhook(ctx, req.(*TestWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.API/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _API_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.API",
 	HandlerType: (*APIServer)(nil),
@@ -2129,6 +3763,14 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Whoami",
 			Handler:    _API_Whoami_Handler,
 		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _API_GetProfile_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _API_UpdateProfile_Handler,
+		},
 		{
 			MethodName: "AddTeam",
 			Handler:    _API_AddTeam_Handler,
@@ -2141,6 +3783,10 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListTeams",
 			Handler:    _API_ListTeams_Handler,
 		},
+		{
+			MethodName: "UpdateTeam",
+			Handler:    _API_UpdateTeam_Handler,
+		},
 		{
 			MethodName: "RemoveTeam",
 			Handler:    _API_RemoveTeam_Handler,
@@ -2165,6 +3811,10 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListProjects",
 			Handler:    _API_ListProjects_Handler,
 		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _API_UpdateProject_Handler,
+		},
 		{
 			MethodName: "RemoveProject",
 			Handler:    _API_RemoveProject_Handler,
@@ -2181,6 +3831,34 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveAppToken",
 			Handler:    _API_RemoveAppToken_Handler,
 		},
+		{
+			MethodName: "ListAppUsers",
+			Handler:    _API_ListAppUsers_Handler,
+		},
+		{
+			MethodName: "RemoveAppUser",
+			Handler:    _API_RemoveAppUser_Handler,
+		},
+		{
+			MethodName: "AddWebhook",
+			Handler:    _API_AddWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _API_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "RemoveWebhook",
+			Handler:    _API_RemoveWebhook_Handler,
+		},
+		{
+			MethodName: "TestWebhook",
+			Handler:    _API_TestWebhook_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _API_ListAuditEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/rs/cors"
+	"github.com/textileio/textile/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// restRoute maps an HTTP method and path to an API method. The path's :ID
+// parameter, if any, is copied into the request field named param.
+type restRoute struct {
+	method string
+	path   string
+	rpc    string
+	param  string
+}
+
+// restRoutes is the REST/JSON mapping of the API service.
+var restRoutes = []restRoute{
+	{http.MethodPost, "/v1/login", "Login", ""},
+	{http.MethodPost, "/v1/logout", "Logout", ""},
+	{http.MethodPost, "/v1/switch", "Switch", ""},
+	{http.MethodGet, "/v1/whoami", "Whoami", ""},
+	{http.MethodGet, "/v1/profile", "GetProfile", ""},
+	{http.MethodPut, "/v1/profile", "UpdateProfile", ""},
+
+	{http.MethodPost, "/v1/teams", "AddTeam", ""},
+	{http.MethodGet, "/v1/teams", "ListTeams", ""},
+	{http.MethodGet, "/v1/teams/:ID", "GetTeam", "ID"},
+	{http.MethodPut, "/v1/teams/:ID", "UpdateTeam", "ID"},
+	{http.MethodDelete, "/v1/teams/:ID", "RemoveTeam", "ID"},
+	{http.MethodPost, "/v1/teams/:ID/invites", "InviteToTeam", "ID"},
+	{http.MethodPost, "/v1/teams/:ID/leave", "LeaveTeam", "ID"},
+
+	{http.MethodPost, "/v1/projects", "AddProject", ""},
+	{http.MethodGet, "/v1/projects", "ListProjects", ""},
+	{http.MethodGet, "/v1/projects/:ID", "GetProject", "ID"},
+	{http.MethodPatch, "/v1/projects/:ID", "UpdateProject", "ID"},
+	{http.MethodDelete, "/v1/projects/:ID", "RemoveProject", "ID"},
+	{http.MethodPost, "/v1/projects/:ID/tokens", "AddAppToken", "projectID"},
+	{http.MethodGet, "/v1/projects/:ID/tokens", "ListAppTokens", "projectID"},
+	{http.MethodDelete, "/v1/tokens/:ID", "RemoveAppToken", "ID"},
+	{http.MethodGet, "/v1/projects/:ID/users", "ListAppUsers", "projectID"},
+	{http.MethodDelete, "/v1/users/:ID", "RemoveAppUser", "ID"},
+	{http.MethodPost, "/v1/projects/:ID/webhooks", "AddWebhook", "projectID"},
+	{http.MethodGet, "/v1/projects/:ID/webhooks", "ListWebhooks", "projectID"},
+	{http.MethodDelete, "/v1/webhooks/:ID", "RemoveWebhook", "ID"},
+	{http.MethodPost, "/v1/webhooks/:ID/test", "TestWebhook", "ID"},
+
+	{http.MethodGet, "/v1/audit", "ListAuditEvents", ""},
+}
+
+var jsonMarshaler = &jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+
+// proxyHandler returns a handler serving the API over gRPC-Web and REST/JSON.
+// Requests are authenticated by the Authorization and X-Scope headers and pass
+// through the same interceptors as gRPC requests.
+func (s *Server) proxyHandler(allowedOrigins []string) http.Handler {
+	allow := func(origin string) bool {
+		return allowOrigin(allowedOrigins, origin)
+	}
+	web := grpcweb.WrapServer(
+		s.rpc,
+		grpcweb.WithOriginFunc(allow),
+		grpcweb.WithWebsockets(true),
+		grpcweb.WithWebsocketOriginFunc(func(r *http.Request) bool {
+			return allow(r.Header.Get("Origin"))
+		}))
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	for _, r := range restRoutes {
+		router.Handle(r.method, r.path, s.restHandler(r))
+	}
+	rest := cors.New(cors.Options{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders: []string{"Authorization", "X-Scope", "Content-Type", "Traceparent"},
+		ExposedHeaders: []string{"X-Request-Id"},
+	}).Handler(router)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.IsGrpcWebRequest(r) ||
+			web.IsAcceptableGrpcCorsRequest(r) ||
+			web.IsGrpcWebSocketRequest(r) {
+			web.ServeHTTP(w, r)
+			return
+		}
+		rest.ServeHTTP(w, r)
+	})
+}
+
+// restHandler returns a handler that decodes a JSON request for the route's
+// API method, invokes it, and encodes the JSON reply.
+func (s *Server) restHandler(route restRoute) gin.HandlerFunc {
+	method := reflect.ValueOf(s.service).MethodByName(route.rpc)
+	reqType := method.Type().In(1).Elem()
+	fullMethod := "/pb.API/" + route.rpc
+
+	return func(c *gin.Context) {
+		req := reflect.New(reqType).Interface().(proto.Message)
+		if err := decodeRequest(c, route.param, req); err != nil {
+			restError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		ctx := restContext(c.Writer, c.Request, fullMethod)
+		reply, err := s.unary(ctx, req, &grpc.UnaryServerInfo{
+			Server:     s.service,
+			FullMethod: fullMethod,
+		}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			out := method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(req)})
+			if err, _ := out[1].Interface().(error); err != nil {
+				return nil, err
+			}
+			return out[0].Interface(), nil
+		})
+		if err != nil {
+			restError(c, err)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err = jsonMarshaler.Marshal(&buf, reply.(proto.Message)); err != nil {
+			restError(c, status.Error(codes.Internal, err.Error()))
+			return
+		}
+		c.Data(http.StatusOK, "application/json", buf.Bytes())
+	}
+}
+
+// decodeRequest unmarshals the JSON body, if any, the query params, and the path param into req.
+func decodeRequest(c *gin.Context, param string, req proto.Message) error {
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err = json.Unmarshal(body, &fields); err != nil {
+			return err
+		}
+	}
+	for k, v := range c.Request.URL.Query() {
+		value, err := json.Marshal(v[0])
+		if err != nil {
+			return err
+		}
+		fields[k] = value
+	}
+	if param != "" {
+		value, err := json.Marshal(c.Param("ID"))
+		if err != nil {
+			return err
+		}
+		fields[param] = value
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return jsonpb.Unmarshal(bytes.NewReader(data), req)
+}
+
+// restContext returns a context that looks like an incoming gRPC call of
+// method, carrying the request's auth and trace headers and client address.
+// Header metadata set by the call is written to w's headers.
+func restContext(w http.ResponseWriter, r *http.Request, method string) context.Context {
+	md := metadata.MD{}
+	if v := r.Header.Get("Authorization"); v != "" {
+		md.Set("authorization", v)
+	}
+	if v := r.Header.Get("X-Scope"); v != "" {
+		md.Set("x-scope", v)
+	}
+	if v := r.Header.Get(tracing.TraceparentHeader); v != "" {
+		md.Set(tracing.TraceparentHeader, v)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	return grpc.NewContextWithServerTransportStream(ctx, &restStream{method: method, header: w.Header()})
+}
+
+// restStream allows grpc.Method to report the method of a REST request, and
+// grpc.SetHeader to set response headers.
+type restStream struct {
+	method string
+	header http.Header
+}
+
+func (s *restStream) Method() string { return s.method }
+
+func (s *restStream) SetHeader(md metadata.MD) error {
+	for k, v := range md {
+		for _, vv := range v {
+			s.header.Add(k, vv)
+		}
+	}
+	return nil
+}
+
+func (s *restStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+func (s *restStream) SetTrailer(metadata.MD) error    { return nil }
+
+// restError writes a gRPC error as JSON with the corresponding HTTP status.
+func restError(c *gin.Context, err error) {
+	st := status.Convert(err)
+	c.AbortWithStatusJSON(httpStatusFromCode(st.Code()), gin.H{
+		"code":    st.Code(),
+		"message": st.Message(),
+	})
+}
+
+// httpStatusFromCode maps gRPC codes to HTTP statuses like grpc-gateway.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// proxyOrigins returns the origins allowed to call the proxy from a browser.
+// These are the gateway's allowed origins plus the gateway itself, which
+// serves the dashboard.
+func proxyOrigins(conf Config) []string {
+	origins := conf.GatewayCORS.AllowedOrigins
+	if len(origins) == 0 || conf.AddrGatewayUrl == "" {
+		return origins
+	}
+	return append([]string{strings.TrimSuffix(conf.AddrGatewayUrl, "/")}, origins...)
+}
+
+// allowOrigin returns whether or not origin is in allowed, which may contain
+// "*". All origins are allowed if allowed is empty.
+func allowOrigin(allowed []string, origin string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, o := range allowed {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
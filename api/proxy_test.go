@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_proxy(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	owner, ownerSession := addUser(t, cols, "owner@doe.com")
+	team := addTeam(t, cols, owner)
+	stranger, _ := addUser(t, cols, "stranger@doe.com")
+	foreignTeam := addTeam(t, cols, stranger)
+	if _, err := cols.Projects.Create(context.Background(), "foo", team.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	proxy := httptest.NewServer(s.proxyHandler(nil))
+	defer proxy.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		token  string
+		scope  string
+		code   int
+		count  int
+	}{
+		{name: "list projects without token", method: "GET", path: "/v1/projects", code: http.StatusUnauthorized},
+		{name: "list user projects", method: "GET", path: "/v1/projects", token: ownerSession.ID, code: http.StatusOK},
+		{name: "list team projects", method: "GET", path: "/v1/projects", token: ownerSession.ID, scope: team.ID, code: http.StatusOK, count: 1},
+		{name: "get foreign team", method: "GET", path: "/v1/teams/" + foreignTeam.ID, token: ownerSession.ID, code: http.StatusForbidden},
+		{name: "add team with bad body", method: "POST", path: "/v1/teams", body: "{", token: ownerSession.ID, code: http.StatusBadRequest},
+		{name: "replace team", method: "PUT", path: "/v1/teams/" + team.ID, body: `{"name":"bar"}`, token: ownerSession.ID, code: http.StatusOK},
+		{name: "patch team", method: "PATCH", path: "/v1/teams/" + team.ID, body: `{"name":"bar"}`, token: ownerSession.ID, code: http.StatusNotFound},
+		{name: "list audit events", method: "GET", path: "/v1/audit?since=1", token: ownerSession.ID, code: http.StatusOK},
+		{name: "list audit events with bad query", method: "GET", path: "/v1/audit?since=foo", token: ownerSession.ID, code: http.StatusBadRequest},
+		{name: "list audit events in bad range", method: "GET", path: "/v1/audit?since=20&until=10", token: ownerSession.ID, code: http.StatusBadRequest},
+		{name: "replace profile", method: "PUT", path: "/v1/profile", body: `{"name":"Owner","username":"owner"}`, token: ownerSession.ID, code: http.StatusOK},
+		{name: "patch profile", method: "PATCH", path: "/v1/profile", body: `{"name":"Owner"}`, token: ownerSession.ID, code: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run("test "+test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, proxy.URL+test.path, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			if test.scope != "" {
+				req.Header.Set("X-Scope", test.scope)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != test.code {
+				t.Fatalf("expected status %d, got %d", test.code, res.StatusCode)
+			}
+			if test.code != http.StatusOK {
+				return
+			}
+			var reply struct {
+				List []json.RawMessage `json:"list"`
+			}
+			if err = json.NewDecoder(res.Body).Decode(&reply); err != nil {
+				t.Fatal(err)
+			}
+			if len(reply.List) != test.count {
+				t.Fatalf("expected %d projects, got %d", test.count, len(reply.List))
+			}
+		})
+	}
+	// The partial patch must not have cleared the username.
+	got, err := cols.Users.Get(context.Background(), owner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Owner" || got.Username != "owner" {
+		t.Fatal("got bad profile")
+	}
+}
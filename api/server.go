@@ -2,12 +2,16 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
+	"net/http"
 	"time"
 
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	logging "github.com/ipfs/go-log"
+	iface "github.com/ipfs/interface-go-ipfs-core"
 	ma "github.com/multiformats/go-multiaddr"
 	fc "github.com/textileio/filecoin/api/client"
 	"github.com/textileio/go-threads/util"
@@ -16,8 +20,13 @@ import (
 	"github.com/textileio/textile/dns"
 	"github.com/textileio/textile/email"
 	"github.com/textileio/textile/gateway"
+	"github.com/textileio/textile/metrics"
+	"github.com/textileio/textile/ratelimit"
+	"github.com/textileio/textile/tracing"
+	"github.com/textileio/textile/webhooks"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 )
 
@@ -29,13 +38,30 @@ var (
 	}
 )
 
+const (
+	// statsInterval is how often the instance count gauges are refreshed.
+	// Counting may load every instance, so it's not done for each scrape.
+	statsInterval = time.Minute
+	// statsTimeout limits collecting instance counts.
+	statsTimeout = time.Second * 10
+)
+
 // reqKey provides a concrete type for request context values.
 type reqKey string
 
 // Server provides a gRPC API to the textile daemon.
 type Server struct {
 	rpc     *grpc.Server
+	unary   grpc.UnaryServerInterceptor
+	proxy   *http.Server
+	metrics *http.Server
 	service *service
+	started bool
+
+	statsDone chan struct{}
+
+	ipLimiter    *ratelimit.Limiter
+	emailLimiter *ratelimit.Limiter
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -43,15 +69,35 @@ type Server struct {
 
 // Config specifies server settings.
 type Config struct {
-	Addr            ma.Multiaddr
+	Addr ma.Multiaddr
+	// AddrProxy serves the API over gRPC-Web and REST/JSON if not nil.
+	AddrProxy       ma.Multiaddr
+	AddrProxyUrl    string
 	AddrGatewayHost ma.Multiaddr
 	AddrGatewayUrl  string
+	// AddrMetrics serves Prometheus metrics at /metrics if not nil.
+	AddrMetrics ma.Multiaddr
 
 	Collections *c.Collections
 
+	IPFSClient iface.CoreAPI
+	Stores     gateway.Stores
+
 	EmailClient    *email.Client
 	FilecoinClient *fc.Client
 	DNSManager     *dns.Manager
+	DNSDomain      string
+
+	GatewayCORS    gateway.CORSConfig
+	GatewayHeaders gateway.HeadersConfig
+
+	RateLimits ratelimit.Config
+
+	// Webhooks specifies how webhook deliveries are retried.
+	Webhooks webhooks.Config
+
+	// TLSConfig enables TLS for the API and the gateway if not nil.
+	TLSConfig *tls.Config
 
 	SessionSecret string
 
@@ -60,32 +106,10 @@ type Config struct {
 
 // NewServer starts and returns a new server.
 func NewServer(ctx context.Context, conf Config) (*Server, error) {
-	var err error
-	if conf.Debug {
-		err = util.SetLogLevels(map[string]logging.LogLevel{
-			"textileapi": logging.LevelDebug,
-		})
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	ctx, cancel := context.WithCancel(ctx)
-	s := &Server{
-		service: &service{
-			collections:    conf.Collections,
-			gateway:        gateway.NewGateway(conf.AddrGatewayHost, conf.AddrGatewayUrl, conf.Collections),
-			emailClient:    conf.EmailClient,
-			filecoinClient: conf.FilecoinClient,
-			sessionSecret:  conf.SessionSecret,
-		},
-		ctx:    ctx,
-		cancel: cancel,
+	s, err := NewUnstartedServer(ctx, conf)
+	if err != nil {
+		return nil, err
 	}
-	s.rpc = grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryServerInterceptor(s.authFunc)),
-		grpc.StreamInterceptor(auth.StreamServerInterceptor(s.authFunc)),
-	)
 
 	addr, err := util.TCPAddrFromMultiAddr(conf.Addr)
 	if err != nil {
@@ -96,22 +120,146 @@ func NewServer(ctx context.Context, conf Config) (*Server, error) {
 		return nil, err
 	}
 	go func() {
-		pb.RegisterAPIServer(s.rpc, s.service)
 		if err := s.rpc.Serve(listener); err != nil {
 			log.Errorf("error registering server: %v", err)
 		}
 	}()
 
+	if conf.AddrProxy != nil {
+		proxyAddr, err := util.TCPAddrFromMultiAddr(conf.AddrProxy)
+		if err != nil {
+			return nil, err
+		}
+		s.proxy = &http.Server{
+			Addr:      proxyAddr,
+			Handler:   s.proxyHandler(proxyOrigins(conf)),
+			TLSConfig: conf.TLSConfig,
+		}
+		go func() {
+			var err error
+			if s.proxy.TLSConfig != nil {
+				err = s.proxy.ListenAndServeTLS("", "")
+			} else {
+				err = s.proxy.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
+				log.Errorf("proxy error: %v", err)
+			}
+		}()
+	}
+
+	if conf.AddrMetrics != nil {
+		metricsAddr, err := util.TCPAddrFromMultiAddr(conf.AddrMetrics)
+		if err != nil {
+			return nil, err
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		s.metrics = &http.Server{
+			Addr:    metricsAddr,
+			Handler: mux,
+		}
+		go func() {
+			if err := s.metrics.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Errorf("metrics error: %v", err)
+			}
+		}()
+		s.statsDone = make(chan struct{})
+		go s.refreshStatsLoop()
+	}
+
 	s.service.gateway.Start()
+	s.service.webhooks.Start()
+	s.started = true
+
+	return s, nil
+}
+
+// NewUnstartedServer returns a new server that is not listening for requests
+// and whose gateway is not started. This is useful for exercising the service
+// directly, e.g., backed by in-memory collections.
+func NewUnstartedServer(ctx context.Context, conf Config) (*Server, error) {
+	if conf.Debug {
+		if err := util.SetLogLevels(map[string]logging.LogLevel{
+			"textileapi": logging.LevelDebug,
+		}); err != nil {
+			return nil, err
+		}
+	}
 
+	hooks := webhooks.NewDispatcher(conf.Collections, conf.Webhooks)
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Server{
+		service: &service{
+			collections: conf.Collections,
+			gateway: gateway.NewGateway(gateway.Config{
+				Addr:        conf.AddrGatewayHost,
+				Url:         conf.AddrGatewayUrl,
+				Collections: conf.Collections,
+				IPFSClient:  conf.IPFSClient,
+				Stores:      conf.Stores,
+				DNSDomain:   conf.DNSDomain,
+				ApiUrl:      conf.AddrProxyUrl,
+				CORS:        conf.GatewayCORS,
+				Headers:     conf.GatewayHeaders,
+				RateLimits:  conf.RateLimits,
+				TLSConfig:   conf.TLSConfig,
+				Webhooks:    hooks,
+			}),
+			ipfsClient:     conf.IPFSClient,
+			emailClient:    conf.EmailClient,
+			filecoinClient: conf.FilecoinClient,
+			webhooks:       hooks,
+			sessionSecret:  conf.SessionSecret,
+		},
+		ipLimiter:    ratelimit.NewLimiter(conf.RateLimits.IP),
+		emailLimiter: ratelimit.NewLimiter(conf.RateLimits.Email),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+	authFunc := metrics.AuthFunc("api", s.authFunc)
+	s.unary = grpcmiddleware.ChainUnaryServer(
+		tracing.UnaryServerInterceptor("api"),
+		metrics.UnaryServerInterceptor("api"),
+		s.limitFunc,
+		auth.UnaryServerInterceptor(authFunc))
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(s.unary),
+		grpc.StreamInterceptor(grpcmiddleware.ChainStreamServer(
+			tracing.StreamServerInterceptor("api"),
+			metrics.StreamServerInterceptor("api"),
+			auth.StreamServerInterceptor(authFunc))),
+	}
+	if conf.TLSConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(conf.TLSConfig)))
+	}
+	s.rpc = grpc.NewServer(opts...)
+	pb.RegisterAPIServer(s.rpc, s.service)
 	return s, nil
 }
 
 // Close the server.
 func (s *Server) Close() error {
+	if s.proxy != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := s.proxy.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	if s.metrics != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := s.metrics.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
 	s.rpc.GracefulStop()
-	if err := s.service.gateway.Stop(); err != nil {
-		return err
+	if s.started {
+		if err := s.service.gateway.Stop(); err != nil {
+			return err
+		}
+		s.service.webhooks.Close()
 	}
 	if s.service.filecoinClient != nil {
 		if err := s.service.filecoinClient.Close(); err != nil {
@@ -119,6 +267,9 @@ func (s *Server) Close() error {
 		}
 	}
 	s.cancel()
+	if s.statsDone != nil {
+		<-s.statsDone
+	}
 	return nil
 }
 
@@ -160,9 +311,71 @@ func (s *Server) authFunc(ctx context.Context) (context.Context, error) {
 	if err := s.service.collections.Sessions.Touch(ctx, session); err != nil {
 		return nil, err
 	}
+	tracing.Annotate(ctx, "user", user.ID)
+	tracing.Annotate(ctx, "scope", scope)
 
 	newCtx := context.WithValue(ctx, reqKey("session"), session)
 	newCtx = context.WithValue(newCtx, reqKey("user"), user)
 	newCtx = context.WithValue(newCtx, reqKey("scope"), scope)
 	return newCtx, nil
 }
+
+// limitFunc rate limits requests to methods that don't require auth by client
+// IP address, and logins by email address.
+func (s *Server) limitFunc(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var public bool
+	for _, ignored := range ignoreMethods {
+		if info.FullMethod == ignored {
+			public = true
+			break
+		}
+	}
+	if !public {
+		return handler(ctx, req)
+	}
+
+	if host := requestIP(ctx); host != "" {
+		if !s.ipLimiter.Allow(host) {
+			return nil, status.Error(codes.ResourceExhausted, "Too many requests")
+		}
+	}
+	if login, ok := req.(*pb.LoginRequest); ok {
+		if !s.emailLimiter.Allow(c.NormalizeEmail(login.Email)) {
+			return nil, status.Error(codes.ResourceExhausted, "Too many login attempts")
+		}
+	}
+	return handler(ctx, req)
+}
+
+// refreshStatsLoop refreshes the instance count gauges until the server is closed.
+func (s *Server) refreshStatsLoop() {
+	defer close(s.statsDone)
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	for {
+		s.refreshStats(s.ctx)
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshStats sets the instance count gauges. Stale counts are kept if they
+// can't be refreshed.
+func (s *Server) refreshStats(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, statsTimeout)
+	defer cancel()
+	stats, err := s.service.collections.Stats(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Errorf("collecting stats: %v", err)
+		}
+		return
+	}
+	metrics.Users.Set(float64(stats.Users))
+	metrics.Projects.Set(float64(stats.Projects))
+	metrics.AppUsers.Set(float64(stats.AppUsers))
+	metrics.ActiveSessions.Set(float64(stats.ActiveSessions))
+}
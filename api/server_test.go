@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/textileio/textile/metrics"
+	"google.golang.org/grpc/codes"
+)
+
+func TestServer_metrics(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	owner, _ := addUser(t, cols, "owner@doe.com")
+	if _, err := cols.Projects.Create(context.Background(), "foo", owner.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test auth failures", func(t *testing.T) {
+		before := metrics.AuthFailures.Value("api", codes.Unauthenticated.String())
+		proxy := httptest.NewServer(s.proxyHandler(nil))
+		defer proxy.Close()
+		res, err := http.Get(proxy.URL + "/v1/projects")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if metrics.AuthFailures.Value("api", codes.Unauthenticated.String()) <= before {
+			t.Fatal("rejected auth should be counted")
+		}
+	})
+
+	t.Run("test gauges", func(t *testing.T) {
+		s.refreshStats(context.Background())
+		rec := httptest.NewRecorder()
+		metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+		body, err := ioutil.ReadAll(rec.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range []string{"textile_users 1\n", "textile_projects 1\n", "textile_active_sessions 1\n"} {
+			if !strings.Contains(string(body), line) {
+				t.Fatalf("expected %q in metrics:\n%s", line, body)
+			}
+		}
+	})
+}
@@ -2,22 +2,42 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	files "github.com/ipfs/go-ipfs-files"
+	iface "github.com/ipfs/interface-go-ipfs-core"
+	"github.com/ipfs/interface-go-ipfs-core/options"
 	fc "github.com/textileio/filecoin/api/client"
 	pb "github.com/textileio/textile/api/pb"
 	c "github.com/textileio/textile/collections"
 	"github.com/textileio/textile/email"
 	"github.com/textileio/textile/gateway"
+	"github.com/textileio/textile/metrics"
+	"github.com/textileio/textile/webhooks"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 var (
 	loginTimeout = time.Minute * 3
 	emailTimeout = time.Second * 10
+
+	// maxAvatarSize is the largest accepted avatar image in bytes.
+	maxAvatarSize = 1 << 20
+	// maxNameLength is the longest accepted display name in bytes.
+	maxNameLength = 128
+
+	usernameRx = regexp.MustCompile(`^[a-z0-9_-]{3,32}$`)
 )
 
 // service is a gRPC service for textile.
@@ -25,8 +45,10 @@ type service struct {
 	collections *c.Collections
 
 	gateway        *gateway.Gateway
+	ipfsClient     iface.CoreAPI
 	emailClient    *email.Client
 	filecoinClient *fc.Client
+	webhooks       *webhooks.Dispatcher
 
 	sessionSecret string
 }
@@ -39,29 +61,14 @@ func (s *service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRep
 		return nil, status.Error(codes.FailedPrecondition, "Email address in not valid")
 	}
 
-	matches, err := s.collections.Users.GetByEmail(ctx, req.Email)
+	user, err := s.collections.Users.GetOrCreate(ctx, req.Email)
 	if err != nil {
 		return nil, err
 	}
-	var user *c.User
-	if len(matches) == 0 {
-		user, err = s.collections.Users.Create(ctx, req.Email)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		user = matches[0]
-	}
 
-	var secret string
-	if s.sessionSecret != "" {
-		secret = s.sessionSecret
-	} else {
-		uid, err := uuid.NewRandom()
-		if err != nil {
-			return nil, err
-		}
-		secret = uid.String()
+	secret, err := s.newSecret()
+	if err != nil {
+		return nil, err
 	}
 
 	ectx, cancel := context.WithTimeout(ctx, emailTimeout)
@@ -71,6 +78,7 @@ func (s *service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRep
 	}
 
 	if !s.awaitVerification(secret) {
+		metrics.Logins.Inc("unverified")
 		return nil, status.Error(codes.Unauthenticated, "Could not verify email address")
 	}
 
@@ -78,6 +86,8 @@ func (s *service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginRep
 	if err != nil {
 		return nil, err
 	}
+	metrics.Logins.Inc("completed")
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: user.ID, Action: c.ActionLogin, TargetID: session.ID})
 
 	return &pb.LoginReply{
 		ID:        user.ID,
@@ -100,6 +110,7 @@ func (s *service) Switch(ctx context.Context, _ *pb.SwitchRequest) (*pb.SwitchRe
 	if err := s.collections.Sessions.SwitchScope(ctx, session, scope); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, &c.AuditEvent{ActorID: session.UserID, Scope: session.UserID, Action: c.ActionSwitch, TargetID: scope})
 
 	return &pb.SwitchReply{}, nil
 }
@@ -115,6 +126,7 @@ func (s *service) Logout(ctx context.Context, _ *pb.LogoutRequest) (*pb.LogoutRe
 	if err := s.collections.Sessions.Delete(ctx, session.ID); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, &c.AuditEvent{ActorID: session.UserID, Scope: session.UserID, Action: c.ActionLogout, TargetID: session.ID})
 
 	return &pb.LogoutReply{}, nil
 }
@@ -143,11 +155,131 @@ func (s *service) Whoami(ctx context.Context, _ *pb.WhoamiRequest) (*pb.WhoamiRe
 		}
 		reply.TeamID = team.ID
 		reply.TeamName = team.Name
+		reply.TeamDescription = team.Description
+		reply.TeamWebsite = team.Website
+		reply.TeamAvatar = team.Avatar
 	}
 
 	return reply, nil
 }
 
+// GetProfile handles a get profile request.
+func (s *service) GetProfile(ctx context.Context, _ *pb.GetProfileRequest) (*pb.GetProfileReply, error) {
+	log.Debugf("received get profile request")
+
+	user, ok := ctx.Value(reqKey("user")).(*c.User)
+	if !ok {
+		log.Fatal("user required")
+	}
+
+	return &pb.GetProfileReply{
+		ID:       user.ID,
+		Email:    user.Email,
+		Name:     user.Name,
+		Username: user.Username,
+		Created:  user.Created,
+	}, nil
+}
+
+// UpdateProfile handles an update profile request.
+// The request replaces the name and username, so callers must send the whole profile.
+// A new email address is only saved after the user confirms it via a sent email.
+func (s *service) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileReply, error) {
+	log.Debugf("received update profile request")
+
+	user, ok := ctx.Value(reqKey("user")).(*c.User)
+	if !ok {
+		log.Fatal("user required")
+	}
+	if len(req.Name) > maxNameLength {
+		return nil, status.Error(codes.InvalidArgument, "Name is too long")
+	}
+	username := c.NormalizeUsername(req.Username)
+	if username != "" && username != user.Username {
+		if !usernameRx.MatchString(username) {
+			return nil, status.Error(codes.InvalidArgument,
+				"Username must be 3 to 32 lowercase letters, digits, dashes, or underscores")
+		}
+		if _, err := s.collections.Users.GetByUsername(ctx, username); err == nil {
+			return nil, status.Error(codes.AlreadyExists, "Username already in use")
+		} else if !errors.Is(err, c.ErrNotFound) {
+			return nil, err
+		}
+	}
+	email := user.Email
+	if req.Email != "" && c.NormalizeEmail(req.Email) != user.Email {
+		if _, err := mail.ParseAddress(req.Email); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, "Email address is not valid")
+		}
+		email = c.NormalizeEmail(req.Email)
+		if _, err := s.collections.Users.GetByEmail(ctx, email); err == nil {
+			return nil, status.Error(codes.AlreadyExists, "Email address already in use")
+		} else if !errors.Is(err, c.ErrNotFound) {
+			return nil, err
+		}
+		if err := s.verifyEmailChange(ctx, email); err != nil {
+			return nil, err
+		}
+		// Verification may take minutes, so start from the current user.
+		var err error
+		if user, err = s.collections.Users.Get(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	user.Name = req.Name
+	user.Username = username
+	user.Email = email
+	// Save checks uniqueness again, since the email or username may have been taken meanwhile.
+	if err := s.collections.Users.Save(ctx, user); errors.Is(err, c.ErrUserExists) {
+		return nil, status.Error(codes.AlreadyExists, "Email address already in use")
+	} else if errors.Is(err, c.ErrUsernameExists) {
+		return nil, status.Error(codes.AlreadyExists, "Username already in use")
+	} else if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: user.ID, Action: c.ActionProfileUpdate, TargetID: user.ID})
+
+	return &pb.UpdateProfileReply{}, nil
+}
+
+// verifyEmailChange sends a confirmation link to a new email address and waits for it to be followed.
+func (s *service) verifyEmailChange(ctx context.Context, email string) error {
+	secret, err := s.newSecret()
+	if err != nil {
+		return err
+	}
+	ectx, cancel := context.WithTimeout(ctx, emailTimeout)
+	defer cancel()
+	if err = s.emailClient.ConfirmEmailChange(ectx, email, s.gateway.Url(), secret); err != nil {
+		return err
+	}
+	if !s.awaitVerification(secret) {
+		return status.Error(codes.Unauthenticated, "Could not verify email address")
+	}
+	return nil
+}
+
+// newSecret returns a secret for an email verification link.
+func (s *service) newSecret() (string, error) {
+	if s.sessionSecret != "" {
+		return s.sessionSecret, nil
+	}
+	uid, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return uid.String(), nil
+}
+
+// displayName returns the name and email of a user, or the email if the user has no name.
+func displayName(user *c.User) string {
+	if user.Name == "" {
+		return user.Email
+	}
+	return fmt.Sprintf("%s (%s)", user.Name, user.Email)
+}
+
 // awaitVerification waits for a user to verify their email via a sent email.
 func (s *service) awaitVerification(secret string) bool {
 	listen := s.gateway.SessionListener()
@@ -181,12 +313,15 @@ func (s *service) AddTeam(ctx context.Context, req *pb.AddTeamRequest) (*pb.AddT
 	}
 
 	team, err := s.collections.Teams.Create(ctx, user.ID, req.Name)
-	if err != nil {
+	if errors.Is(err, c.ErrTeamExists) {
+		return nil, status.Error(codes.AlreadyExists, "Team name already in use")
+	} else if err != nil {
 		return nil, err
 	}
 	if err = s.collections.Users.JoinTeam(ctx, user, team.ID); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: team.ID, Action: c.ActionTeamAdd, TargetID: team.ID})
 
 	return &pb.AddTeamReply{
 		ID: team.ID,
@@ -244,12 +379,80 @@ func (s *service) ListTeams(ctx context.Context, _ *pb.ListTeamsRequest) (*pb.Li
 
 func teamToPbTeam(team *c.Team, members []*pb.GetTeamReply_Member) *pb.GetTeamReply {
 	return &pb.GetTeamReply{
-		ID:      team.ID,
-		OwnerID: team.OwnerID,
-		Name:    team.Name,
-		Created: team.Created,
-		Members: members,
+		ID:          team.ID,
+		OwnerID:     team.OwnerID,
+		Name:        team.Name,
+		Description: team.Description,
+		Website:     team.Website,
+		Avatar:      team.Avatar,
+		Created:     team.Created,
+		Members:     members,
+	}
+}
+
+// UpdateTeam handles an update team request.
+func (s *service) UpdateTeam(ctx context.Context, req *pb.UpdateTeamRequest) (*pb.UpdateTeamReply, error) {
+	log.Debugf("received update team request")
+
+	user, ok := ctx.Value(reqKey("user")).(*c.User)
+	if !ok {
+		log.Fatal("user required")
+	}
+	team, err := s.getTeamForUser(ctx, req.ID, user)
+	if err != nil {
+		return nil, err
+	}
+	if team.OwnerID != user.ID {
+		return nil, status.Error(codes.PermissionDenied, "User is not the team owner")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name required")
+	}
+	website, err := normalizeWebsite(req.Website)
+	if err != nil {
+		return nil, err
+	}
+
+	team.Name = req.Name
+	team.Description = req.Description
+	team.Website = website
+	if req.RemoveAvatar {
+		team.Avatar = ""
+	} else if len(req.Avatar) > 0 {
+		if team.Avatar, err = s.addAvatar(ctx, req.Avatar); err != nil {
+			return nil, err
+		}
+	}
+	if err = s.collections.Teams.Update(ctx, team); errors.Is(err, c.ErrTeamExists) {
+		return nil, status.Error(codes.AlreadyExists, "Team name already in use")
+	} else if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: team.ID, Action: c.ActionTeamUpdate, TargetID: team.ID})
+
+	return &pb.UpdateTeamReply{
+		Avatar: team.Avatar,
+	}, nil
+}
+
+// addAvatar validates an avatar image, adds it to IPFS, and returns its CID.
+func (s *service) addAvatar(ctx context.Context, data []byte) (string, error) {
+	if len(data) > maxAvatarSize {
+		return "", status.Error(codes.InvalidArgument, "Avatar exceeds 1 MiB")
 	}
+	switch http.DetectContentType(data) {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+	default:
+		return "", status.Error(codes.InvalidArgument, "Avatar must be a PNG, JPEG, GIF, or WebP image")
+	}
+	if s.ipfsClient == nil {
+		return "", status.Error(codes.Unavailable, "Avatars are unavailable")
+	}
+	pth, err := s.ipfsClient.Unixfs().Add(ctx, files.NewBytesFile(data), options.Unixfs.Pin(true))
+	if err != nil {
+		return "", err
+	}
+	return pth.Cid().String(), nil
 }
 
 // RemoveTeam handles a remove team request.
@@ -281,6 +484,7 @@ func (s *service) RemoveTeam(ctx context.Context, req *pb.RemoveTeamRequest) (*p
 			return nil, err
 		}
 	}
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: team.ID, Action: c.ActionTeamRemove, TargetID: team.ID})
 
 	return &pb.RemoveTeamReply{}, nil
 }
@@ -302,7 +506,7 @@ func (s *service) InviteToTeam(ctx context.Context, req *pb.InviteToTeamRequest)
 		return nil, status.Error(codes.FailedPrecondition, "Email address in not valid")
 	}
 
-	invite, err := s.collections.Invites.Create(ctx, team.ID, user.ID, req.Email)
+	invite, err := s.collections.Invites.Create(ctx, team.ID, user.ID, c.NormalizeEmail(req.Email))
 	if err != nil {
 		return nil, err
 	}
@@ -310,9 +514,10 @@ func (s *service) InviteToTeam(ctx context.Context, req *pb.InviteToTeamRequest)
 	ectx, cancel := context.WithTimeout(ctx, emailTimeout)
 	defer cancel()
 	if err = s.emailClient.InviteAddress(
-		ectx, team.Name, user.Email, req.Email, s.gateway.Url(), invite.ID); err != nil {
+		ectx, team.Name, displayName(user), req.Email, s.gateway.Url(), invite.ID); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: team.ID, Action: c.ActionTeamInvite, TargetID: invite.ID})
 
 	return &pb.InviteToTeamReply{InviteID: invite.ID}, nil
 }
@@ -336,6 +541,7 @@ func (s *service) LeaveTeam(ctx context.Context, req *pb.LeaveTeamRequest) (*pb.
 	if err = s.collections.Users.LeaveTeam(ctx, user, team.ID); err != nil {
 		return nil, err
 	}
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: team.ID, Action: c.ActionTeamLeave, TargetID: user.ID})
 
 	return &pb.LeaveTeamReply{}, nil
 }
@@ -362,6 +568,7 @@ func (s *service) AddProject(ctx context.Context, req *pb.AddProjectRequest) (*p
 	if err != nil {
 		return nil, err
 	}
+	s.auditScope(ctx, c.ActionProjectAdd, proj.ID)
 
 	return &pb.AddProjectReply{
 		ID:      proj.ID,
@@ -427,6 +634,30 @@ func projectToPbProject(proj *c.Project) *pb.GetProjectReply {
 	}
 }
 
+// UpdateProject handles an update project request.
+func (s *service) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.UpdateProjectReply, error) {
+	log.Debugf("received update project request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name required")
+	}
+	proj, err := s.getProjectForScope(ctx, req.ID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.collections.Projects.Rename(ctx, proj, req.Name); err != nil {
+		return nil, err
+	}
+	s.auditScope(ctx, c.ActionProjectUpdate, proj.ID)
+
+	return &pb.UpdateProjectReply{}, nil
+}
+
 // RemoveProject handles a remove project request.
 func (s *service) RemoveProject(ctx context.Context, req *pb.RemoveProjectRequest) (*pb.RemoveProjectReply, error) {
 	log.Debugf("received remove project request")
@@ -440,9 +671,26 @@ func (s *service) RemoveProject(ctx context.Context, req *pb.RemoveProjectReques
 		return nil, err
 	}
 
+	// Deliveries keep their own copy of the webhook, so they're sent after it's removed.
+	if err = s.webhooks.Enqueue(ctx, proj.ID, c.EventProjectRemove, map[string]interface{}{
+		"project_id": proj.ID,
+		"name":       proj.Name,
+	}); err != nil {
+		return nil, err
+	}
+	hooks, err := s.collections.Webhooks.List(ctx, proj.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range hooks {
+		if err = s.collections.Webhooks.Delete(ctx, hook.ID); err != nil {
+			return nil, err
+		}
+	}
 	if err = s.collections.Projects.Delete(ctx, proj.ID); err != nil {
 		return nil, err
 	}
+	s.auditScope(ctx, c.ActionProjectRemove, proj.ID)
 
 	return &pb.RemoveProjectReply{}, nil
 }
@@ -459,10 +707,15 @@ func (s *service) AddAppToken(ctx context.Context, req *pb.AddAppTokenRequest) (
 	if err != nil {
 		return nil, err
 	}
-	token, err := s.collections.AppTokens.Create(ctx, proj.ID)
+	origins, err := normalizeOrigins(req.AllowedOrigins)
 	if err != nil {
 		return nil, err
 	}
+	token, err := s.collections.AppTokens.Create(ctx, proj.ID, origins)
+	if err != nil {
+		return nil, err
+	}
+	s.auditScope(ctx, c.ActionAppTokenAdd, token.ID)
 
 	return &pb.AddAppTokenReply{
 		ID: token.ID,
@@ -510,20 +763,275 @@ func (s *service) RemoveAppToken(ctx context.Context, req *pb.RemoveAppTokenRequ
 	if err = s.collections.AppTokens.Delete(ctx, token.ID); err != nil {
 		return nil, err
 	}
+	s.auditScope(ctx, c.ActionAppTokenRemove, token.ID)
 
 	return &pb.RemoveAppTokenReply{}, nil
 }
 
-// getTeamForUser returns a team if the user is authorized.
-func (s *service) getTeamForUser(ctx context.Context, teamID string, user *c.User) (*c.Team, error) {
-	team, err := s.collections.Teams.Get(ctx, teamID)
+// ListAppUsers handles a list app users request.
+func (s *service) ListAppUsers(ctx context.Context, req *pb.ListAppUsersRequest) (*pb.ListAppUsersReply, error) {
+	log.Debugf("received list app users request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	proj, err := s.getProjectForScope(ctx, req.ProjectID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.collections.AppUsers.List(ctx, proj.ID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.ListAppUsersReply_AppUser, len(users))
+	for i, user := range users {
+		list[i] = &pb.ListAppUsersReply_AppUser{
+			ID:      user.ID,
+			StoreID: user.StoreID,
+			Created: user.Created,
+		}
+	}
+
+	return &pb.ListAppUsersReply{List: list}, nil
+}
+
+// RemoveAppUser handles a remove app user request.
+// The app user's sessions are removed with it.
+func (s *service) RemoveAppUser(ctx context.Context, req *pb.RemoveAppUserRequest) (*pb.RemoveAppUserReply, error) {
+	log.Debugf("received remove app user request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	user, err := s.collections.AppUsers.Get(ctx, req.ID)
+	if errors.Is(err, c.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "App user not found")
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err = s.getProjectForScope(ctx, user.ProjectID, scope); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.collections.Sessions.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if err = s.collections.Sessions.Delete(ctx, session.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err = s.collections.AppUsers.Delete(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	s.auditScope(ctx, c.ActionAppUserRemove, user.ID)
+
+	return &pb.RemoveAppUserReply{}, nil
+}
+
+// AddWebhook handles an add webhook request.
+// A secret is generated if none is given.
+func (s *service) AddWebhook(ctx context.Context, req *pb.AddWebhookRequest) (*pb.AddWebhookReply, error) {
+	log.Debugf("received add webhook request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	proj, err := s.getProjectForScope(ctx, req.ProjectID, scope)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid webhook URL %s", req.URL)
+	}
+	if err = s.webhooks.CheckURL(ctx, u); errors.Is(err, webhooks.ErrAddressNotAllowed) {
+		return nil, status.Error(codes.InvalidArgument, "Webhook URL must not be a loopback, private, or link-local address")
+	} else if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Webhook host %s could not be resolved", u.Hostname())
+	}
+	events, err := normalizeEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = webhooks.NewSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	hook, err := s.collections.Webhooks.Create(ctx, proj.ID, u.String(), secret, events)
+	if err != nil {
+		return nil, err
+	}
+	s.auditScope(ctx, c.ActionWebhookAdd, hook.ID)
+
+	return &pb.AddWebhookReply{
+		ID:     hook.ID,
+		Secret: secret,
+	}, nil
+}
+
+// ListWebhooks handles a list webhooks request. Secrets are not listed.
+func (s *service) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksReply, error) {
+	log.Debugf("received list webhooks request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	proj, err := s.getProjectForScope(ctx, req.ProjectID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks, err := s.collections.Webhooks.List(ctx, proj.ID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.ListWebhooksReply_Webhook, len(hooks))
+	for i, hook := range hooks {
+		list[i] = &pb.ListWebhooksReply_Webhook{
+			ID:      hook.ID,
+			URL:     hook.URL,
+			Events:  hook.Events,
+			Created: hook.Created,
+		}
+	}
+
+	return &pb.ListWebhooksReply{List: list}, nil
+}
+
+// RemoveWebhook handles a remove webhook request.
+// Queued deliveries are still sent.
+func (s *service) RemoveWebhook(ctx context.Context, req *pb.RemoveWebhookRequest) (*pb.RemoveWebhookReply, error) {
+	log.Debugf("received remove webhook request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	hook, err := s.getWebhookWithScope(ctx, req.ID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.collections.Webhooks.Delete(ctx, hook.ID); err != nil {
+		return nil, err
+	}
+	s.auditScope(ctx, c.ActionWebhookRemove, hook.ID)
+
+	return &pb.RemoveWebhookReply{}, nil
+}
+
+// TestWebhook handles a test webhook request by sending a ping event right away.
+// The reply doesn't include the response status.
+func (s *service) TestWebhook(ctx context.Context, req *pb.TestWebhookRequest) (*pb.TestWebhookReply, error) {
+	log.Debugf("received test webhook request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	hook, err := s.getWebhookWithScope(ctx, req.ID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only report success or failure, so webhooks can't be used to inspect other hosts.
+	if err = s.webhooks.Send(ctx, hook, c.EventPing, map[string]interface{}{
+		"project_id": hook.ProjectID,
+		"webhook_id": hook.ID,
+	}); err != nil {
+		log.Debugf("testing webhook %s: %v", hook.ID, err)
+		return nil, status.Error(codes.Unavailable, "Webhook delivery failed")
+	}
+
+	return &pb.TestWebhookReply{}, nil
+}
+
+// ListAuditEvents handles a list audit events request.
+func (s *service) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsReply, error) {
+	log.Debugf("received list audit events request")
+
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	if req.Since < 0 || req.Until < 0 || (req.Until > 0 && req.Until <= req.Since) {
+		return nil, status.Error(codes.InvalidArgument, "Time range is not valid")
+	}
+
+	events, err := s.collections.AuditEvents.List(ctx, scope, req.Since, req.Until)
 	if err != nil {
 		return nil, err
 	}
-	if team == nil {
+	list := make([]*pb.ListAuditEventsReply_AuditEvent, len(events))
+	for i, e := range events {
+		list[i] = &pb.ListAuditEventsReply_AuditEvent{
+			ID:       e.ID,
+			ActorID:  e.ActorID,
+			Scope:    e.Scope,
+			Action:   e.Action,
+			TargetID: e.TargetID,
+			IP:       e.IP,
+			Created:  e.Created,
+		}
+	}
+
+	return &pb.ListAuditEventsReply{List: list}, nil
+}
+
+// audit records an event with the IP address of the request.
+// Failures are only logged since the audited action was already taken.
+func (s *service) audit(ctx context.Context, e *c.AuditEvent) {
+	e.IP = requestIP(ctx)
+	if err := s.collections.AuditEvents.Create(ctx, e); err != nil {
+		log.Errorf("recording %s audit event: %v", e.Action, err)
+	}
+}
+
+// auditScope records an action of the request's user on target in the request's scope.
+func (s *service) auditScope(ctx context.Context, action, target string) {
+	user, ok := ctx.Value(reqKey("user")).(*c.User)
+	if !ok {
+		log.Fatal("user required")
+	}
+	scope, ok := ctx.Value(reqKey("scope")).(string)
+	if !ok {
+		log.Fatal("scope required")
+	}
+	s.audit(ctx, &c.AuditEvent{ActorID: user.ID, Scope: scope, Action: action, TargetID: target})
+}
+
+// requestIP returns the host of the request's peer address, if any.
+func requestIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// getTeamForUser returns a team if the user is authorized.
+func (s *service) getTeamForUser(ctx context.Context, teamID string, user *c.User) (*c.Team, error) {
+	team, err := s.collections.Teams.Get(ctx, teamID)
+	if errors.Is(err, c.ErrNotFound) {
 		return nil, status.Error(codes.NotFound, "Team not found")
+	} else if err != nil {
+		return nil, err
 	}
-	if !s.collections.Users.HasTeam(user, team.ID) {
+	if !user.HasTeam(team.ID) {
 		return nil, status.Error(codes.PermissionDenied, "User is not a team member")
 	}
 	return team, nil
@@ -532,11 +1040,10 @@ func (s *service) getTeamForUser(ctx context.Context, teamID string, user *c.Use
 // getProjectForScope returns a project if the scope is authorized.
 func (s *service) getProjectForScope(ctx context.Context, projID, scope string) (*c.Project, error) {
 	proj, err := s.collections.Projects.Get(ctx, projID)
-	if err != nil {
-		return nil, err
-	}
-	if proj == nil {
+	if errors.Is(err, c.ErrNotFound) {
 		return nil, status.Error(codes.NotFound, "Project not found")
+	} else if err != nil {
+		return nil, err
 	}
 	if proj.Scope != scope {
 		return nil, status.Error(codes.PermissionDenied, "Scope does not own project")
@@ -547,14 +1054,81 @@ func (s *service) getProjectForScope(ctx context.Context, projID, scope string)
 // getAppTokenWithScope returns an app token if the scope is authorized for the associated project.
 func (s *service) getAppTokenWithScope(ctx context.Context, tokenID, scope string) (*c.AppToken, error) {
 	token, err := s.collections.AppTokens.Get(ctx, tokenID)
-	if err != nil {
-		return nil, err
-	}
-	if token == nil {
+	if errors.Is(err, c.ErrNotFound) {
 		return nil, status.Error(codes.NotFound, "Token not found")
+	} else if err != nil {
+		return nil, err
 	}
 	if _, err := s.getProjectForScope(ctx, token.ProjectID, scope); err != nil {
 		return nil, err
 	}
 	return token, nil
 }
+
+// getWebhookWithScope returns a webhook if its project is in scope.
+func (s *service) getWebhookWithScope(ctx context.Context, hookID, scope string) (*c.Webhook, error) {
+	hook, err := s.collections.Webhooks.Get(ctx, hookID)
+	if errors.Is(err, c.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err := s.getProjectForScope(ctx, hook.ProjectID, scope); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// normalizeEvents validates webhook events and removes duplicates.
+func normalizeEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "At least one event required: %s",
+			strings.Join(c.WebhookEvents, ", "))
+	}
+	var res []string
+	seen := make(map[string]bool)
+	for _, e := range events {
+		var known bool
+		for _, k := range c.WebhookEvents {
+			if e == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown webhook event %s", e)
+		}
+		if !seen[e] {
+			seen[e] = true
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+// normalizeWebsite validates an http(s) website URL. An empty URL is allowed.
+func normalizeWebsite(website string) (string, error) {
+	if website == "" {
+		return "", nil
+	}
+	u, err := url.Parse(website)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", status.Errorf(codes.InvalidArgument, "Invalid website %s", website)
+	}
+	return u.String(), nil
+}
+
+// normalizeOrigins validates browser origins and returns them in the form
+// sent by browsers in the Origin header, e.g., https://example.com.
+func normalizeOrigins(origins []string) ([]string, error) {
+	var res []string
+	for _, o := range origins {
+		u, err := url.Parse(strings.TrimSuffix(o, "/"))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid origin %s", o)
+		}
+		res = append(res, strings.ToLower(u.Scheme+"://"+u.Host))
+	}
+	return res, nil
+}
@@ -0,0 +1,792 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/textileio/textile/api/pb"
+	c "github.com/textileio/textile/collections"
+	"github.com/textileio/textile/collections/memory"
+	"github.com/textileio/textile/email"
+	"github.com/textileio/textile/webhooks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestService_authFunc(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	owner, ownerSession := addUser(t, cols, "owner@doe.com")
+	_, strangerSession := addUser(t, cols, "stranger@doe.com")
+	team := addTeam(t, cols, owner)
+
+	expired, err := cols.Sessions.Create(context.Background(), owner.ID, owner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired.Expiry = 0
+	if err = cols.Sessions.SwitchScope(context.Background(), expired, owner.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		scope string
+		code  codes.Code
+	}{
+		{name: "without token", code: codes.Unauthenticated},
+		{name: "with bad token", token: "bad", code: codes.Unauthenticated},
+		{name: "with expired token", token: expired.ID, code: codes.Unauthenticated},
+		{name: "with user scope", token: ownerSession.ID, code: codes.OK},
+		{name: "with team scope", token: ownerSession.ID, scope: team.ID, code: codes.OK},
+		{name: "with foreign team scope", token: strangerSession.ID, scope: team.ID, code: codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		t.Run("test auth "+test.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if test.token != "" {
+				md.Set("authorization", "bearer "+test.token)
+			}
+			if test.scope != "" {
+				md.Set("x-scope", test.scope)
+			}
+			_, err := s.authFunc(metadata.NewIncomingContext(context.Background(), md))
+			checkCode(t, err, test.code)
+		})
+	}
+}
+
+func TestService_Profile(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	jon, jonSession := addUser(t, cols, "jon@doe.com")
+	_, janeSession := addUser(t, cols, "jane@doe.com")
+
+	tests := []struct {
+		name    string
+		session *c.Session
+		call    func(ctx context.Context) error
+		code    codes.Code
+	}{
+		{
+			name:    "update profile",
+			session: jonSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateProfile(ctx, &pb.UpdateProfileRequest{Name: "Jon Doe", Username: "Jon"})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "get profile",
+			session: jonSession,
+			call: func(ctx context.Context) error {
+				reply, err := s.service.GetProfile(ctx, &pb.GetProfileRequest{})
+				if err == nil && (reply.ID != jon.ID || reply.Name != "Jon Doe" || reply.Username != "jon") {
+					t.Fatalf("got wrong profile: %v", reply)
+				}
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "update profile with taken username",
+			session: janeSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateProfile(ctx, &pb.UpdateProfileRequest{Username: "jon"})
+				return err
+			},
+			code: codes.AlreadyExists,
+		},
+		{
+			name:    "update profile with bad username",
+			session: janeSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateProfile(ctx, &pb.UpdateProfileRequest{Username: "j@ne"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "update profile with taken email",
+			session: janeSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateProfile(ctx, &pb.UpdateProfileRequest{Email: "JON@doe.com"})
+				return err
+			},
+			code: codes.AlreadyExists,
+		},
+		{
+			name:    "update profile with bad email",
+			session: janeSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateProfile(ctx, &pb.UpdateProfileRequest{Email: "jane"})
+				return err
+			},
+			code: codes.FailedPrecondition,
+		},
+	}
+	for _, test := range tests {
+		t.Run("test "+test.name, func(t *testing.T) {
+			checkCode(t, test.call(authedCtx(t, s, test.session, "")), test.code)
+		})
+	}
+}
+
+func TestService_Teams(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	owner, ownerSession := addUser(t, cols, "owner@doe.com")
+	member, memberSession := addUser(t, cols, "member@doe.com")
+	_, strangerSession := addUser(t, cols, "stranger@doe.com")
+	team := addTeam(t, cols, owner)
+	if err := cols.Users.JoinTeam(context.Background(), member, team.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		session *c.Session
+		call    func(ctx context.Context) error
+		code    codes.Code
+	}{
+		{
+			name:    "get team as member",
+			session: memberSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.GetTeam(ctx, &pb.GetTeamRequest{ID: team.ID})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "get team as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.GetTeam(ctx, &pb.GetTeamRequest{ID: team.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "invite with bad email",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.InviteToTeam(ctx, &pb.InviteToTeamRequest{ID: team.ID, Email: "jane"})
+				return err
+			},
+			code: codes.FailedPrecondition,
+		},
+		{
+			name:    "invite as member",
+			session: memberSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.InviteToTeam(ctx, &pb.InviteToTeamRequest{ID: team.ID, Email: "jane@doe.com"})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "remove team as member",
+			session: memberSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveTeam(ctx, &pb.RemoveTeamRequest{ID: team.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "update team as member",
+			session: memberSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateTeam(ctx, &pb.UpdateTeamRequest{ID: team.ID, Name: "bar"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "update team with bad website",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateTeam(ctx, &pb.UpdateTeamRequest{ID: team.ID, Name: "bar", Website: "ftp://bar"})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "update team with bad avatar",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateTeam(ctx, &pb.UpdateTeamRequest{ID: team.ID, Name: "bar", Avatar: []byte("not an image")})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "update team avatar without ipfs",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateTeam(ctx, &pb.UpdateTeamRequest{ID: team.ID, Name: "bar", Avatar: []byte("\x89PNG\r\n\x1a\n")})
+				return err
+			},
+			code: codes.Unavailable,
+		},
+		{
+			name:    "add team with taken name",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.AddTeam(ctx, &pb.AddTeamRequest{Name: "FOO"})
+				return err
+			},
+			code: codes.AlreadyExists,
+		},
+		{
+			name:    "update team as owner",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				if _, err := s.service.UpdateTeam(ctx, &pb.UpdateTeamRequest{
+					ID:          team.ID,
+					Name:        "bar",
+					Description: "Bar team",
+					Website:     "https://bar.com",
+				}); err != nil {
+					return err
+				}
+				reply, err := s.service.GetTeam(ctx, &pb.GetTeamRequest{ID: team.ID})
+				if err == nil && (reply.Name != "bar" || reply.Description != "Bar team" || reply.Website != "https://bar.com") {
+					t.Fatalf("got wrong team fields: %v", reply)
+				}
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "leave team as owner",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.LeaveTeam(ctx, &pb.LeaveTeamRequest{ID: team.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "leave team as member",
+			session: memberSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.LeaveTeam(ctx, &pb.LeaveTeamRequest{ID: team.ID})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "remove team as owner",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveTeam(ctx, &pb.RemoveTeamRequest{ID: team.ID})
+				return err
+			},
+			code: codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run("test "+test.name, func(t *testing.T) {
+			checkCode(t, test.call(authedCtx(t, s, test.session, "")), test.code)
+		})
+	}
+}
+
+func TestService_Projects(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	owner, ownerSession := addUser(t, cols, "owner@doe.com")
+	_, strangerSession := addUser(t, cols, "stranger@doe.com")
+	team := addTeam(t, cols, owner)
+
+	ctx := authedCtx(t, s, ownerSession, team.ID)
+	proj, err := s.service.AddProject(ctx, &pb.AddProjectRequest{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := s.service.AddAppToken(ctx, &pb.AddAppTokenRequest{ProjectID: proj.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appUser, err := cols.AppUsers.GetOrCreate(context.Background(), proj.ID, "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	appUserSession, err := cols.Sessions.Create(context.Background(), appUser.ID, proj.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		session *c.Session
+		scope   string
+		call    func(ctx context.Context) error
+		code    codes.Code
+	}{
+		{
+			name:    "get project in team scope",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.GetProject(ctx, &pb.GetProjectRequest{ID: proj.ID})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "get project in user scope",
+			session: ownerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.GetProject(ctx, &pb.GetProjectRequest{ID: proj.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "get project as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.GetProject(ctx, &pb.GetProjectRequest{ID: proj.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "list app tokens as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.ListAppTokens(ctx, &pb.ListAppTokensRequest{ProjectID: proj.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "remove app token as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveAppToken(ctx, &pb.RemoveAppTokenRequest{ID: token.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "remove app token in team scope",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveAppToken(ctx, &pb.RemoveAppTokenRequest{ID: token.ID})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "list app users as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.ListAppUsers(ctx, &pb.ListAppUsersRequest{ProjectID: proj.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "list app users in team scope",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				reply, err := s.service.ListAppUsers(ctx, &pb.ListAppUsersRequest{ProjectID: proj.ID})
+				if err == nil && len(reply.List) != 1 {
+					t.Fatalf("expected 1 app user, got %d", len(reply.List))
+				}
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "remove app user as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveAppUser(ctx, &pb.RemoveAppUserRequest{ID: appUser.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "remove app user in team scope",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				if _, err := s.service.RemoveAppUser(ctx, &pb.RemoveAppUserRequest{ID: appUser.ID}); err != nil {
+					return err
+				}
+				if _, err := cols.Sessions.Get(ctx, appUserSession.ID); err == nil {
+					t.Fatal("expected app user session to be removed")
+				}
+				return nil
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "rename project as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateProject(ctx, &pb.UpdateProjectRequest{ID: proj.ID, Name: "bar"})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "rename project without name",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.UpdateProject(ctx, &pb.UpdateProjectRequest{ID: proj.ID})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "rename project in team scope",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				if _, err := s.service.UpdateProject(ctx, &pb.UpdateProjectRequest{ID: proj.ID, Name: "bar"}); err != nil {
+					return err
+				}
+				reply, err := s.service.GetProject(ctx, &pb.GetProjectRequest{ID: proj.ID})
+				if err == nil && reply.Name != "bar" {
+					t.Fatalf("expected name bar, got %s", reply.Name)
+				}
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "remove project as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveProject(ctx, &pb.RemoveProjectRequest{ID: proj.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "remove project in team scope",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveProject(ctx, &pb.RemoveProjectRequest{ID: proj.ID})
+				return err
+			},
+			code: codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run("test "+test.name, func(t *testing.T) {
+			checkCode(t, test.call(authedCtx(t, s, test.session, test.scope)), test.code)
+		})
+	}
+}
+
+func TestService_AuditEvents(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	owner, ownerSession := addUser(t, cols, "owner@doe.com")
+	member, memberSession := addUser(t, cols, "member@doe.com")
+	team := addTeam(t, cols, owner)
+	if err := cols.Users.JoinTeam(context.Background(), member, team.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4000}
+	ctx := peer.NewContext(authedCtx(t, s, ownerSession, team.ID), &peer.Peer{Addr: addr})
+	proj, err := s.service.AddProject(ctx, &pb.AddProjectRequest{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.service.UpdateProject(ctx, &pb.UpdateProjectRequest{ID: proj.ID, Name: "bar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list team events as member", func(t *testing.T) {
+		ctx := authedCtx(t, s, memberSession, team.ID)
+		reply, err := s.service.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{})
+		if err != nil {
+			t.Fatalf("list audit events should succeed: %v", err)
+		}
+		if len(reply.List) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(reply.List))
+		}
+		for _, e := range reply.List {
+			if e.ActorID != owner.ID || e.Scope != team.ID || e.TargetID != proj.ID || e.IP != "10.0.0.1" {
+				t.Fatalf("got bad event %v", e)
+			}
+		}
+	})
+
+	t.Run("list user events", func(t *testing.T) {
+		ctx := authedCtx(t, s, memberSession, "")
+		reply, err := s.service.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.List) != 0 {
+			t.Fatal("team events should not be listed in user scope")
+		}
+	})
+
+	t.Run("list events in bad time range", func(t *testing.T) {
+		ctx := authedCtx(t, s, ownerSession, team.ID)
+		_, err := s.service.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{Since: 20, Until: 10})
+		checkCode(t, err, codes.InvalidArgument)
+	})
+}
+
+func TestService_Webhooks(t *testing.T) {
+	t.Parallel()
+	s, cols, done := setupService(t)
+	defer done()
+	owner, ownerSession := addUser(t, cols, "owner@doe.com")
+	_, strangerSession := addUser(t, cols, "stranger@doe.com")
+	team := addTeam(t, cols, owner)
+
+	var code = http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	ctx := authedCtx(t, s, ownerSession, team.ID)
+	proj, err := s.service.AddProject(ctx, &pb.AddProjectRequest{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook, err := s.service.AddWebhook(ctx, &pb.AddWebhookRequest{
+		ProjectID: proj.ID,
+		URL:       server.URL,
+		Events:    []string{c.EventProjectRemove},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hook.Secret == "" {
+		t.Fatal("add webhook should generate a secret")
+	}
+
+	tests := []struct {
+		name    string
+		session *c.Session
+		scope   string
+		call    func(ctx context.Context) error
+		code    codes.Code
+	}{
+		{
+			name:    "add webhook with bad url",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.AddWebhook(ctx, &pb.AddWebhookRequest{
+					ProjectID: proj.ID, URL: "ftp://foo.com", Events: []string{c.EventProjectRemove}})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "add webhook with private url",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				hooks := s.service.webhooks
+				s.service.webhooks = webhooks.NewDispatcher(cols, webhooks.Config{})
+				defer func() { s.service.webhooks = hooks }()
+				_, err := s.service.AddWebhook(ctx, &pb.AddWebhookRequest{
+					ProjectID: proj.ID, URL: "http://169.254.169.254/latest", Events: []string{c.EventProjectRemove}})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "add webhook without events",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.AddWebhook(ctx, &pb.AddWebhookRequest{ProjectID: proj.ID, URL: server.URL})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "add webhook with unknown event",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.AddWebhook(ctx, &pb.AddWebhookRequest{
+					ProjectID: proj.ID, URL: server.URL, Events: []string{"foo"}})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:    "list webhooks as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.ListWebhooks(ctx, &pb.ListWebhooksRequest{ProjectID: proj.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "test webhook as stranger",
+			session: strangerSession,
+			call: func(ctx context.Context) error {
+				_, err := s.service.TestWebhook(ctx, &pb.TestWebhookRequest{ID: hook.ID})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:    "test webhook",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.TestWebhook(ctx, &pb.TestWebhookRequest{ID: hook.ID})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name:    "test failing webhook",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				code = http.StatusInternalServerError
+				defer func() { code = http.StatusNoContent }()
+				_, err := s.service.TestWebhook(ctx, &pb.TestWebhookRequest{ID: hook.ID})
+				if err != nil && strings.Contains(err.Error(), "500") {
+					t.Fatal("test webhook should not report the response status")
+				}
+				return err
+			},
+			code: codes.Unavailable,
+		},
+		{
+			name:    "remove missing webhook",
+			session: ownerSession,
+			scope:   team.ID,
+			call: func(ctx context.Context) error {
+				_, err := s.service.RemoveWebhook(ctx, &pb.RemoveWebhookRequest{ID: "foo"})
+				return err
+			},
+			code: codes.NotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.call(authedCtx(t, s, test.session, test.scope))
+			checkCode(t, err, test.code)
+		})
+	}
+
+	t.Run("remove project", func(t *testing.T) {
+		if _, err := s.service.RemoveProject(ctx, &pb.RemoveProjectRequest{ID: proj.ID}); err != nil {
+			t.Fatal(err)
+		}
+		hooks, err := cols.Webhooks.List(context.Background(), proj.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hooks) != 0 {
+			t.Fatal("project webhooks should be removed")
+		}
+		queued, err := cols.WebhookDeliveries.ListDue(context.Background(), time.Now().Unix())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(queued) != 1 || queued[0].Event != c.EventProjectRemove || queued[0].URL != server.URL {
+			t.Fatal("project removal should be queued for delivery")
+		}
+	})
+}
+
+func setupService(t *testing.T) (*Server, *c.Collections, func()) {
+	cols := memory.NewCollections(nil)
+	emailClient, err := email.NewClient("Textile <verify@email.textile.io>", "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewUnstartedServer(context.Background(), Config{
+		AddrGatewayUrl: "http://127.0.0.1:8006",
+		Collections:    cols,
+		EmailClient:    emailClient,
+		// Test webhooks are served on loopback addresses.
+		Webhooks: webhooks.Config{AllowPrivateAddrs: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, cols, func() {
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func addUser(t *testing.T, cols *c.Collections, email string) (*c.User, *c.Session) {
+	ctx := context.Background()
+	user, err := cols.Users.Create(ctx, email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := cols.Sessions.Create(ctx, user.ID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, session
+}
+
+func addTeam(t *testing.T, cols *c.Collections, owner *c.User) *c.Team {
+	ctx := context.Background()
+	team, err := cols.Teams.Create(ctx, owner.ID, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cols.Users.JoinTeam(ctx, owner, team.ID); err != nil {
+		t.Fatal(err)
+	}
+	return team
+}
+
+// authedCtx returns a context that has passed the server's auth func.
+func authedCtx(t *testing.T, s *Server, session *c.Session, scope string) context.Context {
+	md := metadata.Pairs("authorization", "bearer "+session.ID)
+	if scope != "" {
+		md.Set("x-scope", scope)
+	}
+	ctx, err := s.authFunc(metadata.NewIncomingContext(context.Background(), md))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctx
+}
+
+func checkCode(t *testing.T, err error, code codes.Code) {
+	if status.Code(err) != code {
+		t.Fatalf("expected code %s, got: %v", code, err)
+	}
+}
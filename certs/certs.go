@@ -0,0 +1,337 @@
+package certs
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log"
+	"github.com/textileio/textile/dns"
+	"golang.org/x/crypto/acme"
+)
+
+var log = logging.Logger("certs")
+
+const (
+	// renewBefore is how long before expiry ACME certificates are renewed.
+	renewBefore = time.Hour * 24 * 30
+	// renewCheckInterval is how often ACME certificates are checked for renewal.
+	renewCheckInterval = time.Hour * 12
+	// obtainTimeout bounds the time spent obtaining an ACME certificate.
+	obtainTimeout = time.Minute * 10
+
+	accountKeyFile = "acme_account.pem"
+	cachedCertFile = "acme_cert.pem"
+)
+
+// DNSProvider creates the TXT records used for ACME DNS-01 challenges.
+// It's satisfied by dns.Manager.
+type DNSProvider interface {
+	NewTXT(name string, content string) (*dns.Record, error)
+	Delete(recordID string) error
+}
+
+// Config specifies where TLS certificates come from. Certificates are loaded
+// from CertFile and KeyFile if set. Otherwise, if ACMEDomains is not empty, a
+// certificate covering all of them is obtained from an ACME CA, answering
+// DNS-01 challenges with DNS. Wildcard domains, e.g., *.textile.io, are allowed.
+type Config struct {
+	CertFile string
+	KeyFile  string
+
+	ACMEDomains      []string
+	ACMEEmail        string
+	ACMEDirectoryURL string // defaults to Let's Encrypt
+	CacheDir         string // stores the ACME account key and certificate
+	DNS              DNSProvider
+}
+
+// Enabled returns whether or not the config provides certificates.
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || len(c.ACMEDomains) > 0
+}
+
+// Manager provides a TLS certificate, renewing it in the background if
+// it comes from an ACME CA.
+type Manager struct {
+	conf   Config
+	client *acme.Client
+
+	lock sync.RWMutex
+	cert *tls.Certificate
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewManager returns a manager for the certificate specified by conf.
+// ACME certificates are obtained before returning unless a valid one is cached.
+func NewManager(ctx context.Context, conf Config) (*Manager, error) {
+	if !conf.Enabled() {
+		return nil, errors.New("no certificate source configured")
+	}
+	m := &Manager{conf: conf, done: make(chan struct{})}
+	if conf.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		m.cert = &cert
+		close(m.done)
+		return m, nil
+	}
+
+	if conf.DNS == nil {
+		return nil, errors.New("ACME certificates require a DNS manager")
+	}
+	if conf.CacheDir == "" {
+		return nil, errors.New("ACME certificates require a cache directory")
+	}
+	if err := os.MkdirAll(conf.CacheDir, 0700); err != nil {
+		return nil, err
+	}
+	key, err := m.loadAccountKey()
+	if err != nil {
+		return nil, err
+	}
+	m.client = &acme.Client{
+		Key:          key,
+		DirectoryURL: conf.ACMEDirectoryURL,
+	}
+	if m.client.DirectoryURL == "" {
+		m.client.DirectoryURL = acme.LetsEncryptURL
+	}
+
+	if cert, err := m.loadCert(); err == nil && !needsRenewal(cert) {
+		m.cert = cert
+	} else if err = m.renew(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, m.cancel = context.WithCancel(context.Background())
+	go m.renewLoop(ctx)
+	return m, nil
+}
+
+// TLSConfig returns a TLS config serving the manager's certificate.
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: m.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
+
+// GetCertificate returns the current certificate.
+func (m *Manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.cert, nil
+}
+
+// Close stops background renewal.
+func (m *Manager) Close() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	<-m.done
+}
+
+func (m *Manager) renewLoop(ctx context.Context) {
+	defer close(m.done)
+	tick := time.NewTicker(renewCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			m.lock.RLock()
+			renew := needsRenewal(m.cert)
+			m.lock.RUnlock()
+			if !renew {
+				continue
+			}
+			if err := m.renew(ctx); err != nil {
+				log.Errorf("error renewing certificate: %v", err)
+			}
+		}
+	}
+}
+
+// renew obtains a new certificate from the ACME CA and caches it.
+func (m *Manager) renew(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, obtainTimeout)
+	defer cancel()
+	log.Infof("obtaining certificate for %s", strings.Join(m.conf.ACMEDomains, ", "))
+
+	acct := &acme.Account{}
+	if m.conf.ACMEEmail != "" {
+		acct.Contact = []string{"mailto:" + m.conf.ACMEEmail}
+	}
+	if _, err := m.client.Register(ctx, acct, acme.AcceptTOS); err != nil &&
+		err != acme.ErrAccountAlreadyExists {
+		return err
+	}
+
+	order, err := m.client.AuthorizeOrder(ctx, acme.DomainIDs(m.conf.ACMEDomains...))
+	if err != nil {
+		return err
+	}
+	for _, u := range order.AuthzURLs {
+		if err = m.authorize(ctx, u); err != nil {
+			return err
+		}
+	}
+	if order, err = m.client.WaitOrder(ctx, order.URI); err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		DNSNames: m.conf.ACMEDomains,
+	}, key)
+	if err != nil {
+		return err
+	}
+	der, _, err := m.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return err
+	}
+	cert := &tls.Certificate{Certificate: der, PrivateKey: key}
+	if cert.Leaf, err = x509.ParseCertificate(der[0]); err != nil {
+		return err
+	}
+	if err = m.saveCert(cert, key); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	m.cert = cert
+	m.lock.Unlock()
+	log.Infof("obtained certificate valid until %s", cert.Leaf.NotAfter)
+	return nil
+}
+
+// authorize completes the DNS-01 challenge of a pending authorization.
+func (m *Manager) authorize(ctx context.Context, url string) error {
+	authz, err := m.client.GetAuthorization(ctx, url)
+	if err != nil {
+		return err
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("no dns-01 challenge offered for %s", authz.Identifier.Value)
+	}
+
+	value, err := m.client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return err
+	}
+	record, err := m.conf.DNS.NewTXT("_acme-challenge."+authz.Identifier.Value, value)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := m.conf.DNS.Delete(record.ID); err != nil {
+			log.Errorf("error deleting challenge record: %v", err)
+		}
+	}()
+
+	if _, err = m.client.Accept(ctx, chal); err != nil {
+		return err
+	}
+	_, err = m.client.WaitAuthorization(ctx, authz.URI)
+	return err
+}
+
+// needsRenewal returns whether or not cert is missing or close to expiry.
+func needsRenewal(cert *tls.Certificate) bool {
+	return cert == nil || cert.Leaf == nil || time.Until(cert.Leaf.NotAfter) < renewBefore
+}
+
+// loadAccountKey loads the ACME account key, creating it if needed.
+func (m *Manager) loadAccountKey() (crypto.Signer, error) {
+	pth := filepath.Join(m.conf.CacheDir, accountKeyFile)
+	data, err := ioutil.ReadFile(pth)
+	if os.IsNotExist(err) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		data = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+		if err = ioutil.WriteFile(pth, data, 0600); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid account key in %s", pth)
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// loadCert loads the cached certificate. It must cover the configured domains.
+func (m *Manager) loadCert() (*tls.Certificate, error) {
+	pth := filepath.Join(m.conf.CacheDir, cachedCertFile)
+	cert, err := tls.LoadX509KeyPair(pth, pth)
+	if err != nil {
+		return nil, err
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return nil, err
+	}
+	for _, d := range m.conf.ACMEDomains {
+		if err = cert.Leaf.VerifyHostname(strings.Replace(d, "*", "x", 1)); err != nil {
+			return nil, err
+		}
+	}
+	return &cert, nil
+}
+
+// saveCert caches a certificate chain and its key in a single PEM file.
+func (m *Manager) saveCert(cert *tls.Certificate, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	for _, c := range cert.Certificate {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c})...)
+	}
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})...)
+	return ioutil.WriteFile(filepath.Join(m.conf.CacheDir, cachedCertFile), data, 0600)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc/status"
 )
 
 type Flag struct {
@@ -19,7 +20,9 @@ type Flag struct {
 	DefValue interface{}
 }
 
-func InitConfig(v *viper.Viper, file string, defDir string, name string) func() {
+// InitConfig returns a func that reads the named config into v. If profile
+// is not nil, the settings it returns override those in the config file.
+func InitConfig(v *viper.Viper, file string, defDir string, name string, profile func() map[string]interface{}) func() {
 	return func() {
 		if file != "" {
 			v.SetConfigFile(file)
@@ -37,6 +40,14 @@ func InitConfig(v *viper.Viper, file string, defDir string, name string) func()
 		v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 		v.AutomaticEnv()
 		_ = v.ReadInConfig()
+
+		if profile != nil {
+			if settings := profile(); len(settings) > 0 {
+				if err := v.MergeConfigMap(settings); err != nil {
+					Fatal(err)
+				}
+			}
+		}
 	}
 }
 
@@ -69,11 +80,11 @@ func AddrFromStr(str string) ma.Multiaddr {
 }
 
 func Message(format string, args ...interface{}) {
-	fmt.Println(aurora.Sprintf(aurora.BrightBlack("> "+format), args...))
+	fmt.Fprintln(messages(), aurora.Sprintf(aurora.BrightBlack("> "+format), args...))
 }
 
 func Success(format string, args ...interface{}) {
-	fmt.Println(aurora.Sprintf(aurora.Cyan("> Success! %s"),
+	fmt.Fprintln(messages(), aurora.Sprintf(aurora.Cyan("> Success! %s"),
 		aurora.Sprintf(aurora.BrightBlack(format), args...)))
 }
 
@@ -82,13 +93,33 @@ func End(format string, args ...interface{}) {
 	os.Exit(0)
 }
 
+// Fatal prints err and exits with its exit code. Errors are written as
+// objects with code and message fields unless the output format is a table.
 func Fatal(err error, args ...interface{}) {
-	words := strings.SplitN(err.Error(), " ", 2)
+	st := status.Convert(err)
+	words := strings.SplitN(st.Message(), " ", 2)
 	words[0] = strings.Title(words[0])
 	msg := strings.Join(words, " ")
-	fmt.Println(aurora.Sprintf(aurora.Red("> Error! %s"),
+	if Output != OutputTable {
+		plain := make([]interface{}, len(args))
+		for i, a := range args {
+			if v, ok := a.(aurora.Value); ok {
+				a = v.Value()
+			}
+			plain[i] = a
+		}
+		out, merr := marshal(map[string]interface{}{
+			"code":    st.Code().String(),
+			"message": fmt.Sprintf(msg, plain...),
+		})
+		if merr == nil {
+			_, _ = os.Stderr.Write(out)
+			os.Exit(ExitCode(err))
+		}
+	}
+	fmt.Fprintln(messages(), aurora.Sprintf(aurora.Red("> Error! %s"),
 		aurora.Sprintf(aurora.BrightBlack(msg), args...)))
-	os.Exit(1)
+	os.Exit(ExitCode(err))
 }
 
 func RenderTable(header []string, data [][]string) {
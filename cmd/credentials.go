@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path"
+	"strings"
+
+	"github.com/99designs/keyring"
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	// PassphraseEnv holds the passphrase of the encrypted credentials file,
+	// which is only used if no OS keyring is available. A key derived from
+	// the machine and user is used if it's not set.
+	PassphraseEnv = "TXTL_PASSPHRASE"
+
+	// DefaultAccount is the credential account used without a profile.
+	DefaultAccount = "default"
+
+	credentialsService = "textile"
+	credentialsName    = "credentials"
+)
+
+// Credentials stores auth tokens by account, e.g., a profile name, in the OS
+// keyring, or in files encrypted with a passphrase if no keyring is available.
+type Credentials struct {
+	ring keyring.Keyring
+	dir  string
+}
+
+// OpenCredentials opens the credentials of the user. The fallback files are
+// kept in defDir under the user's home directory.
+func OpenCredentials(defDir string) (*Credentials, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+	dir := path.Join(home, defDir, credentialsName)
+	ring, err := keyring.Open(keyring.Config{
+		ServiceName: credentialsService,
+		AllowedBackends: []keyring.BackendType{
+			keyring.KeychainBackend,
+			keyring.WinCredBackend,
+			keyring.SecretServiceBackend,
+			keyring.KWalletBackend,
+			keyring.FileBackend,
+		},
+		KeychainTrustApplication: true,
+		FileDir:                  dir,
+		FilePasswordFunc:         filePassphrase,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Credentials{ring: ring, dir: dir}, nil
+}
+
+// Get returns the token of account, or an empty string if there is none.
+func (c *Credentials) Get(account string) (string, error) {
+	item, err := c.ring.Get(account)
+	if errors.Is(err, keyring.ErrKeyNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("reading token of %s: %v", account, err)
+	}
+	return string(item.Data), nil
+}
+
+// Set stores the token of account. Fallback files are only readable by the user.
+func (c *Credentials) Set(account, token string) error {
+	if err := c.ring.Set(keyring.Item{
+		Key:         account,
+		Data:        []byte(token),
+		Label:       "Textile (" + account + ")",
+		Description: "Textile auth token",
+	}); err != nil {
+		return err
+	}
+	// The file backend only sets permissions on files it creates.
+	if err := os.Chmod(path.Join(c.dir, account), 0600); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Remove deletes the token of account, if any.
+func (c *Credentials) Remove(account string) error {
+	err := c.ring.Remove(account)
+	if errors.Is(err, keyring.ErrKeyNotFound) || os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// filePassphrase returns the passphrase from PassphraseEnv, or the machine key.
+func filePassphrase(string) (string, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return p, nil
+	}
+	return machineKey()
+}
+
+// machineKey derives a key from the machine ID, or the hostname if there is
+// none, and the current user. It only protects files copied off the machine.
+func machineKey() (string, error) {
+	var id string
+	for _, p := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		if data, err := ioutil.ReadFile(p); err == nil {
+			id = strings.TrimSpace(string(data))
+			break
+		}
+	}
+	if id == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return "", err
+		}
+		id = host
+	}
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(credentialsService + ":" + id + ":" + u.Uid))
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+var (
+	// Output is the format of command results. Messages are written to
+	// stderr unless it's OutputTable, so stdout only contains results.
+	Output = OutputTable
+	// Interactive is false if commands must not prompt for input.
+	Interactive = true
+)
+
+// SetOutput sets the output format.
+func SetOutput(format string) error {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML:
+		Output = format
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %s (use %s, %s, or %s)",
+			format, OutputTable, OutputJSON, OutputYAML)
+	}
+}
+
+// SetInteractive enables prompts unless disabled by yes or stdin isn't a terminal.
+func SetInteractive(yes bool) {
+	Interactive = !yes && terminal.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Render writes a command result. Tables are rendered from header and data,
+// other formats from v, which may be a protobuf message.
+func Render(v interface{}, header []string, data [][]string) {
+	if Output == OutputTable {
+		if len(data) > 0 {
+			RenderTable(header, data)
+		}
+		return
+	}
+	out, err := marshal(v)
+	if err != nil {
+		Fatal(err)
+	}
+	_, _ = os.Stdout.Write(out)
+}
+
+// ExitCode returns the process exit code for err. Errors carrying a gRPC
+// status exit with its code, e.g., 5 for NotFound and 16 for Unauthenticated.
+// Other errors exit with 2, the code of Unknown.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return int(status.Code(err))
+}
+
+// messages returns the writer for messages.
+func messages() io.Writer {
+	if Output == OutputTable {
+		return os.Stdout
+	}
+	return os.Stderr
+}
+
+// marshal encodes v in the output format.
+func marshal(v interface{}) ([]byte, error) {
+	var data []byte
+	if m, ok := v.(proto.Message); ok {
+		var buf bytes.Buffer
+		marshaler := &jsonpb.Marshaler{OrigName: true, EmitDefaults: true, Indent: "  "}
+		if err := marshaler.Marshal(&buf, m); err != nil {
+			return nil, err
+		}
+		data = buf.Bytes()
+	} else {
+		var err error
+		if data, err = json.MarshalIndent(v, "", "  "); err != nil {
+			return nil, err
+		}
+	}
+	if Output == OutputJSON {
+		return append(data, '\n'), nil
+	}
+	var obj interface{}
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(obj)
+}
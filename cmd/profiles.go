@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ProfileEnv names a profile that overrides the current one.
+	ProfileEnv = "TXTL_PROFILE"
+
+	profilesName = "profiles.yml"
+)
+
+// Profile holds the settings for one server and account.
+// Field names match config keys. Tokens are kept in Credentials by profile name.
+type Profile struct {
+	APITarget   string `yaml:"api_target" json:"api_target"`
+	APITLS      bool   `yaml:"api_tls" json:"api_tls"`
+	APIInsecure bool   `yaml:"api_insecure,omitempty" json:"api_insecure"`
+	APICAFile   string `yaml:"api_ca_file,omitempty" json:"api_ca_file"`
+	Scope       string `yaml:"scope,omitempty" json:"scope"`
+}
+
+// ConfigSettings returns the profile's config settings by key.
+func (p *Profile) ConfigSettings() map[string]interface{} {
+	s := map[string]interface{}{
+		"api_target":   p.APITarget,
+		"api_tls":      p.APITLS,
+		"api_insecure": p.APIInsecure,
+		"api_ca_file":  p.APICAFile,
+	}
+	if p.Scope != "" {
+		s["scope"] = p.Scope
+	}
+	return s
+}
+
+// Profiles are named profiles stored in the user's config directory.
+type Profiles struct {
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+
+	path string
+}
+
+// LoadProfiles reads the profiles in defDir under the user's home directory.
+// No profiles are returned if the file doesn't exist yet.
+func LoadProfiles(defDir string) (*Profiles, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+	p := &Profiles{path: path.Join(home, defDir, profilesName)}
+	data, err := ioutil.ReadFile(p.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	if p.Profiles == nil {
+		p.Profiles = make(map[string]*Profile)
+	}
+	return p, nil
+}
+
+// Active returns the name and settings of the profile selected by flag,
+// ProfileEnv, or the current profile, in that order. A nil profile is
+// returned if none is selected.
+func (p *Profiles) Active(flag string) (string, *Profile, error) {
+	name := flag
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+	if name == "" {
+		name = p.Current
+	}
+	if name == "" {
+		return "", nil, nil
+	}
+	profile, ok := p.Profiles[name]
+	if !ok {
+		return "", nil, fmt.Errorf("profile %s not found", name)
+	}
+	return name, profile, nil
+}
+
+// Save writes the profiles readable only by the user.
+func (p *Profiles) Save() error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(path.Dir(p.path), os.ModePerm); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(p.path, data, 0600); err != nil {
+		return err
+	}
+	return os.Chmod(p.path, 0600)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/caarlos0/spin"
+	"github.com/logrusorgru/aurora"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	api "github.com/textileio/textile/api/client"
+	"github.com/textileio/textile/cmd"
+)
+
+func init() {
+	rootCmd.AddCommand(accountCmd)
+	accountCmd.AddCommand(updateAccountCmd)
+
+	updateAccountCmd.Flags().String(
+		"name",
+		"",
+		"Display name")
+
+	updateAccountCmd.Flags().String(
+		"username",
+		"",
+		"Username")
+
+	updateAccountCmd.Flags().String(
+		"email",
+		"",
+		"New email address, which must be confirmed")
+}
+
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Account management",
+	Long:  `Show and update the profile of your account.`,
+	Run: func(c *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		profile, err := client.GetProfile(
+			ctx,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			})
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Render(profile, []string{"name", "username", "email", "id", "created"},
+			[][]string{{profile.Name, profile.Username, profile.Email, profile.ID,
+				time.Unix(profile.Created, 0).Format(time.RFC3339)}})
+	},
+}
+
+var updateAccountCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update your profile",
+	Long: `Update your display name, username, or email address. Fields without a flag
+are unchanged, or prompted for if no flags are given. A new email address is
+only saved after you follow the link sent to it.`,
+	Args: cobra.NoArgs,
+	Run: func(c *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		profile, err := client.GetProfile(
+			ctx,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			})
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		name, username, email := profile.Name, profile.Username, profile.Email
+		if !c.Flags().Changed("name") && !c.Flags().Changed("username") && !c.Flags().Changed("email") {
+			requireInteractive("flags for the fields to update")
+			for _, f := range []struct {
+				label string
+				val   *string
+			}{
+				{"Display name", &name},
+				{"Username", &username},
+				{"Email", &email},
+			} {
+				prompt := promptui.Prompt{
+					Label:   f.label,
+					Default: *f.val,
+				}
+				if *f.val, err = prompt.Run(); err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+		if c.Flags().Changed("name") {
+			name, _ = c.Flags().GetString("name")
+		}
+		if c.Flags().Changed("username") {
+			username, _ = c.Flags().GetString("username")
+		}
+		if c.Flags().Changed("email") {
+			email, _ = c.Flags().GetString("email")
+		}
+
+		timeout := cmdTimeout
+		s := spin.New("%s Waiting for your confirmation")
+		if email != profile.Email {
+			timeout = loginTimeout
+			cmd.Message("We sent an email to %s. Please follow the steps provided inside it.",
+				aurora.White(email).Bold())
+			if cmd.Interactive {
+				s.Start()
+			}
+		}
+
+		uctx, ucancel := context.WithTimeout(context.Background(), timeout)
+		defer ucancel()
+		err = client.UpdateProfile(
+			uctx,
+			name,
+			username,
+			email,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			})
+		s.Stop()
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Success("Updated your profile")
+	},
+}
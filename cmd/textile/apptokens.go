@@ -9,6 +9,8 @@ import (
 	"github.com/spf13/cobra"
 	api "github.com/textileio/textile/api/client"
 	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -17,6 +19,11 @@ func init() {
 		addAppTokensCmd,
 		lsAppTokensCmd,
 		rmAppTokensCmd)
+
+	addAppTokensCmd.Flags().StringSlice(
+		"origin",
+		nil,
+		"Browser origin allowed to register app users with the token (repeatable)")
 }
 
 var appTokensCmd = &cobra.Command{
@@ -34,16 +41,23 @@ var appTokensCmd = &cobra.Command{
 var addAppTokensCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add app token",
-	Long:  `Add a new application token (interactive).`,
+	Long:  `Add a new application token (interactive without --id).`,
+	Args:  cobra.NoArgs,
 	Run: func(c *cobra.Command, args []string) {
 		project := selectProject("Select project", aurora.Sprintf(
-			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")))
+			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), nil)
+
+		origins, err := c.Flags().GetStringSlice("origin")
+		if err != nil {
+			cmd.Fatal(err)
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
 		token, err := client.AddAppToken(
 			ctx,
 			project.ID,
+			origins,
 			api.Auth{
 				Token: authViper.GetString("token"),
 			})
@@ -51,6 +65,7 @@ var addAppTokensCmd = &cobra.Command{
 			cmd.Fatal(err)
 		}
 
+		cmd.Render(token, nil, nil)
 		cmd.Success("Added new app token %s", aurora.White(token.ID).Bold())
 	},
 }
@@ -61,7 +76,8 @@ var lsAppTokensCmd = &cobra.Command{
 		"list",
 	},
 	Short: "List app tokens",
-	Long:  `List application tokens for a project.`,
+	Long:  `List application tokens for a project (interactive without --id).`,
+	Args:  cobra.NoArgs,
 	Run: func(c *cobra.Command, args []string) {
 		lsTokens()
 	},
@@ -69,7 +85,7 @@ var lsAppTokensCmd = &cobra.Command{
 
 func lsTokens() {
 	project := selectProject("Select project", aurora.Sprintf(
-		aurora.BrightBlack("> Selected {{ .Name | white | bold }}")))
+		aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 	defer cancel()
@@ -83,31 +99,30 @@ func lsTokens() {
 		cmd.Fatal(err)
 	}
 
-	if len(tokens.List) > 0 {
-		data := make([][]string, len(tokens.List))
-		for i, t := range tokens.List {
-			data[i] = []string{t}
-		}
-		cmd.RenderTable([]string{"id"}, data)
+	data := make([][]string, len(tokens.List))
+	for i, t := range tokens.List {
+		data[i] = []string{t}
 	}
+	cmd.Render(tokens, []string{"id"}, data)
 
 	cmd.Message("Found %d tokens", aurora.White(len(tokens.List)).Bold())
 }
 
 var rmAppTokensCmd = &cobra.Command{
-	Use: "rm",
+	Use: "rm [token]",
 	Aliases: []string{
 		"remove",
 	},
 	Short: "Remove an app token",
-	Long:  `Remove an app token (interactive).`,
+	Long:  `Remove an app token (interactive without --id and a token).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		project := selectProject("Select project", aurora.Sprintf(
-			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")))
+			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), nil)
 
 		selected := selectToken("Remove app token", aurora.Sprintf(
 			aurora.BrightBlack("> Removing token {{ . | white | bold }}")),
-			project.ID)
+			project.ID, args)
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -124,7 +139,9 @@ var rmAppTokensCmd = &cobra.Command{
 	},
 }
 
-func selectToken(label, successMsg, projID string) string {
+// selectToken returns the token given as the first arg, or prompts for one
+// if there are no args.
+func selectToken(label, successMsg, projID string, args []string) string {
 	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 	defer cancel()
 	tokens, err := client.ListAppTokens(
@@ -137,9 +154,18 @@ func selectToken(label, successMsg, projID string) string {
 		cmd.Fatal(err)
 	}
 
+	if len(args) > 0 {
+		for _, t := range tokens.List {
+			if t == args[0] {
+				return t
+			}
+		}
+		cmd.Fatal(status.Errorf(codes.NotFound, "token %s not found", args[0]))
+	}
 	if len(tokens.List) == 0 {
 		cmd.End("You don't have any tokens!")
 	}
+	requireInteractive("a token")
 
 	prompt := promptui.Select{
 		Label: label,
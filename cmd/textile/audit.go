@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/cobra"
+	api "github.com/textileio/textile/api/client"
+	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().String(
+		"since",
+		"",
+		"Only list events at or after this time, given as RFC3339 or a duration ago, e.g., 24h")
+
+	auditCmd.Flags().String(
+		"until",
+		"",
+		"Only list events before this time, given as RFC3339 or a duration ago")
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List audit events",
+	Long: `List the audit events of the current scope, oldest first. Events record who
+changed what, when, and from which IP address.`,
+	Args: cobra.NoArgs,
+	Run: func(c *cobra.Command, args []string) {
+		since := parseTimeFlag(c, "since")
+		until := parseTimeFlag(c, "until")
+
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		events, err := client.ListAuditEvents(
+			ctx,
+			since,
+			until,
+			api.Auth{
+				Token: authViper.GetString("token"),
+				Scope: configViper.GetString("scope"),
+			})
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		data := make([][]string, len(events.List))
+		for i, e := range events.List {
+			data[i] = []string{time.Unix(e.Created, 0).Format(time.RFC3339), e.Action, e.ActorID, e.TargetID, e.IP}
+		}
+		cmd.Render(events, []string{"time", "action", "actor", "target", "ip"}, data)
+
+		cmd.Message("Found %d audit events for current scope", aurora.White(len(events.List)).Bold())
+	},
+}
+
+// parseTimeFlag returns the Unix time of a flag given as RFC3339 or a duration
+// before now, or zero if the flag is not set.
+func parseTimeFlag(c *cobra.Command, name string) int64 {
+	v, err := c.Flags().GetString(name)
+	if err != nil {
+		cmd.Fatal(err)
+	}
+	if v == "" {
+		return 0
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.Unix()
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		cmd.Fatal(status.Error(codes.InvalidArgument,
+			"invalid --%s, expected a time in RFC3339 format or a duration"), name)
+	}
+	return time.Now().Add(-d).Unix()
+}
@@ -2,18 +2,16 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"net/mail"
-	"os"
-	"path"
 
 	"github.com/logrusorgru/aurora"
 
 	"github.com/caarlos0/spin"
 	"github.com/manifoldco/promptui"
-	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -21,20 +19,32 @@ func init() {
 }
 
 var loginCmd = &cobra.Command{
-	Use:   "login",
+	Use:   "login [email]",
 	Short: "Login",
-	Long:  `Login to Textile.`,
+	Long:  `Login to Textile (interactive without an email).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
-		prompt := promptui.Prompt{
-			Label: "Enter your email",
-			Validate: func(email string) error {
-				_, err := mail.ParseAddress(email)
-				return err
-			},
+		validate := func(email string) error {
+			_, err := mail.ParseAddress(email)
+			return err
 		}
-		email, err := prompt.Run()
-		if err != nil {
-			log.Fatal(err)
+		var email string
+		var err error
+		if len(args) > 0 {
+			email = args[0]
+			if err = validate(email); err != nil {
+				cmd.Fatal(status.Error(codes.InvalidArgument, err.Error()))
+			}
+		} else {
+			requireInteractive("an email address")
+			prompt := promptui.Prompt{
+				Label:    "Enter your email",
+				Validate: validate,
+			}
+			email, err = prompt.Run()
+			if err != nil {
+				log.Fatal(err)
+			}
 		}
 
 		// @todo: Add a security code that can be visually verified in the email.
@@ -42,34 +52,26 @@ var loginCmd = &cobra.Command{
 			aurora.White(email).Bold())
 
 		s := spin.New("%s Waiting for your confirmation")
-		s.Start()
+		if cmd.Interactive {
+			s.Start()
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
 		defer cancel()
 		res, err := client.Login(ctx, email)
-		s.Stop()
-		if err != nil {
-			cmd.Fatal(err)
+		if cmd.Interactive {
+			s.Stop()
 		}
-
-		authViper.Set("token", res.SessionID)
-
-		home, err := homedir.Dir()
 		if err != nil {
 			cmd.Fatal(err)
 		}
-		dir := path.Join(home, ".textile")
-		if err = os.MkdirAll(dir, os.ModePerm); err != nil {
-			cmd.Fatal(err)
-		}
-
-		filename := path.Join(dir, "auth.yml")
 
-		if err := authViper.WriteConfigAs(filename); err != nil {
+		if err := creds.Set(account(), res.SessionID); err != nil {
 			cmd.Fatal(err)
 		}
+		removeAuthFile()
 
-		fmt.Println(aurora.Sprintf("%s Email confirmed", aurora.Green("✔")))
+		cmd.Message("%s Email confirmed", aurora.Green("✔"))
 		cmd.Success("You are now logged in. Initialize a new project directory with `%s`.",
 			aurora.Cyan("textile init"))
 	},
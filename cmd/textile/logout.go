@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	api "github.com/textileio/textile/api/client"
 	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -16,18 +17,33 @@ func init() {
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Logout",
-	Long:  `Logout of Textile.`,
+	Long: `Logout of Textile. The stored token is removed even if the session
+can't be ended on the server, e.g., when it's unreachable.`,
 	Run: func(c *cobra.Command, args []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
-		if err := client.Logout(ctx, api.Auth{
+		logoutErr := client.Logout(ctx, api.Auth{
 			Token: authViper.GetString("token"),
-		}); err != nil {
+		})
+
+		if err := creds.Remove(account()); err != nil {
 			cmd.Fatal(err)
 		}
+		removeAuthFile()
 
-		_ = os.RemoveAll(authViper.ConfigFileUsed())
-
+		if logoutErr != nil {
+			s := status.Convert(logoutErr)
+			cmd.Fatal(status.Errorf(s.Code(), "removed the local token, but the session wasn't ended: %s", s.Message()))
+		}
 		cmd.Success("Bye :)")
 	},
 }
+
+// removeAuthFile removes the auth file of older versions, which held the token in plain text.
+func removeAuthFile() {
+	if f := authViper.ConfigFileUsed(); f != "" {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			cmd.Fatal(err)
+		}
+	}
+}
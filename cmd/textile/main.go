@@ -3,7 +3,11 @@ package main
 import (
 	"context"
 	"crypto/tls"
-	"errors"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
 	"strings"
 	"time"
 
@@ -14,7 +18,10 @@ import (
 	"github.com/textileio/go-threads/util"
 	api "github.com/textileio/textile/api/client"
 	"github.com/textileio/textile/cmd"
+	"github.com/textileio/textile/tracing"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -54,9 +61,34 @@ var (
 			Key:      "api_target",
 			DefValue: "api.textile.io:443",
 		},
+		"tls": {
+			Key:      "api_tls",
+			DefValue: false,
+		},
+		"insecure": {
+			Key:      "api_insecure",
+			DefValue: false,
+		},
+		"caFile": {
+			Key:      "api_ca_file",
+			DefValue: "",
+		},
+		"output": {
+			Key:      "output",
+			DefValue: cmd.OutputTable,
+		},
+		"yes": {
+			Key:      "yes",
+			DefValue: false,
+		},
+		"otlpEndpoint": {
+			Key:      "otlp_endpoint",
+			DefValue: "",
+		},
 	}
 
-	client *api.Client
+	client   *api.Client
+	exporter *tracing.Exporter
 
 	cmdTimeout   = time.Second * 10
 	loginTimeout = time.Minute * 3
@@ -65,8 +97,20 @@ var (
 func init() {
 	rootCmd.AddCommand(whoamiCmd, switchCmd)
 
-	cobra.OnInitialize(cmd.InitConfig(authViper, authFile, ".textile", "auth"))
-	cobra.OnInitialize(cmd.InitConfig(configViper, configFile, ".textile", "config"))
+	// The active profile must be loaded before the configs it overrides.
+	cobra.OnInitialize(loadProfile)
+	cobra.OnInitialize(cmd.InitConfig(authViper, authFile, ".textile", "auth", storedToken))
+	cobra.OnInitialize(cmd.InitConfig(configViper, configFile, ".textile", "config", func() map[string]interface{} {
+		if profile == nil {
+			return nil
+		}
+		return profile.ConfigSettings()
+	}))
+
+	rootCmd.PersistentFlags().String(
+		"profile",
+		"",
+		"Profile to use instead of the current one (also "+cmd.ProfileEnv+")")
 
 	rootCmd.PersistentFlags().StringP(
 		"token",
@@ -110,6 +154,38 @@ func init() {
 		flags["apiTarget"].DefValue.(string),
 		"Textile gRPC API Target")
 
+	rootCmd.PersistentFlags().Bool(
+		"tls",
+		flags["tls"].DefValue.(bool),
+		"Connect to the API with TLS (default true unless the target is a loopback address)")
+
+	rootCmd.PersistentFlags().Bool(
+		"insecure",
+		flags["insecure"].DefValue.(bool),
+		"Skip verification of the API's TLS certificate")
+
+	rootCmd.PersistentFlags().String(
+		"caFile",
+		flags["caFile"].DefValue.(string),
+		"PEM encoded CA bundle for verifying the API's TLS certificate (default system roots)")
+
+	rootCmd.PersistentFlags().StringP(
+		"output",
+		"o",
+		flags["output"].DefValue.(string),
+		"Output format (table, json, or yaml)")
+
+	rootCmd.PersistentFlags().BoolP(
+		"yes",
+		"y",
+		flags["yes"].DefValue.(bool),
+		"Never prompt; selections must be given with arguments or flags")
+
+	rootCmd.PersistentFlags().String(
+		"otlpEndpoint",
+		flags["otlpEndpoint"].DefValue.(string),
+		"OTLP/HTTP collector URL to export request traces to (disabled if empty)")
+
 	if err := cmd.BindFlags(configViper, rootCmd, flags); err != nil {
 		cmd.Fatal(err)
 	}
@@ -124,7 +200,12 @@ func main() {
 var rootCmd = &cobra.Command{
 	Use:   "textile",
 	Short: "Textile client",
-	Long:  `The Textile client.`,
+	Long: `The Textile client.
+
+Commands don't prompt when --yes is set or stdin isn't a terminal, in which
+case selections must be given with arguments or flags. Failed commands exit
+with the gRPC status code of the error, e.g., 5 for NotFound, 7 for
+PermissionDenied, and 16 for Unauthenticated, or 2 for other errors.`,
 	PersistentPreRun: func(c *cobra.Command, args []string) {
 		authViper.SetConfigType("yaml")
 		configViper.SetConfigType("yaml")
@@ -132,9 +213,18 @@ var rootCmd = &cobra.Command{
 		cmd.ExpandConfigVars(authViper, flags)
 		cmd.ExpandConfigVars(configViper, flags)
 
+		if err := cmd.SetOutput(configViper.GetString("output")); err != nil {
+			cmd.Fatal(status.Error(codes.InvalidArgument, err.Error()))
+		}
+		cmd.SetInteractive(configViper.GetBool("yes"))
+
+		if c == profileCmd || c.Parent() == profileCmd {
+			return
+		}
+
 		if authViper.GetString("token") == "" && c.Use != "login" {
 			msg := "unauthorized! run `%s` or use `%s` to authorize"
-			cmd.Fatal(errors.New(msg),
+			cmd.Fatal(status.Error(codes.Unauthenticated, msg),
 				aurora.Cyan("textile login"), aurora.Cyan("--token"))
 		}
 
@@ -146,13 +236,20 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
-		target := configViper.GetString("api_target")
-		var creds credentials.TransportCredentials
-		if strings.Contains(target, "443") {
-			creds = credentials.NewTLS(&tls.Config{})
-		}
 		var err error
-		client, err = api.NewClient(target, creds)
+		exporter, err = tracing.Start(tracing.Config{
+			Service:      "textile",
+			OTLPEndpoint: configViper.GetString("otlp_endpoint"),
+		})
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		creds, err := transportCredentials(c)
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		client, err = api.NewClient(configViper.GetString("api_target"), creds)
 		if err != nil {
 			cmd.Fatal(err)
 		}
@@ -163,9 +260,68 @@ var rootCmd = &cobra.Command{
 				cmd.Fatal(err)
 			}
 		}
+		exporter.Close()
 	},
 }
 
+// transportCredentials returns the API connection credentials,
+// or nil if TLS is disabled.
+func transportCredentials(c *cobra.Command) (credentials.TransportCredentials, error) {
+	if !useTLS(c) {
+		return nil, nil
+	}
+	conf := &tls.Config{
+		InsecureSkipVerify: configViper.GetBool("api_insecure"),
+	}
+	if caFile := configViper.GetString("api_ca_file"); caFile != "" {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+	}
+	return credentials.NewTLS(conf), nil
+}
+
+// useTLS returns whether or not to connect to the API with TLS. Unless it's
+// set with the tls flag, config, or a profile, TLS is used for all but loopback
+// targets, so a local textiled works without it.
+func useTLS(c *cobra.Command) bool {
+	_, env := os.LookupEnv("TXTL_API_TLS")
+	if c.Flags().Changed("tls") || configViper.InConfig("api_tls") || env {
+		return configViper.GetBool("api_tls")
+	}
+	return !isLoopback(configViper.GetString("api_target"))
+}
+
+// isLoopback returns whether or not a gRPC target is a loopback address.
+func isLoopback(target string) bool {
+	if i := strings.LastIndex(target, "/"); i >= 0 {
+		target = target[i+1:] // drop a scheme, e.g., dns:///
+	}
+	host, _, err := net.SplitHostPort(target)
+	if err != nil {
+		host = target
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// requireInteractive exits with an InvalidArgument error if prompting for
+// what isn't allowed.
+func requireInteractive(what string) {
+	if !cmd.Interactive {
+		cmd.Fatal(status.Errorf(codes.InvalidArgument,
+			"%s is required when prompts are disabled", what))
+	}
+}
+
 var whoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Show user or team",
@@ -183,6 +339,11 @@ var whoamiCmd = &cobra.Command{
 			cmd.Fatal(err)
 		}
 
+		var data [][]string
+		if who.TeamID != "" {
+			data = [][]string{{who.TeamName, who.TeamDescription, who.TeamWebsite, who.TeamAvatar}}
+		}
+		cmd.Render(who, []string{"team", "description", "website", "avatar"}, data)
 		if who.TeamID != "" {
 			cmd.Message("You are %s in the %s team",
 				aurora.White(who.Email).Bold(), aurora.White(who.TeamName).Bold())
@@ -193,13 +354,14 @@ var whoamiCmd = &cobra.Command{
 }
 
 var switchCmd = &cobra.Command{
-	Use:   "switch",
+	Use:   "switch [id]",
 	Short: "Switch teams or personal account",
-	Long:  `Switch between teams and your personal account.`,
+	Long:  `Switch between teams and your personal account (interactive without an ID or name).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		selected := selectTeam("Switch to", aurora.Sprintf(
 			aurora.BrightBlack("> Switching to {{ .Name | white | bold }}")),
-			true)
+			true, args)
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/cobra"
+	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	profiles    *cmd.Profiles
+	profile     *cmd.Profile
+	profileName string
+
+	creds *cmd.Credentials
+)
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(
+		addProfileCmd,
+		useProfileCmd,
+		lsProfileCmd,
+		rmProfileCmd)
+}
+
+// loadProfile loads the profiles and selects the active one.
+func loadProfile() {
+	var err error
+	profiles, err = cmd.LoadProfiles(".textile")
+	if err != nil {
+		cmd.Fatal(err)
+	}
+	flag, err := rootCmd.PersistentFlags().GetString("profile")
+	if err != nil {
+		cmd.Fatal(err)
+	}
+	profileName, profile, err = profiles.Active(flag)
+	if err != nil {
+		cmd.Fatal(status.Error(codes.NotFound, err.Error()))
+	}
+	creds, err = cmd.OpenCredentials(".textile")
+	if err != nil {
+		cmd.Fatal(err)
+	}
+}
+
+// account returns the credential account of the active profile.
+func account() string {
+	if profileName == "" {
+		return cmd.DefaultAccount
+	}
+	return profileName
+}
+
+// storedToken returns the token stored for the active profile.
+func storedToken() map[string]interface{} {
+	token, err := creds.Get(account())
+	if err != nil {
+		cmd.Fatal(err)
+	}
+	if token == "" {
+		return nil
+	}
+	return map[string]interface{}{"token": token}
+}
+
+var profileCmd = &cobra.Command{
+	Use: "profile",
+	Aliases: []string{
+		"profiles",
+	},
+	Short: "Profile management",
+	Long: `Manage named profiles holding the API target, TLS settings, default scope,
+of different servers and accounts. Auth tokens are kept in the OS keyring, or
+in an encrypted file if no keyring is available.`,
+	Run: func(c *cobra.Command, args []string) {
+		lsProfiles()
+	},
+}
+
+var addProfileCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Add a profile",
+	Long: `Add or replace a profile with the current settings, which may be given with
+flags, e.g., --apiTarget and --tls. The auth token is only saved if given with
+--token. The first profile becomes the current one.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		p := &cmd.Profile{
+			APITarget:   configViper.GetString("api_target"),
+			APITLS:      useTLS(c),
+			APIInsecure: configViper.GetBool("api_insecure"),
+			APICAFile:   configViper.GetString("api_ca_file"),
+			Scope:       configViper.GetString("scope"),
+		}
+		if c.Flags().Changed("token") {
+			if err := creds.Set(args[0], authViper.GetString("token")); err != nil {
+				cmd.Fatal(err)
+			}
+		}
+		profiles.Profiles[args[0]] = p
+		if profiles.Current == "" {
+			profiles.Current = args[0]
+		}
+		if err := profiles.Save(); err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Render(p, nil, nil)
+		cmd.Success("Added profile %s", aurora.White(args[0]).Bold())
+	},
+}
+
+var useProfileCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Use a profile",
+	Long:  `Make a profile the current one.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		if _, ok := profiles.Profiles[args[0]]; !ok {
+			cmd.Fatal(status.Errorf(codes.NotFound, "profile %s not found", args[0]))
+		}
+		profiles.Current = args[0]
+		if err := profiles.Save(); err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Success("Using profile %s", aurora.White(args[0]).Bold())
+	},
+}
+
+var lsProfileCmd = &cobra.Command{
+	Use: "ls",
+	Aliases: []string{
+		"list",
+	},
+	Short: "List profiles",
+	Long:  `List profiles. The active profile is marked.`,
+	Run: func(c *cobra.Command, args []string) {
+		lsProfiles()
+	},
+}
+
+type profileItem struct {
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+	LoggedIn bool   `json:"logged_in"`
+	*cmd.Profile
+}
+
+func lsProfiles() {
+	names := make([]string, 0, len(profiles.Profiles))
+	for n := range profiles.Profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	items := make([]profileItem, len(names))
+	data := make([][]string, len(names))
+	for i, n := range names {
+		p := profiles.Profiles[n]
+		token, err := creds.Get(n)
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		items[i] = profileItem{Name: n, Active: n == profileName, LoggedIn: token != "", Profile: p}
+		var mark string
+		if items[i].Active {
+			mark = "*"
+		}
+		data[i] = []string{mark, n, p.APITarget, strconv.FormatBool(p.APITLS), p.Scope,
+			strconv.FormatBool(items[i].LoggedIn)}
+	}
+	cmd.Render(items, []string{"", "name", "api target", "tls", "scope", "logged in"}, data)
+
+	cmd.Message("Found %d profiles", aurora.White(len(names)).Bold())
+}
+
+var rmProfileCmd = &cobra.Command{
+	Use: "rm [name]",
+	Aliases: []string{
+		"remove",
+	},
+	Short: "Remove a profile",
+	Long:  `Remove a profile and its stored token. Removing the current profile leaves no profile in use.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		if _, ok := profiles.Profiles[args[0]]; !ok {
+			cmd.Fatal(status.Errorf(codes.NotFound, "profile %s not found", args[0]))
+		}
+		if err := creds.Remove(args[0]); err != nil {
+			cmd.Fatal(err)
+		}
+		delete(profiles.Profiles, args[0])
+		if profiles.Current == args[0] {
+			profiles.Current = ""
+		}
+		if err := profiles.Save(); err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Success("Removed profile %s", aurora.White(args[0]).Bold())
+	},
+}
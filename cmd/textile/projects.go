@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -10,23 +11,29 @@ import (
 	"github.com/logrusorgru/aurora"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	api "github.com/textileio/textile/api/client"
 	"github.com/textileio/textile/api/pb"
 	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
-	rootCmd.AddCommand(initCmd, lsCmd, inspectCmd, rmCmd)
+	rootCmd.AddCommand(initCmd, lsCmd, inspectCmd, rmCmd, projectsCmd)
+	projectsCmd.AddCommand(linkProjectsCmd, unlinkProjectsCmd, renameProjectsCmd)
 
 	initCmd.Flags().String(
 		"name",
 		"",
 		"Project name")
 
-	initCmd.Flags().String(
-		"path",
-		".",
-		"Project path")
+	for _, c := range []*cobra.Command{initCmd, linkProjectsCmd, unlinkProjectsCmd} {
+		c.Flags().String(
+			"path",
+			".",
+			"Project path")
+	}
 }
 
 var initCmd = &cobra.Command{
@@ -34,30 +41,16 @@ var initCmd = &cobra.Command{
 	Short: "Init a new project",
 	Long:  `Initialize a new project.`,
 	Run: func(c *cobra.Command, args []string) {
-		var pth, name string
-		var err error
-		if !c.Flag("path").Changed {
-			pth, err = os.Getwd()
-			if err != nil {
-				cmd.Fatal(err)
-			}
-		} else {
-			pth = c.Flag("path").Value.String()
-		}
+		pth, filename := projectConfigFile(c)
+		var name string
 		if !c.Flag("name").Changed {
-			name = path.Base(pth)
+			name = path.Base(path.Dir(pth))
 		} else {
 			name = c.Flag("name").Value.String()
 		}
 
-		pth = path.Join(pth, ".textile")
-		if err := os.MkdirAll(pth, os.ModePerm); err != nil {
-			cmd.Fatal(err)
-		}
-		filename := path.Join(pth, "config.yml")
-
 		if _, err := os.Stat(filename); err == nil {
-			cmd.Fatal(fmt.Errorf("project already exists in %s", pth))
+			cmd.Fatal(status.Errorf(codes.AlreadyExists, "project already exists in %s", pth))
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
@@ -72,15 +65,10 @@ var initCmd = &cobra.Command{
 		if err != nil {
 			cmd.Fatal(err)
 		}
-		configViper.Set("id", proj.ID)
-		if proj.StoreID != "" {
-			configViper.Set("store", proj.StoreID)
-		}
 
-		if err := configViper.WriteConfigAs(filename); err != nil {
-			cmd.Fatal(err)
-		}
+		writeProjectConfig(pth, filename, proj.ID, proj.StoreID)
 
+		cmd.Render(proj, nil, nil)
 		cmd.Success("Initialized empty project in %s", aurora.White(pth).Bold())
 	},
 }
@@ -93,54 +81,58 @@ var lsCmd = &cobra.Command{
 	Short: "List projects",
 	Long:  `List existing projects under the current scope.`,
 	Run: func(c *cobra.Command, args []string) {
-		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
-		defer cancel()
-		projects, err := client.ListProjects(
-			ctx,
-			api.Auth{
-				Token: authViper.GetString("token"),
-				Scope: configViper.GetString("scope"),
-			})
-		if err != nil {
-			cmd.Fatal(err)
-		}
+		lsProjects()
+	},
+}
 
-		if len(projects.List) > 0 {
-			data := make([][]string, len(projects.List))
-			for i, p := range projects.List {
-				data[i] = []string{p.Name, p.ID, p.StoreID}
-			}
-			cmd.RenderTable([]string{"name", "id", "store id"}, data)
-		}
+func lsProjects() {
+	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+	defer cancel()
+	projects, err := client.ListProjects(
+		ctx,
+		api.Auth{
+			Token: authViper.GetString("token"),
+			Scope: configViper.GetString("scope"),
+		})
+	if err != nil {
+		cmd.Fatal(err)
+	}
 
-		cmd.Message("Found %d projects for current scope", aurora.White(len(projects.List)).Bold())
-	},
+	data := make([][]string, len(projects.List))
+	for i, p := range projects.List {
+		data[i] = []string{p.Name, p.ID, p.StoreID}
+	}
+	cmd.Render(projects, []string{"name", "id", "store id"}, data)
+
+	cmd.Message("Found %d projects for current scope", aurora.White(len(projects.List)).Bold())
 }
 
 // @todo: Display something more meaningful when the info is available, e.g., Filecoin and sync info.
 var inspectCmd = &cobra.Command{
-	Use:   "inspect",
+	Use:   "inspect [id]",
 	Short: "Display project information",
-	Long:  `Display detailed information about a project (interactive).`,
+	Long:  `Display detailed information about a project (interactive without an ID or name).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		selected := selectProject("Select project", aurora.Sprintf(
-			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")))
+			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), args)
 
-		cmd.RenderTable([]string{"name", "id", "store id", "filecoin wallet address", "filecoin wallet balance"},
+		cmd.Render(selected, []string{"name", "id", "store id", "filecoin wallet address", "filecoin wallet balance"},
 			[][]string{{selected.Name, selected.ID, selected.StoreID, selected.WalletAddress, strconv.FormatInt(selected.WalletBalance, 10)}})
 	},
 }
 
 var rmCmd = &cobra.Command{
-	Use: "rm",
+	Use: "rm [id]",
 	Aliases: []string{
 		"remove",
 	},
 	Short: "Remove a project",
-	Long:  `Remove a project (interactive).`,
+	Long:  `Remove a project (interactive without an ID or name).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		selected := selectProject("Remove project", aurora.Sprintf(
-			aurora.BrightBlack("> Removing project {{ .Name | white | bold }}")))
+			aurora.BrightBlack("> Removing project {{ .Name | white | bold }}")), args)
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -160,7 +152,166 @@ var rmCmd = &cobra.Command{
 	},
 }
 
-func selectProject(label, successMsg string) *pb.GetProjectReply {
+var projectsCmd = &cobra.Command{
+	Use: "projects",
+	Aliases: []string{
+		"project",
+	},
+	Short: "Project management",
+	Long:  `Manage your projects and the directories linked to them.`,
+	Run: func(c *cobra.Command, args []string) {
+		lsProjects()
+	},
+}
+
+var linkProjectsCmd = &cobra.Command{
+	Use:   "link [id]",
+	Short: "Link a directory to a project",
+	Long: `Link a directory to an existing project, e.g., after cloning a repository
+on a new machine (interactive without an ID or name).`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		pth, filename := projectConfigFile(c)
+		if linked := linkedProject(filename); linked != "" {
+			cmd.Fatal(status.Error(codes.AlreadyExists, "directory %s is already linked to project %s (run `%s` first)"),
+				aurora.White(pth).Bold(), aurora.White(linked).Bold(), aurora.Cyan("textile projects unlink"))
+		}
+
+		selected := selectProject("Link project", aurora.Sprintf(
+			aurora.BrightBlack("> Linking project {{ .Name | white | bold }}")), args)
+
+		writeProjectConfig(pth, filename, selected.ID, selected.StoreID)
+
+		cmd.Render(selected, nil, nil)
+		cmd.Success("Linked project %s to %s", aurora.White(selected.Name).Bold(), aurora.White(pth).Bold())
+	},
+}
+
+var unlinkProjectsCmd = &cobra.Command{
+	Use:   "unlink",
+	Short: "Unlink a directory from its project",
+	Long:  `Unlink a directory from its project. The project itself is not removed.`,
+	Args:  cobra.NoArgs,
+	Run: func(c *cobra.Command, args []string) {
+		pth, filename := projectConfigFile(c)
+		linked := linkedProject(filename)
+		if linked == "" {
+			cmd.Fatal(status.Error(codes.NotFound, "directory %s is not linked to a project"), aurora.White(pth).Bold())
+		}
+		if err := os.Remove(filename); err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Success("Unlinked project %s from %s", aurora.White(linked).Bold(), aurora.White(pth).Bold())
+	},
+}
+
+var renameProjectsCmd = &cobra.Command{
+	Use:   "rename [name]",
+	Short: "Rename a project",
+	Long: `Rename the project given by --id, or the one linked to the current directory
+(interactive without a name, or to pick a project if neither is given).`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		var sel []string
+		if id := configViper.GetString("id"); id != "" {
+			sel = []string{id}
+		}
+		selected := selectProject("Rename project", aurora.Sprintf(
+			aurora.BrightBlack("> Renaming project {{ .Name | white | bold }}")), sel)
+
+		validate := func(name string) error {
+			if name == "" {
+				return errors.New("name required")
+			}
+			return nil
+		}
+		var name string
+		if len(args) > 0 {
+			name = args[0]
+			if err := validate(name); err != nil {
+				cmd.Fatal(status.Error(codes.InvalidArgument, err.Error()))
+			}
+		} else {
+			requireInteractive("a project name")
+			prompt := promptui.Prompt{
+				Label:    "Enter a new project name",
+				Default:  selected.Name,
+				Validate: validate,
+			}
+			var err error
+			name, err = prompt.Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		if err := client.UpdateProject(
+			ctx,
+			selected.ID,
+			name,
+			api.Auth{
+				Token: authViper.GetString("token"),
+				Scope: configViper.GetString("scope"),
+			}); err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Success("Renamed project %s to %s", aurora.White(selected.Name).Bold(), aurora.White(name).Bold())
+	},
+}
+
+// projectConfigFile returns the config directory and file of the project path given by --path.
+func projectConfigFile(c *cobra.Command) (string, string) {
+	var pth string
+	if !c.Flag("path").Changed {
+		var err error
+		pth, err = os.Getwd()
+		if err != nil {
+			cmd.Fatal(err)
+		}
+	} else {
+		pth = c.Flag("path").Value.String()
+	}
+	pth = path.Join(pth, ".textile")
+	return pth, path.Join(pth, "config.yml")
+}
+
+// linkedProject returns the ID of the project in a config file, if any.
+func linkedProject(filename string) string {
+	if _, err := os.Stat(filename); err != nil {
+		return ""
+	}
+	v := viper.New()
+	v.SetConfigFile(filename)
+	if err := v.ReadInConfig(); err != nil {
+		cmd.Fatal(err)
+	}
+	return v.GetString("id")
+}
+
+// writeProjectConfig writes a project config file linking a project and its
+// store. Other settings aren't written, since they would override flags and
+// profiles for everyone working in the directory.
+func writeProjectConfig(pth, filename, projectID, storeID string) {
+	if err := os.MkdirAll(pth, os.ModePerm); err != nil {
+		cmd.Fatal(err)
+	}
+	v := viper.New()
+	v.Set("id", projectID)
+	if storeID != "" {
+		v.Set("store", storeID)
+	}
+	if err := v.WriteConfigAs(filename); err != nil {
+		cmd.Fatal(err)
+	}
+}
+
+// selectProject returns the project named by the first arg, which may be an
+// ID or name, or by the --id flag. It prompts for one if neither is given.
+func selectProject(label, successMsg string, args []string) *pb.GetProjectReply {
 	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 	defer cancel()
 	projects, err := client.ListProjects(
@@ -172,9 +323,24 @@ func selectProject(label, successMsg string) *pb.GetProjectReply {
 	if err != nil {
 		cmd.Fatal(err)
 	}
+	var want string
+	if len(args) > 0 {
+		want = args[0]
+	} else if rootCmd.PersistentFlags().Changed("id") || !cmd.Interactive {
+		want = configViper.GetString("id")
+	}
+	if want != "" {
+		for _, p := range projects.List {
+			if p.ID == want || p.Name == want {
+				return p
+			}
+		}
+		cmd.Fatal(status.Errorf(codes.NotFound, "project %s not found", want))
+	}
 	if len(projects.List) == 0 {
 		cmd.End("You don't have any projects!")
 	}
+	requireInteractive("a project ID or name")
 
 	prompt := promptui.Select{
 		Label: label,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/mail"
 
 	"github.com/logrusorgru/aurora"
@@ -11,6 +12,8 @@ import (
 	"github.com/spf13/cobra"
 	api "github.com/textileio/textile/api/client"
 	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -18,11 +21,37 @@ func init() {
 	teamsCmd.AddCommand(
 		addTeamsCmd,
 		lsTeamsCmd,
+		updateTeamsCmd,
 		membersTeamsCmd,
 		rmTeamsCmd,
 		inviteTeamsCmd,
 		leaveTeamsCmd,
 		switchTeamsCmd)
+
+	updateTeamsCmd.Flags().String(
+		"name",
+		"",
+		"Team name")
+
+	updateTeamsCmd.Flags().String(
+		"description",
+		"",
+		"Team description")
+
+	updateTeamsCmd.Flags().String(
+		"website",
+		"",
+		"Team website URL")
+
+	updateTeamsCmd.Flags().String(
+		"avatar",
+		"",
+		"Path to a PNG, JPEG, GIF, or WebP avatar image of up to 1 MiB")
+
+	updateTeamsCmd.Flags().Bool(
+		"removeAvatar",
+		false,
+		"Remove the team avatar")
 }
 
 var teamsCmd = &cobra.Command{
@@ -38,35 +67,49 @@ var teamsCmd = &cobra.Command{
 }
 
 var addTeamsCmd = &cobra.Command{
-	Use:   "add",
+	Use:   "add [name]",
 	Short: "Add team",
-	Long:  `Add a new team (interactive).`,
+	Long:  `Add a new team (interactive without a name).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
-		prompt := promptui.Prompt{
-			Label: "Enter a team name",
-			Validate: func(name string) error {
-				if len(name) < 3 {
-					return errors.New("name too short")
-				}
-				return nil
-			},
+		validate := func(name string) error {
+			if len(name) < 3 {
+				return errors.New("name too short")
+			}
+			return nil
 		}
-		name, err := prompt.Run()
-		if err != nil {
-			log.Fatal(err)
+		var name string
+		if len(args) > 0 {
+			name = args[0]
+			if err := validate(name); err != nil {
+				cmd.Fatal(status.Error(codes.InvalidArgument, err.Error()))
+			}
+		} else {
+			requireInteractive("a team name")
+			prompt := promptui.Prompt{
+				Label:    "Enter a team name",
+				Validate: validate,
+			}
+			var err error
+			name, err = prompt.Run()
+			if err != nil {
+				log.Fatal(err)
+			}
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
-		if _, err := client.AddTeam(
+		team, err := client.AddTeam(
 			ctx,
 			name,
 			api.Auth{
 				Token: authViper.GetString("token"),
-			}); err != nil {
+			})
+		if err != nil {
 			cmd.Fatal(err)
 		}
 
+		cmd.Render(team, nil, nil)
 		cmd.Success("Added new team %s", aurora.White(name).Bold())
 	},
 }
@@ -95,25 +138,109 @@ func lsTeams() {
 		cmd.Fatal(err)
 	}
 
-	if len(teams.List) > 0 {
-		data := make([][]string, len(teams.List))
-		for i, t := range teams.List {
-			data[i] = []string{t.Name, t.ID}
-		}
-		cmd.RenderTable([]string{"name", "id"}, data)
+	data := make([][]string, len(teams.List))
+	for i, t := range teams.List {
+		data[i] = []string{t.Name, t.ID, t.Description, t.Website, t.Avatar}
 	}
+	cmd.Render(teams, []string{"name", "id", "description", "website", "avatar"}, data)
 
 	cmd.Message("Found %d teams", aurora.White(len(teams.List)).Bold())
 }
 
+var updateTeamsCmd = &cobra.Command{
+	Use:   "update [id]",
+	Short: "Update a team",
+	Long: `Update a team's name, description, website, or avatar image (interactive
+without an ID or name). Fields without a flag are unchanged, or prompted for if
+no flags are given. You must be the team owner.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		selected := selectTeam("Update team", aurora.Sprintf(
+			aurora.BrightBlack("> Updating team {{ .Name | white | bold }}")),
+			false, args)
+
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		team, err := client.GetTeam(
+			ctx,
+			selected.ID,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			})
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		info := api.TeamInfo{
+			Name:        team.Name,
+			Description: team.Description,
+			Website:     team.Website,
+		}
+		var changed bool
+		for _, f := range []string{"name", "description", "website", "avatar", "removeAvatar"} {
+			changed = changed || c.Flags().Changed(f)
+		}
+		if !changed {
+			requireInteractive("flags for the fields to update")
+			for _, f := range []struct {
+				label string
+				val   *string
+			}{
+				{"Name", &info.Name},
+				{"Description", &info.Description},
+				{"Website", &info.Website},
+			} {
+				prompt := promptui.Prompt{
+					Label:   f.label,
+					Default: *f.val,
+				}
+				if *f.val, err = prompt.Run(); err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+		if c.Flags().Changed("name") {
+			info.Name, _ = c.Flags().GetString("name")
+		}
+		if c.Flags().Changed("description") {
+			info.Description, _ = c.Flags().GetString("description")
+		}
+		if c.Flags().Changed("website") {
+			info.Website, _ = c.Flags().GetString("website")
+		}
+		if c.Flags().Changed("avatar") {
+			file, _ := c.Flags().GetString("avatar")
+			if info.Avatar, err = ioutil.ReadFile(file); err != nil {
+				cmd.Fatal(err)
+			}
+		}
+		info.RemoveAvatar, _ = c.Flags().GetBool("removeAvatar")
+
+		res, err := client.UpdateTeam(
+			ctx,
+			selected.ID,
+			info,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			})
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Render(res, nil, nil)
+		cmd.Success("Updated team %s", aurora.White(info.Name).Bold())
+	},
+}
+
 var membersTeamsCmd = &cobra.Command{
-	Use:   "members",
+	Use:   "members [id]",
 	Short: "List team members",
-	Long:  `List current team members (interactive).`,
+	Long:  `List current team members (interactive without an ID or name).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		selected := selectTeam("Select team", aurora.Sprintf(
 			aurora.BrightBlack("> Selected team {{ .Name | white | bold }}")),
-			false)
+			false, args)
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -127,29 +254,28 @@ var membersTeamsCmd = &cobra.Command{
 			cmd.Fatal(err)
 		}
 
-		if len(team.Members) > 0 {
-			data := make([][]string, len(team.Members))
-			for i, m := range team.Members {
-				data[i] = []string{m.Email, m.ID}
-			}
-			cmd.RenderTable([]string{"email", "id"}, data)
+		data := make([][]string, len(team.Members))
+		for i, m := range team.Members {
+			data[i] = []string{m.Email, m.ID}
 		}
+		cmd.Render(team, []string{"email", "id"}, data)
 
 		cmd.Message("Found %d members", aurora.White(len(team.Members)).Bold())
 	},
 }
 
 var rmTeamsCmd = &cobra.Command{
-	Use: "rm",
+	Use: "rm [id]",
 	Aliases: []string{
 		"remove",
 	},
 	Short: "Remove a team",
-	Long:  `Remove a team (interactive). You must be the team owner.`,
+	Long:  `Remove a team (interactive without an ID or name). You must be the team owner.`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		selected := selectTeam("Remove team", aurora.Sprintf(
 			aurora.BrightBlack("> Removing team {{ .Name | white | bold }}")),
-			false)
+			false, args)
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -167,9 +293,10 @@ var rmTeamsCmd = &cobra.Command{
 }
 
 var inviteTeamsCmd = &cobra.Command{
-	Use:   "invite",
+	Use:   "invite [email]",
 	Short: "Invite members",
-	Long:  `Invite a new member to a team.`,
+	Long:  `Invite a new member to a team (interactive without an email).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -185,47 +312,61 @@ var inviteTeamsCmd = &cobra.Command{
 
 		if who.TeamID == "" {
 			msg := "please select a team scope using `%s` or use `%s`"
-			cmd.Fatal(errors.New(msg),
+			cmd.Fatal(status.Error(codes.FailedPrecondition, msg),
 				aurora.Cyan("textile switch"), aurora.Cyan("--scope"))
 		}
 
-		prompt := promptui.Prompt{
-			Label: "Enter email to invite",
-			Validate: func(email string) error {
-				_, err := mail.ParseAddress(email)
-				return err
-			},
+		validate := func(email string) error {
+			_, err := mail.ParseAddress(email)
+			return err
 		}
-		email, err := prompt.Run()
-		if err != nil {
-			log.Fatal(err)
+		var email string
+		if len(args) > 0 {
+			email = args[0]
+			if err := validate(email); err != nil {
+				cmd.Fatal(status.Error(codes.InvalidArgument, err.Error()))
+			}
+		} else {
+			requireInteractive("an email address")
+			prompt := promptui.Prompt{
+				Label:    "Enter email to invite",
+				Validate: validate,
+			}
+			email, err = prompt.Run()
+			if err != nil {
+				log.Fatal(err)
+			}
 		}
 
 		ctx2, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
-		if _, err := client.InviteToTeam(
+		invite, err := client.InviteToTeam(
 			ctx2,
 			who.TeamID,
 			email,
 			api.Auth{
 				Token: authViper.GetString("token"),
-			}); err != nil {
+			})
+		if err != nil {
 			cmd.Fatal(err)
 		}
 
+		cmd.Render(invite, nil, nil)
+
 		cmd.Success("We sent %s an invitation to the %s team", aurora.White(email).Bold(),
 			aurora.White(who.TeamName).Bold())
 	},
 }
 
 var leaveTeamsCmd = &cobra.Command{
-	Use:   "leave",
+	Use:   "leave [id]",
 	Short: "Leave a team",
-	Long:  `Leave a team (interactive).`,
+	Long:  `Leave a team (interactive without an ID or name).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		selected := selectTeam("Leave team", aurora.Sprintf(
 			aurora.BrightBlack("> Leaving team {{ .Name | white | bold }}")),
-			false)
+			false, args)
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -243,13 +384,14 @@ var leaveTeamsCmd = &cobra.Command{
 }
 
 var switchTeamsCmd = &cobra.Command{
-	Use:   "switch",
+	Use:   "switch [id]",
 	Short: "Switch teams",
-	Long:  `Switch to a different team.`,
+	Long:  `Switch to a different team (interactive without an ID or name).`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(c *cobra.Command, args []string) {
 		selected := selectTeam("Switch to team", aurora.Sprintf(
 			aurora.BrightBlack("> Switching to team {{ .Name | white | bold }}")),
-			false)
+			false, args)
 
 		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 		defer cancel()
@@ -272,7 +414,9 @@ type teamItem struct {
 	Extra string
 }
 
-func selectTeam(label, successMsg string, includeAccount bool) *teamItem {
+// selectTeam returns the team named by the first arg, which may be an ID or
+// name, or prompts for one if there are no args.
+func selectTeam(label, successMsg string, includeAccount bool, args []string) *teamItem {
 	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
 	defer cancel()
 	teams, err := client.ListTeams(
@@ -318,9 +462,18 @@ func selectTeam(label, successMsg string, includeAccount bool) *teamItem {
 		items = append([]*teamItem{account}, items...)
 	}
 
+	if len(args) > 0 {
+		for _, t := range items {
+			if t.ID == args[0] || t.Name == args[0] {
+				return t
+			}
+		}
+		cmd.Fatal(status.Errorf(codes.NotFound, "team %s not found", args[0]))
+	}
 	if len(items) == 0 {
 		cmd.End("You don't have any teams!")
 	}
+	requireInteractive("a team ID or name")
 
 	prompt := promptui.Select{
 		Label: label,
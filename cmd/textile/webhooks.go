@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	api "github.com/textileio/textile/api/client"
+	"github.com/textileio/textile/api/pb"
+	"github.com/textileio/textile/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	rootCmd.AddCommand(webhooksCmd)
+	webhooksCmd.AddCommand(
+		addWebhooksCmd,
+		lsWebhooksCmd,
+		rmWebhooksCmd,
+		testWebhooksCmd)
+
+	addWebhooksCmd.Flags().StringSlice(
+		"event",
+		nil,
+		"Event to deliver: app_user.register, team.member_join, or project.remove (repeatable)")
+
+	addWebhooksCmd.Flags().String(
+		"secret",
+		"",
+		"Secret for signing deliveries (generated if not given)")
+}
+
+var webhooksCmd = &cobra.Command{
+	Use: "webhooks",
+	Aliases: []string{
+		"webhook",
+	},
+	Short: "Webhook management",
+	Long: `Manage your project's webhooks. Events are posted as JSON, signed with the
+webhook's secret in the X-Textile-Signature header, and retried with backoff
+until they're accepted with a 2xx status.`,
+	Run: func(c *cobra.Command, args []string) {
+		lsWebhooks()
+	},
+}
+
+var addWebhooksCmd = &cobra.Command{
+	Use:   "add [url]",
+	Short: "Add a webhook",
+	Long:  `Add a webhook that receives the given events (interactive without --id).`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		project := selectProject("Select project", aurora.Sprintf(
+			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), nil)
+
+		events, err := c.Flags().GetStringSlice("event")
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		secret, err := c.Flags().GetString("secret")
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		hook, err := client.AddWebhook(
+			ctx,
+			project.ID,
+			args[0],
+			secret,
+			events,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			})
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Render(hook, []string{"id", "secret"}, [][]string{{hook.ID, hook.Secret}})
+		cmd.Success("Added new webhook %s. Keep the secret, it can't be shown again.", aurora.White(hook.ID).Bold())
+	},
+}
+
+var lsWebhooksCmd = &cobra.Command{
+	Use: "ls",
+	Aliases: []string{
+		"list",
+	},
+	Short: "List webhooks",
+	Long:  `List webhooks for a project (interactive without --id).`,
+	Args:  cobra.NoArgs,
+	Run: func(c *cobra.Command, args []string) {
+		lsWebhooks()
+	},
+}
+
+func lsWebhooks() {
+	project := selectProject("Select project", aurora.Sprintf(
+		aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), nil)
+
+	hooks := listWebhooks(project.ID)
+	data := make([][]string, len(hooks.List))
+	for i, h := range hooks.List {
+		data[i] = []string{h.ID, h.URL, strings.Join(h.Events, ", "),
+			time.Unix(h.Created, 0).Format(time.RFC3339)}
+	}
+	cmd.Render(hooks, []string{"id", "url", "events", "created"}, data)
+
+	cmd.Message("Found %d webhooks", aurora.White(len(hooks.List)).Bold())
+}
+
+func listWebhooks(projID string) *pb.ListWebhooksReply {
+	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+	defer cancel()
+	hooks, err := client.ListWebhooks(
+		ctx,
+		projID,
+		api.Auth{
+			Token: authViper.GetString("token"),
+		})
+	if err != nil {
+		cmd.Fatal(err)
+	}
+	return hooks
+}
+
+var rmWebhooksCmd = &cobra.Command{
+	Use: "rm [id]",
+	Aliases: []string{
+		"remove",
+	},
+	Short: "Remove a webhook",
+	Long: `Remove a webhook (interactive without --id and a webhook ID). Events that
+are already queued are still delivered.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		project := selectProject("Select project", aurora.Sprintf(
+			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), nil)
+
+		selected := selectWebhook("Remove webhook", aurora.Sprintf(
+			aurora.BrightBlack("> Removing webhook {{ .URL | white | bold }}")),
+			project.ID, args)
+
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		if err := client.RemoveWebhook(
+			ctx,
+			selected.ID,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			}); err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Success("Removed webhook %s", aurora.White(selected.ID).Bold())
+	},
+}
+
+var testWebhooksCmd = &cobra.Command{
+	Use:   "test [id]",
+	Short: "Test a webhook",
+	Long:  `Send a ping event to a webhook right away (interactive without --id and a webhook ID).`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		project := selectProject("Select project", aurora.Sprintf(
+			aurora.BrightBlack("> Selected {{ .Name | white | bold }}")), nil)
+
+		selected := selectWebhook("Test webhook", aurora.Sprintf(
+			aurora.BrightBlack("> Testing webhook {{ .URL | white | bold }}")),
+			project.ID, args)
+
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		if err := client.TestWebhook(
+			ctx,
+			selected.ID,
+			api.Auth{
+				Token: authViper.GetString("token"),
+			}); err != nil {
+			cmd.Fatal(err)
+		}
+
+		cmd.Success("Webhook %s received the ping event", aurora.White(selected.ID).Bold())
+	},
+}
+
+// selectWebhook returns the webhook given as the first arg, or prompts for
+// one if there are no args.
+func selectWebhook(label, successMsg, projID string, args []string) *pb.ListWebhooksReply_Webhook {
+	hooks := listWebhooks(projID)
+
+	if len(args) > 0 {
+		for _, h := range hooks.List {
+			if h.ID == args[0] {
+				return h
+			}
+		}
+		cmd.Fatal(status.Errorf(codes.NotFound, "webhook %s not found", args[0]))
+	}
+	if len(hooks.List) == 0 {
+		cmd.End("You don't have any webhooks!")
+	}
+	requireInteractive("a webhook ID")
+
+	prompt := promptui.Select{
+		Label: label,
+		Items: hooks.List,
+		Templates: &promptui.SelectTemplates{
+			Active:   fmt.Sprintf(`{{ "%s" | cyan }} {{ .URL | bold }}`, promptui.IconSelect),
+			Inactive: `{{ .URL | faint }}`,
+			Details:  `{{ "(ID:" | faint }} {{ .ID | faint }}{{ ")" | faint }}`,
+			Selected: successMsg,
+		},
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return hooks.List[index]
+}
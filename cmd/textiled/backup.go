@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/cobra"
+	"github.com/textileio/textile/cmd"
+	"github.com/textileio/textile/collections"
+	"github.com/textileio/textile/core"
+)
+
+func init() {
+	rootCmd.AddCommand(backupCmd, restoreCmd)
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Backup collections",
+	Long: `Export all collections and their store IDs to an archive file.
+The daemon must be stopped.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		filename := args[0]
+		if _, err := os.Stat(filename); err == nil {
+			cmd.Fatal(fmt.Errorf("%s already exists", filename))
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cols, closeRepo, err := core.OpenCollections(ctx, loadConfig())
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		defer func() {
+			if err := closeRepo(); err != nil {
+				cmd.Fatal(err)
+			}
+		}()
+
+		pending, err := cols.PendingMigrations()
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		if len(pending) > 0 {
+			msg := "found %d pending migrations, run `%s` first"
+			cmd.Fatal(errors.New(msg),
+				len(pending), aurora.Cyan("textiled migrate"))
+		}
+
+		file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		defer file.Close()
+		manifest, err := cols.Backup(ctx, file)
+		if err != nil {
+			_ = os.Remove(filename)
+			cmd.Fatal(err)
+		}
+
+		renderManifest(manifest)
+		cmd.Success("Wrote backup to %s", aurora.White(filename).Bold())
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Short: "Restore collections",
+	Long: `Import all collections from a backup archive file into a fresh repo.
+The daemon must be stopped.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(c *cobra.Command, args []string) {
+		file, err := os.Open(args[0])
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		defer file.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cols, closeRepo, err := core.OpenCollections(ctx, loadConfig())
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		defer func() {
+			if err := closeRepo(); err != nil {
+				cmd.Fatal(err)
+			}
+		}()
+
+		manifest, err := cols.Restore(ctx, file)
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		renderManifest(manifest)
+		cmd.Success("Restored backup from %s", aurora.White(args[0]).Bold())
+	},
+}
+
+func renderManifest(manifest *collections.Manifest) {
+	names := make([]string, 0, len(manifest.Counts))
+	for name := range manifest.Counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data := make([][]string, len(names))
+	for i, name := range names {
+		data[i] = []string{name, strconv.Itoa(manifest.Counts[name])}
+	}
+	cmd.RenderTable([]string{"collection", "instances"}, data)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	logging "github.com/ipfs/go-log"
@@ -11,8 +12,13 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/textileio/go-threads/util"
+	"github.com/textileio/textile/certs"
 	"github.com/textileio/textile/cmd"
 	"github.com/textileio/textile/core"
+	"github.com/textileio/textile/gateway"
+	"github.com/textileio/textile/ratelimit"
+	"github.com/textileio/textile/tracing"
+	"github.com/textileio/textile/webhooks"
 )
 
 var (
@@ -38,6 +44,14 @@ var (
 			Key:      "addr.api",
 			DefValue: "/ip4/127.0.0.1/tcp/3006",
 		},
+		"addrApiProxy": {
+			Key:      "addr.api_proxy",
+			DefValue: "/ip4/127.0.0.1/tcp/3007",
+		},
+		"addrApiProxyUrl": {
+			Key:      "addr.api_proxy_url",
+			DefValue: "http://127.0.0.1:3007",
+		},
 		"addrThreadsHost": {
 			Key:      "addr.threads.host",
 			DefValue: "/ip4/0.0.0.0/tcp/4006",
@@ -70,6 +84,78 @@ var (
 			Key:      "addr.ipfs.api",
 			DefValue: "/ip4/127.0.0.1/tcp/5001",
 		},
+		"addrMetrics": {
+			Key:      "addr.metrics",
+			DefValue: "/ip4/127.0.0.1/tcp/9006",
+		},
+		"gatewayCorsOrigins": {
+			Key:      "gateway.cors.allowed_origins",
+			DefValue: []string{},
+		},
+		"gatewayCorsMethods": {
+			Key:      "gateway.cors.allowed_methods",
+			DefValue: []string{},
+		},
+		"gatewayCorsHeaders": {
+			Key:      "gateway.cors.allowed_headers",
+			DefValue: []string{},
+		},
+		"gatewayHstsMaxAge": {
+			Key:      "gateway.headers.hsts_max_age",
+			DefValue: time.Duration(0),
+		},
+		"gatewayCsp": {
+			Key:      "gateway.headers.csp",
+			DefValue: "",
+		},
+		"gatewayFrameOptions": {
+			Key:      "gateway.headers.frame_options",
+			DefValue: "SAMEORIGIN",
+		},
+		"tlsCertFile": {
+			Key:      "tls.cert_file",
+			DefValue: "",
+		},
+		"tlsKeyFile": {
+			Key:      "tls.key_file",
+			DefValue: "",
+		},
+		"tlsAcmeDomains": {
+			Key:      "tls.acme.domains",
+			DefValue: []string{},
+		},
+		"tlsAcmeEmail": {
+			Key:      "tls.acme.email",
+			DefValue: "",
+		},
+		"tlsAcmeDirectory": {
+			Key:      "tls.acme.directory_url",
+			DefValue: "",
+		},
+		"rateLimitIpEvery": {
+			Key:      "ratelimit.ip.every",
+			DefValue: time.Second,
+		},
+		"rateLimitIpBurst": {
+			Key:      "ratelimit.ip.burst",
+			DefValue: 30,
+		},
+		"rateLimitEmailEvery": {
+			Key:      "ratelimit.email.every",
+			DefValue: time.Minute,
+		},
+		"rateLimitEmailBurst": {
+			Key:      "ratelimit.email.burst",
+			DefValue: 3,
+		},
+		"rateLimitTokenEvery": {
+			Key:      "ratelimit.token.every",
+			DefValue: 100 * time.Millisecond,
+		},
+		"rateLimitTokenBurst": {
+			Key:      "ratelimit.token.burst",
+			DefValue: 100,
+		},
 		"addrFilecoinApi": {
 			Key:      "addr.filecoin.api",
 			DefValue: "/ip4/127.0.0.1/tcp/5002",
@@ -98,11 +184,31 @@ var (
 			Key:      "email.api_key",
 			DefValue: "",
 		},
+		"collectionsBackend": {
+			Key:      "collections.backend",
+			DefValue: core.CollectionsThreads,
+		},
+		"collectionsDSN": {
+			Key:      "collections.dsn",
+			DefValue: "",
+		},
+		"webhooksAllowPrivateAddrs": {
+			Key:      "webhooks.allow_private_addrs",
+			DefValue: false,
+		},
+		"traceOtlpEndpoint": {
+			Key:      "trace.otlp_endpoint",
+			DefValue: "",
+		},
+		"traceSampleRate": {
+			Key:      "trace.sample_rate",
+			DefValue: 1.0,
+		},
 	}
 )
 
 func init() {
-	cobra.OnInitialize(cmd.InitConfig(configViper, configFile, ".textiled", "config"))
+	cobra.OnInitialize(cmd.InitConfig(configViper, configFile, ".textiled", "config", nil))
 
 	rootCmd.PersistentFlags().StringVar(
 		&configFile,
@@ -131,6 +237,14 @@ func init() {
 		"addrApi",
 		flags["addrApi"].DefValue.(string),
 		"Textile API listen address")
+	rootCmd.PersistentFlags().String(
+		"addrApiProxy",
+		flags["addrApiProxy"].DefValue.(string),
+		"Textile API gRPC-Web and REST proxy address")
+	rootCmd.PersistentFlags().String(
+		"addrApiProxyUrl",
+		flags["addrApiProxyUrl"].DefValue.(string),
+		"Textile API proxy public URL, used by the gateway dashboard")
 
 	rootCmd.PersistentFlags().String(
 		"addrThreadsHost",
@@ -158,6 +272,11 @@ func init() {
 		flags["addrIpfsApi"].DefValue.(string),
 		"IPFS API address")
 
+	rootCmd.PersistentFlags().String(
+		"addrMetrics",
+		flags["addrMetrics"].DefValue.(string),
+		"Prometheus metrics listen address, served at /metrics (disabled if empty)")
+
 	// Gateway settings
 	rootCmd.PersistentFlags().String(
 		"addrGatewayHost",
@@ -167,6 +286,78 @@ func init() {
 		"addrGatewayUrl",
 		flags["addrGatewayUrl"].DefValue.(string),
 		"Public gateway address")
+	rootCmd.PersistentFlags().StringSlice(
+		"gatewayCorsOrigins",
+		flags["gatewayCorsOrigins"].DefValue.([]string),
+		"Origins allowed to make cross-origin gateway requests (default all)")
+	rootCmd.PersistentFlags().StringSlice(
+		"gatewayCorsMethods",
+		flags["gatewayCorsMethods"].DefValue.([]string),
+		"Methods allowed in cross-origin gateway requests (default GET, POST, HEAD)")
+	rootCmd.PersistentFlags().StringSlice(
+		"gatewayCorsHeaders",
+		flags["gatewayCorsHeaders"].DefValue.([]string),
+		"Headers allowed in cross-origin gateway requests (default Origin, Accept, Content-Type, X-Requested-With)")
+	rootCmd.PersistentFlags().Duration(
+		"gatewayHstsMaxAge",
+		flags["gatewayHstsMaxAge"].DefValue.(time.Duration),
+		"Gateway Strict-Transport-Security max-age (disabled if zero)")
+	rootCmd.PersistentFlags().String(
+		"gatewayCsp",
+		flags["gatewayCsp"].DefValue.(string),
+		"Gateway Content-Security-Policy header")
+	rootCmd.PersistentFlags().String(
+		"gatewayFrameOptions",
+		flags["gatewayFrameOptions"].DefValue.(string),
+		"Gateway X-Frame-Options header")
+
+	// TLS settings
+	rootCmd.PersistentFlags().String(
+		"tlsCertFile",
+		flags["tlsCertFile"].DefValue.(string),
+		"PEM encoded TLS certificate for the API and gateway")
+	rootCmd.PersistentFlags().String(
+		"tlsKeyFile",
+		flags["tlsKeyFile"].DefValue.(string),
+		"PEM encoded TLS key for the API and gateway")
+	rootCmd.PersistentFlags().StringSlice(
+		"tlsAcmeDomains",
+		flags["tlsAcmeDomains"].DefValue.([]string),
+		"Domains of an ACME certificate for the API and gateway, obtained with DNS-01 challenges in dnsDomain")
+	rootCmd.PersistentFlags().String(
+		"tlsAcmeEmail",
+		flags["tlsAcmeEmail"].DefValue.(string),
+		"Contact email for the ACME account")
+	rootCmd.PersistentFlags().String(
+		"tlsAcmeDirectory",
+		flags["tlsAcmeDirectory"].DefValue.(string),
+		"ACME directory URL (default Let's Encrypt)")
+
+	// Rate limit settings
+	rootCmd.PersistentFlags().Duration(
+		"rateLimitIpEvery",
+		flags["rateLimitIpEvery"].DefValue.(time.Duration),
+		"Interval at which unauthenticated requests are allowed per client IP (disabled if zero)")
+	rootCmd.PersistentFlags().Int(
+		"rateLimitIpBurst",
+		flags["rateLimitIpBurst"].DefValue.(int),
+		"Burst of unauthenticated requests allowed per client IP")
+	rootCmd.PersistentFlags().Duration(
+		"rateLimitEmailEvery",
+		flags["rateLimitEmailEvery"].DefValue.(time.Duration),
+		"Interval at which logins are allowed per email address (disabled if zero)")
+	rootCmd.PersistentFlags().Int(
+		"rateLimitEmailBurst",
+		flags["rateLimitEmailBurst"].DefValue.(int),
+		"Burst of logins allowed per email address")
+	rootCmd.PersistentFlags().Duration(
+		"rateLimitTokenEvery",
+		flags["rateLimitTokenEvery"].DefValue.(time.Duration),
+		"Interval at which app user registrations are allowed per app token (disabled if zero)")
+	rootCmd.PersistentFlags().Int(
+		"rateLimitTokenBurst",
+		flags["rateLimitTokenBurst"].DefValue.(int),
+		"Burst of app user registrations allowed per app token")
 
 	// Filecoin settings
 	rootCmd.PersistentFlags().String(
@@ -206,6 +397,34 @@ func init() {
 		flags["emailApiKey"].DefValue.(string),
 		"Mailgun API key for sending emails")
 
+	// Collections storage settings
+	rootCmd.PersistentFlags().String(
+		"collectionsBackend",
+		flags["collectionsBackend"].DefValue.(string),
+		"Collections storage backend: threads, sqlite3, or postgres")
+
+	rootCmd.PersistentFlags().String(
+		"collectionsDSN",
+		flags["collectionsDSN"].DefValue.(string),
+		"Data source name for SQL collections backends (default ${repo}/collections.db for sqlite3)")
+
+	// Webhook settings
+	rootCmd.PersistentFlags().Bool(
+		"webhooksAllowPrivateAddrs",
+		flags["webhooksAllowPrivateAddrs"].DefValue.(bool),
+		"Allow webhooks on loopback, private, and link-local addresses (unsafe unless all users are trusted)")
+
+	// Tracing settings
+	rootCmd.PersistentFlags().String(
+		"traceOtlpEndpoint",
+		flags["traceOtlpEndpoint"].DefValue.(string),
+		"OTLP/HTTP collector URL to export request traces to, e.g., http://127.0.0.1:4318 (disabled if empty)")
+
+	rootCmd.PersistentFlags().Float64(
+		"traceSampleRate",
+		flags["traceSampleRate"].DefValue.(float64),
+		"Fraction of request traces to export (traces sampled by the caller are always exported)")
+
 	if err := cmd.BindFlags(configViper, rootCmd, flags); err != nil {
 		log.Fatal(err)
 	}
@@ -240,30 +459,6 @@ var rootCmd = &cobra.Command{
 		}
 		log.Debugf("loaded config: %s", string(settings))
 
-		addrApi := cmd.AddrFromStr(configViper.GetString("addr.api"))
-		addrThreadsHost := cmd.AddrFromStr(configViper.GetString("addr.threads.host"))
-		addrThreadsServiceApi := cmd.AddrFromStr(configViper.GetString("addr.threads_service.api"))
-		addrThreadsServiceApiProxy := cmd.AddrFromStr(configViper.GetString("addr.threads_service.api_proxy"))
-		addrThreadsApi := cmd.AddrFromStr(configViper.GetString("addr.threads.api"))
-		addrThreadsApiProxy := cmd.AddrFromStr(configViper.GetString("addr.threads.api_proxy"))
-		addrIpfsApi := cmd.AddrFromStr(configViper.GetString("addr.ipfs.api"))
-
-		addrGatewayHost := cmd.AddrFromStr(configViper.GetString("addr.gateway.host"))
-		addrGatewayUrl := configViper.GetString("addr.gateway.url")
-
-		var addrFilecoinApi ma.Multiaddr
-		if str := configViper.GetString("addr.filecoin.api"); str != "" {
-			addrFilecoinApi = cmd.AddrFromStr(str)
-		}
-
-		dnsDomain := configViper.GetString("dns.domain")
-		dnsZoneID := configViper.GetString("dns.zone_id")
-		dnsToken := configViper.GetString("dns.token")
-
-		emailFrom := configViper.GetString("email.from")
-		emailDomain := configViper.GetString("email.domain")
-		emailApiKey := configViper.GetString("email.api_key")
-
 		logFile := configViper.GetString("log.file")
 		if logFile != "" {
 			util.SetupDefaultLoggingConfig(logFile)
@@ -272,27 +467,7 @@ var rootCmd = &cobra.Command{
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		textile, err := core.NewTextile(ctx, core.Config{
-			RepoPath:                   configViper.GetString("repo"),
-			AddrApi:                    addrApi,
-			AddrThreadsHost:            addrThreadsHost,
-			AddrThreadsServiceApi:      addrThreadsServiceApi,
-			AddrThreadsServiceApiProxy: addrThreadsServiceApiProxy,
-			AddrThreadsApi:             addrThreadsApi,
-			AddrThreadsApiProxy:        addrThreadsApiProxy,
-			AddrIpfsApi:                addrIpfsApi,
-			AddrGatewayHost:            addrGatewayHost,
-			AddrGatewayUrl:             addrGatewayUrl,
-			AddrFilecoinApi:            addrFilecoinApi,
-			DNSDomain:                  dnsDomain,
-			DNSZoneID:                  dnsZoneID,
-			DNSToken:                   dnsToken,
-			EmailFrom:                  emailFrom,
-			EmailDomain:                emailDomain,
-			EmailApiKey:                emailApiKey,
-			ThreadsInternalToken:       uuid.New().String(),
-			Debug:                      configViper.GetBool("log.debug"),
-		})
+		textile, err := core.NewTextile(ctx, loadConfig())
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -305,3 +480,115 @@ var rootCmd = &cobra.Command{
 		select {}
 	},
 }
+
+// loadConfig builds the core config from flags, env and the config file.
+func loadConfig() core.Config {
+	addrApi := cmd.AddrFromStr(configViper.GetString("addr.api"))
+	addrApiProxy := cmd.AddrFromStr(configViper.GetString("addr.api_proxy"))
+	addrApiProxyUrl := configViper.GetString("addr.api_proxy_url")
+	addrThreadsHost := cmd.AddrFromStr(configViper.GetString("addr.threads.host"))
+	addrThreadsServiceApi := cmd.AddrFromStr(configViper.GetString("addr.threads_service.api"))
+	addrThreadsServiceApiProxy := cmd.AddrFromStr(configViper.GetString("addr.threads_service.api_proxy"))
+	addrThreadsApi := cmd.AddrFromStr(configViper.GetString("addr.threads.api"))
+	addrThreadsApiProxy := cmd.AddrFromStr(configViper.GetString("addr.threads.api_proxy"))
+	addrIpfsApi := cmd.AddrFromStr(configViper.GetString("addr.ipfs.api"))
+
+	addrGatewayHost := cmd.AddrFromStr(configViper.GetString("addr.gateway.host"))
+	addrGatewayUrl := configViper.GetString("addr.gateway.url")
+	gatewayCORS := gateway.CORSConfig{
+		AllowedOrigins: configViper.GetStringSlice("gateway.cors.allowed_origins"),
+		AllowedMethods: configViper.GetStringSlice("gateway.cors.allowed_methods"),
+		AllowedHeaders: configViper.GetStringSlice("gateway.cors.allowed_headers"),
+	}
+	gatewayHeaders := gateway.HeadersConfig{
+		HSTSMaxAge:            configViper.GetDuration("gateway.headers.hsts_max_age"),
+		ContentSecurityPolicy: configViper.GetString("gateway.headers.csp"),
+		FrameOptions:          configViper.GetString("gateway.headers.frame_options"),
+	}
+
+	var addrFilecoinApi ma.Multiaddr
+	if str := configViper.GetString("addr.filecoin.api"); str != "" {
+		addrFilecoinApi = cmd.AddrFromStr(str)
+	}
+	var addrMetrics ma.Multiaddr
+	if str := configViper.GetString("addr.metrics"); str != "" {
+		addrMetrics = cmd.AddrFromStr(str)
+	}
+
+	tlsConf := certs.Config{
+		CertFile:         configViper.GetString("tls.cert_file"),
+		KeyFile:          configViper.GetString("tls.key_file"),
+		ACMEDomains:      configViper.GetStringSlice("tls.acme.domains"),
+		ACMEEmail:        configViper.GetString("tls.acme.email"),
+		ACMEDirectoryURL: configViper.GetString("tls.acme.directory_url"),
+	}
+
+	rateLimits := ratelimit.Config{
+		IP: ratelimit.Limit{
+			Every: configViper.GetDuration("ratelimit.ip.every"),
+			Burst: configViper.GetInt("ratelimit.ip.burst"),
+		},
+		Email: ratelimit.Limit{
+			Every: configViper.GetDuration("ratelimit.email.every"),
+			Burst: configViper.GetInt("ratelimit.email.burst"),
+		},
+		Token: ratelimit.Limit{
+			Every: configViper.GetDuration("ratelimit.token.every"),
+			Burst: configViper.GetInt("ratelimit.token.burst"),
+		},
+	}
+
+	dnsDomain := configViper.GetString("dns.domain")
+	dnsZoneID := configViper.GetString("dns.zone_id")
+	dnsToken := configViper.GetString("dns.token")
+
+	emailFrom := configViper.GetString("email.from")
+	emailDomain := configViper.GetString("email.domain")
+	emailApiKey := configViper.GetString("email.api_key")
+
+	collectionsBackend := configViper.GetString("collections.backend")
+	collectionsDSN := configViper.GetString("collections.dsn")
+
+	webhooksConf := webhooks.Config{
+		AllowPrivateAddrs: configViper.GetBool("webhooks.allow_private_addrs"),
+	}
+
+	tracingConf := tracing.Config{
+		Service:      "textiled",
+		OTLPEndpoint: configViper.GetString("trace.otlp_endpoint"),
+		SampleRate:   configViper.GetFloat64("trace.sample_rate"),
+	}
+
+	return core.Config{
+		RepoPath:                   configViper.GetString("repo"),
+		AddrApi:                    addrApi,
+		AddrApiProxy:               addrApiProxy,
+		AddrApiProxyUrl:            addrApiProxyUrl,
+		AddrThreadsHost:            addrThreadsHost,
+		AddrThreadsServiceApi:      addrThreadsServiceApi,
+		AddrThreadsServiceApiProxy: addrThreadsServiceApiProxy,
+		AddrThreadsApi:             addrThreadsApi,
+		AddrThreadsApiProxy:        addrThreadsApiProxy,
+		AddrIpfsApi:                addrIpfsApi,
+		AddrGatewayHost:            addrGatewayHost,
+		AddrGatewayUrl:             addrGatewayUrl,
+		AddrFilecoinApi:            addrFilecoinApi,
+		AddrMetrics:                addrMetrics,
+		GatewayCORS:                gatewayCORS,
+		GatewayHeaders:             gatewayHeaders,
+		RateLimits:                 rateLimits,
+		TLS:                        tlsConf,
+		DNSDomain:                  dnsDomain,
+		DNSZoneID:                  dnsZoneID,
+		DNSToken:                   dnsToken,
+		EmailFrom:                  emailFrom,
+		EmailDomain:                emailDomain,
+		EmailApiKey:                emailApiKey,
+		ThreadsInternalToken:       uuid.New().String(),
+		CollectionsBackend:         collectionsBackend,
+		CollectionsDSN:             collectionsDSN,
+		Webhooks:                   webhooksConf,
+		Tracing:                    tracingConf,
+		Debug:                      configViper.GetBool("log.debug"),
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/cobra"
+	"github.com/textileio/textile/cmd"
+	"github.com/textileio/textile/collections"
+	"github.com/textileio/textile/core"
+)
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().Bool(
+		"dryRun",
+		false,
+		"Only list pending migrations without running them")
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate collections",
+	Long: `Run pending collection schema migrations.
+Migrations also run automatically when the daemon starts. The daemon must be stopped.`,
+	Run: func(c *cobra.Command, args []string) {
+		dryRun, err := c.Flags().GetBool("dryRun")
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cols, closeRepo, err := core.OpenCollections(ctx, loadConfig())
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		defer func() {
+			if err := closeRepo(); err != nil {
+				cmd.Fatal(err)
+			}
+		}()
+
+		version, err := cols.SchemaVersion()
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		var migrations []collections.Migration
+		if dryRun {
+			migrations, err = cols.PendingMigrations()
+		} else {
+			migrations, err = cols.Migrate(ctx)
+		}
+		renderMigrations(migrations)
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		if dryRun {
+			cmd.Message("Found %d pending migrations from schema version %d",
+				aurora.White(len(migrations)).Bold(), aurora.White(version).Bold())
+			return
+		}
+		cmd.Success("Applied %d migrations from schema version %d",
+			aurora.White(len(migrations)).Bold(), aurora.White(version).Bold())
+	},
+}
+
+func renderMigrations(migrations []collections.Migration) {
+	if len(migrations) == 0 {
+		return
+	}
+	data := make([][]string, len(migrations))
+	for i, m := range migrations {
+		data[i] = []string{strconv.Itoa(m.Version), m.Description}
+	}
+	cmd.RenderTable([]string{"version", "description"}, data)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/cobra"
+	"github.com/textileio/textile/cmd"
+	"github.com/textileio/textile/core"
+)
+
+func init() {
+	rootCmd.AddCommand(usersCmd)
+	usersCmd.AddCommand(mergeUsersCmd)
+
+	mergeUsersCmd.Flags().Bool(
+		"dryRun",
+		false,
+		"Only report duplicates without merging them")
+}
+
+var usersCmd = &cobra.Command{
+	Use: "users",
+	Aliases: []string{
+		"user",
+	},
+	Short: "User utils",
+	Long:  `User maintenance utilities.`,
+}
+
+var mergeUsersCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge duplicate users",
+	Long: `Merge users that share an email address into the oldest user.
+Team memberships, sessions, and projects of the duplicates are moved over.
+The daemon must be stopped.`,
+	Run: func(c *cobra.Command, args []string) {
+		dryRun, err := c.Flags().GetBool("dryRun")
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		collections, closeRepo, err := core.OpenCollections(ctx, loadConfig())
+		if err != nil {
+			cmd.Fatal(err)
+		}
+		defer func() {
+			if err := closeRepo(); err != nil {
+				cmd.Fatal(err)
+			}
+		}()
+
+		merges, err := collections.MergeUsers(ctx, dryRun)
+		if err != nil {
+			cmd.Fatal(err)
+		}
+
+		if len(merges) > 0 {
+			data := make([][]string, len(merges))
+			for i, m := range merges {
+				data[i] = []string{m.Email, m.UserID, strings.Join(m.Merged, ", ")}
+			}
+			cmd.RenderTable([]string{"email", "kept id", "merged ids"}, data)
+		}
+
+		if dryRun {
+			cmd.Message("Found %d users to merge or normalize", aurora.White(len(merges)).Bold())
+			return
+		}
+		cmd.Success("Merged or normalized %d users", aurora.White(len(merges)).Bold())
+	},
+}
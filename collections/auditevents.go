@@ -0,0 +1,48 @@
+package collections
+
+import (
+	"context"
+)
+
+// Audited actions.
+const (
+	ActionLogin           = "login"
+	ActionLogout          = "logout"
+	ActionSwitch          = "switch"
+	ActionProfileUpdate   = "profile.update"
+	ActionTeamAdd         = "team.add"
+	ActionTeamUpdate      = "team.update"
+	ActionTeamRemove      = "team.remove"
+	ActionTeamInvite      = "team.invite"
+	ActionTeamLeave       = "team.leave"
+	ActionInviteAccept    = "invite.accept"
+	ActionProjectAdd      = "project.add"
+	ActionProjectUpdate   = "project.update"
+	ActionProjectRemove   = "project.remove"
+	ActionAppTokenAdd     = "app_token.add"
+	ActionAppTokenRemove  = "app_token.remove"
+	ActionAppUserRegister = "app_user.register"
+	ActionAppUserRemove   = "app_user.remove"
+	ActionWebhookAdd      = "webhook.add"
+	ActionWebhookRemove   = "webhook.remove"
+)
+
+// AuditEvent records an action taken by a user or app user in a scope.
+type AuditEvent struct {
+	ID       string
+	ActorID  string
+	Scope    string
+	Action   string
+	TargetID string
+	IP       string
+	Created  int64
+}
+
+// AuditEvents is append-only. Events can't be changed or deleted.
+type AuditEvents interface {
+	// Create sets the ID and creation time of e and saves it.
+	Create(ctx context.Context, e *AuditEvent) error
+	// List returns the events of scope created in [since, until), oldest first.
+	// A zero bound leaves that end of the range open.
+	List(ctx context.Context, scope string, since, until int64) ([]*AuditEvent, error)
+}
@@ -0,0 +1,118 @@
+package collections
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupVersion is the archive format version written by WriteArchive.
+const BackupVersion = 1
+
+const manifestName = "manifest.json"
+
+// Manifest describes the contents of a backup archive.
+type Manifest struct {
+	Version       int               `json:"version"`
+	Backend       string            `json:"backend,omitempty"`
+	SchemaVersion int               `json:"schema_version"`
+	Created       int64             `json:"created"`
+	Stores        map[string]string `json:"stores,omitempty"` // datastore key -> store ID
+	Counts        map[string]int    `json:"counts"`           // model name -> instance count
+}
+
+// WriteArchive writes the manifest and the JSON encoded instances of each model
+// to w as a gzipped tar archive.
+func WriteArchive(w io.Writer, manifest *Manifest, models map[string][]byte) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeTarFile(tw, modelFileName(name), models[name]); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writeTarFile(tw, manifestName, data); err != nil {
+		return err
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// ReadArchive reads an archive written by WriteArchive. It returns the manifest
+// and the JSON encoded instances of each model.
+func ReadArchive(r io.Reader) (*Manifest, map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	var manifest *Manifest
+	models := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hdr.Name == manifestName {
+			manifest = &Manifest{}
+			if err = json.Unmarshal(data, manifest); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		dir, file := path.Split(hdr.Name)
+		if dir == "collections/" && path.Ext(file) == ".json" {
+			models[strings.TrimSuffix(file, ".json")] = data
+		}
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("backup is missing %s", manifestName)
+	}
+	if manifest.Version != BackupVersion {
+		return nil, nil, fmt.Errorf("unsupported backup version %d", manifest.Version)
+	}
+	return manifest, models, nil
+}
+
+func modelFileName(name string) string {
+	return path.Join("collections", name+".json")
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
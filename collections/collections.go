@@ -2,151 +2,71 @@ package collections
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"io"
 
-	"github.com/alecthomas/jsonschema"
-	"github.com/google/uuid"
-	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log"
-	"github.com/textileio/go-threads/api/client"
 )
 
 var (
 	log = logging.Logger("collections")
 
-	dsUsersKey    = datastore.NewKey("/users")
-	dsSessionsKey = datastore.NewKey("/sessions")
-	dsTeamsKey    = datastore.NewKey("/teams")
-	dsInvitesKey  = datastore.NewKey("/invites")
-	dsProjectsKey = datastore.NewKey("/projects")
-
-	dsAppTokensKey = datastore.NewKey("/apptokens")
-	dsAppUsersKey  = datastore.NewKey("/appusers")
+	// ErrNotFound indicates the requested instance does not exist.
+	// Every backend returns it from the collections' Get methods, so callers
+	// can check for it with errors.Is regardless of the backend.
+	ErrNotFound = errors.New("instance not found")
 )
 
-type Collection interface {
-	GetName() string
-	GetInstance() interface{}
-	GetStoreID() *uuid.UUID
+// Backend maintains the storage behind a set of collections.
+type Backend interface {
+	// SchemaVersion returns the current schema version.
+	SchemaVersion() (int, error)
+	// PendingMigrations returns the migrations that have not been applied yet.
+	PendingMigrations() ([]Migration, error)
+	// Migrate applies pending migrations in order and returns the ones that were applied.
+	Migrate(ctx context.Context) ([]Migration, error)
+	// Backup writes every collection to w as an archive.
+	Backup(ctx context.Context, w io.Writer) (*Manifest, error)
+	// Restore imports an archive written by Backup into empty collections.
+	Restore(ctx context.Context, r io.Reader) (*Manifest, error)
+	// Stats returns instance counts for monitoring.
+	Stats(ctx context.Context) (*Stats, error)
 }
 
-type Collections struct {
-	threads *client.Client
-	token   string
-	ds      datastore.Datastore
-
-	Users    *Users
-	Sessions *Sessions
-	Teams    *Teams
-	Invites  *Invites
-	Projects *Projects
-
-	AppTokens *AppTokens
-	AppUsers  *AppUsers
+// Stats holds instance counts for monitoring.
+type Stats struct {
+	Users          int
+	Projects       int
+	AppUsers       int
+	ActiveSessions int // sessions that haven't expired
 }
 
-// NewCollections gets or create store instances for active collections.
-func NewCollections(ctx context.Context, threads *client.Client, token string, ds datastore.Datastore) (c *Collections, err error) {
-	c = &Collections{
-		threads: threads,
-		token:   token,
-		ds:      ds,
-
-		Users:    &Users{threads: threads, token: token},
-		Sessions: &Sessions{threads: threads, token: token},
-		Teams:    &Teams{threads: threads, token: token},
-		Invites:  &Invites{threads: threads, token: token},
-		Projects: &Projects{threads: threads, token: token},
-
-		AppTokens: &AppTokens{threads: threads, token: token},
-		AppUsers:  &AppUsers{threads: threads, token: token},
-	}
-	ctx = AuthCtx(ctx, c.token)
+// StoreCreator creates and starts threads stores.
+// Projects and app users get a dedicated store regardless of the collections backend.
+type StoreCreator interface {
+	NewStore(ctx context.Context) (string, error)
+	Start(ctx context.Context, storeID string) error
+}
 
-	c.Users.storeID, err = c.addCollection(ctx, c.Users, dsUsersKey)
-	if err != nil {
-		return nil, err
-	}
-	c.Sessions.storeID, err = c.addCollection(ctx, c.Sessions, dsSessionsKey)
-	if err != nil {
-		return nil, err
-	}
-	c.Teams.storeID, err = c.addCollection(ctx, c.Teams, dsTeamsKey)
-	if err != nil {
-		return nil, err
-	}
-	c.Invites.storeID, err = c.addCollection(ctx, c.Invites, dsInvitesKey)
-	if err != nil {
-		return nil, err
-	}
-	c.Projects.storeID, err = c.addCollection(ctx, c.Projects, dsProjectsKey)
-	if err != nil {
-		return nil, err
-	}
-	c.AppTokens.storeID, err = c.addCollection(ctx, c.AppTokens, dsAppTokensKey)
-	if err != nil {
-		return nil, err
-	}
-	c.AppUsers.storeID, err = c.addCollection(ctx, c.AppUsers, dsAppUsersKey)
-	if err != nil {
-		return nil, err
-	}
+// Collections holds the control-plane collections.
+// Get methods return ErrNotFound if the instance does not exist, never a nil
+// instance without an error.
+type Collections struct {
+	Backend
 
-	log.Debugf("users store: %s", c.Users.GetStoreID().String())
-	log.Debugf("sessions store: %s", c.Sessions.GetStoreID().String())
-	log.Debugf("teams store: %s", c.Teams.GetStoreID().String())
-	log.Debugf("invites store: %s", c.Invites.GetStoreID().String())
-	log.Debugf("projects store: %s", c.Projects.GetStoreID().String())
-	log.Debugf("app tokens store: %s", c.Projects.GetStoreID().String())
-	log.Debugf("app users store: %s", c.Invites.GetStoreID().String())
+	Users    Users
+	Sessions Sessions
+	Teams    Teams
+	Invites  Invites
+	Projects Projects
 
-	return c, nil
-}
+	AppTokens AppTokens
+	AppUsers  AppUsers
 
-func (c *Collections) addCollection(ctx context.Context, col Collection, key datastore.Key) (*uuid.UUID, error) {
-	storeID, err := storeIDAtKey(c.ds, key)
-	if err != nil {
-		return nil, err
-	}
-	if storeID == nil {
-		ids, err := c.threads.NewStore(ctx)
-		if err != nil {
-			return nil, err
-		}
-		id := uuid.MustParse(ids)
-		storeID = &id
-
-		schema, err := json.Marshal(jsonschema.Reflect(col.GetInstance()))
-		if err != nil {
-			panic(err)
-		}
-		if err = c.threads.RegisterSchema(ctx, storeID.String(), col.GetName(), string(schema)); err != nil {
-			return nil, err
-		}
-		if err = c.ds.Put(key, storeID[:]); err != nil {
-			return nil, err
-		}
-		if err = c.threads.Start(ctx, storeID.String()); err != nil {
-			return nil, err
-		}
-	}
-	return storeID, nil
-}
+	AuditEvents AuditEvents
 
-func storeIDAtKey(ds datastore.Datastore, key datastore.Key) (*uuid.UUID, error) {
-	idv, err := ds.Get(key)
-	if err != nil {
-		if errors.Is(err, datastore.ErrNotFound) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	id := &uuid.UUID{}
-	if err = id.UnmarshalBinary(idv); err != nil {
-		return nil, err
-	}
-	return id, nil
+	Webhooks          Webhooks
+	WebhookDeliveries WebhookDeliveries
 }
 
 type authKey string
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type AppTokens struct {
+	db *db
+}
+
+func (a *AppTokens) Create(_ context.Context, projectID string, allowedOrigins []string) (*c.AppToken, error) {
+	a.db.Lock()
+	defer a.db.Unlock()
+	token := &c.AppToken{
+		ID:             newID(),
+		ProjectID:      projectID,
+		AllowedOrigins: append([]string(nil), allowedOrigins...),
+	}
+	cp := copyAppToken(token)
+	a.db.appTokens[token.ID] = cp
+	return token, nil
+}
+
+func (a *AppTokens) Get(_ context.Context, id string) (*c.AppToken, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+	token, ok := a.db.appTokens[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	return copyAppToken(token), nil
+}
+
+func (a *AppTokens) List(_ context.Context, projectID string) ([]*c.AppToken, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+	var tokens []*c.AppToken
+	for _, token := range a.db.appTokens {
+		if token.ProjectID == projectID {
+			tokens = append(tokens, copyAppToken(token))
+		}
+	}
+	return tokens, nil
+}
+
+func (a *AppTokens) Delete(_ context.Context, id string) error {
+	a.db.Lock()
+	defer a.db.Unlock()
+	delete(a.db.appTokens, id)
+	return nil
+}
+
+func copyAppToken(token *c.AppToken) *c.AppToken {
+	cp := *token
+	cp.AllowedOrigins = append([]string(nil), token.AllowedOrigins...)
+	return &cp
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type AppUsers struct {
+	db     *db
+	stores c.StoreCreator
+}
+
+func (u *AppUsers) GetOrCreate(ctx context.Context, projectID, deviceID string) (*c.AppUser, error) {
+	if user, err := u.Get(ctx, deviceID); err == nil {
+		return user, nil
+	}
+	user := &c.AppUser{
+		ID:        deviceID,
+		ProjectID: projectID,
+		Created:   time.Now().Unix(),
+	}
+	var err error
+	user.StoreID, err = u.stores.NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u.db.Lock()
+	cp := *user
+	u.db.appUsers[user.ID] = &cp
+	u.db.Unlock()
+	if err = u.stores.Start(ctx, user.StoreID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *AppUsers) Get(_ context.Context, id string) (*c.AppUser, error) {
+	u.db.RLock()
+	defer u.db.RUnlock()
+	user, ok := u.db.appUsers[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	cp := *user
+	return &cp, nil
+}
+
+func (u *AppUsers) List(_ context.Context, projectID string) ([]*c.AppUser, error) {
+	u.db.RLock()
+	defer u.db.RUnlock()
+	var users []*c.AppUser
+	for _, user := range u.db.appUsers {
+		if user.ProjectID == projectID {
+			cp := *user
+			users = append(users, &cp)
+		}
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].Created < users[j].Created
+	})
+	return users, nil
+}
+
+// @todo: Delete associated sessions
+func (u *AppUsers) Delete(_ context.Context, id string) error {
+	u.db.Lock()
+	defer u.db.Unlock()
+	delete(u.db.appUsers, id)
+	return nil
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type AuditEvents struct {
+	db *db
+}
+
+func (a *AuditEvents) Create(_ context.Context, e *c.AuditEvent) error {
+	a.db.Lock()
+	defer a.db.Unlock()
+	e.ID = newID()
+	e.Created = time.Now().Unix()
+	cp := *e
+	a.db.auditEvents = append(a.db.auditEvents, &cp)
+	return nil
+}
+
+func (a *AuditEvents) List(_ context.Context, scope string, since, until int64) ([]*c.AuditEvent, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+	var list []*c.AuditEvent
+	for _, e := range a.db.auditEvents {
+		if e.Scope != scope || e.Created < since || (until > 0 && e.Created >= until) {
+			continue
+		}
+		cp := *e
+		list = append(list, &cp)
+	}
+	return list, nil
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+// backendName identifies archives written by this backend.
+const backendName = "memory"
+
+// backend implements c.Backend for collections that live in memory.
+// Instances always have the current schema, so there's nothing to migrate.
+type backend struct {
+	db *db
+}
+
+// SchemaVersion is always zero.
+func (b *backend) SchemaVersion() (int, error) {
+	return 0, nil
+}
+
+// PendingMigrations is always empty.
+func (b *backend) PendingMigrations() ([]c.Migration, error) {
+	return nil, nil
+}
+
+// Migrate does nothing.
+func (b *backend) Migrate(context.Context) ([]c.Migration, error) {
+	return nil, nil
+}
+
+// models returns each model name with a pointer to its instances.
+func (b *backend) models() map[string]interface{} {
+	return map[string]interface{}{
+		"User":     &b.db.users,
+		"Session":  &b.db.sessions,
+		"Team":     &b.db.teams,
+		"Invite":   &b.db.invites,
+		"Project":  &b.db.projects,
+		"AppToken": &b.db.appTokens,
+		"AppUser":  &b.db.appUsers,
+
+		"AuditEvent": &b.db.auditEvents,
+
+		"Webhook":         &b.db.webhooks,
+		"WebhookDelivery": &b.db.webhookDeliveries,
+	}
+}
+
+// counts returns each model name with its number of instances.
+func (b *backend) counts() map[string]int {
+	return map[string]int{
+		"User":     len(b.db.users),
+		"Session":  len(b.db.sessions),
+		"Team":     len(b.db.teams),
+		"Invite":   len(b.db.invites),
+		"Project":  len(b.db.projects),
+		"AppToken": len(b.db.appTokens),
+		"AppUser":  len(b.db.appUsers),
+
+		"AuditEvent": len(b.db.auditEvents),
+
+		"Webhook":         len(b.db.webhooks),
+		"WebhookDelivery": len(b.db.webhookDeliveries),
+	}
+}
+
+// Backup writes every collection to w as a gzipped tar archive.
+func (b *backend) Backup(_ context.Context, w io.Writer) (*c.Manifest, error) {
+	b.db.RLock()
+	defer b.db.RUnlock()
+	manifest := &c.Manifest{
+		Version: c.BackupVersion,
+		Backend: backendName,
+		Created: time.Now().Unix(),
+		Counts:  b.counts(),
+	}
+	models := make(map[string][]byte)
+	for name, instances := range b.models() {
+		data, err := json.Marshal(instances)
+		if err != nil {
+			return nil, err
+		}
+		models[name] = data
+	}
+	if err := c.WriteArchive(w, manifest, models); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Restore imports a backup archive written by Backup. Collections must be empty.
+func (b *backend) Restore(_ context.Context, r io.Reader) (*c.Manifest, error) {
+	manifest, models, err := c.ReadArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Backend != backendName {
+		return nil, fmt.Errorf("backup was not written by the %s backend", backendName)
+	}
+	b.db.Lock()
+	defer b.db.Unlock()
+	for name, count := range b.counts() {
+		if count > 0 {
+			return nil, fmt.Errorf("%s collection is not empty", name)
+		}
+	}
+	for name, instances := range b.models() {
+		data, ok := models[name]
+		if !ok {
+			return nil, fmt.Errorf("backup is missing %s instances", name)
+		}
+		if err = json.Unmarshal(data, instances); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// Stats returns instance counts for monitoring.
+func (b *backend) Stats(context.Context) (*c.Stats, error) {
+	b.db.RLock()
+	defer b.db.RUnlock()
+	now := int(time.Now().Unix())
+	var active int
+	for _, s := range b.db.sessions {
+		if s.Expiry >= now {
+			active++
+		}
+	}
+	return &c.Stats{
+		Users:          len(b.db.users),
+		Projects:       len(b.db.projects),
+		AppUsers:       len(b.db.appUsers),
+		ActiveSessions: active,
+	}, nil
+}
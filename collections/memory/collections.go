@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	c "github.com/textileio/textile/collections"
+)
+
+// db holds the instances of all collections.
+type db struct {
+	sync.RWMutex
+
+	users    map[string]*c.User
+	sessions map[string]*c.Session
+	teams    map[string]*c.Team
+	invites  map[string]*c.Invite
+	projects map[string]*c.Project
+
+	appTokens map[string]*c.AppToken
+	appUsers  map[string]*c.AppUser
+
+	auditEvents []*c.AuditEvent
+
+	webhooks          map[string]*c.Webhook
+	webhookDeliveries map[string]*c.WebhookDelivery
+}
+
+// NewCollections returns empty collections that live in memory. They are intended
+// for tests that don't need a full daemon. Dedicated project and app user stores are
+// created with stores. If stores is nil, random store IDs are used instead.
+func NewCollections(stores c.StoreCreator) *c.Collections {
+	d := &db{
+		users:    make(map[string]*c.User),
+		sessions: make(map[string]*c.Session),
+		teams:    make(map[string]*c.Team),
+		invites:  make(map[string]*c.Invite),
+		projects: make(map[string]*c.Project),
+
+		appTokens: make(map[string]*c.AppToken),
+		appUsers:  make(map[string]*c.AppUser),
+
+		webhooks:          make(map[string]*c.Webhook),
+		webhookDeliveries: make(map[string]*c.WebhookDelivery),
+	}
+	if stores == nil {
+		stores = randomStores{}
+	}
+	return &c.Collections{
+		Backend: &backend{db: d},
+
+		Users:    &Users{db: d},
+		Sessions: &Sessions{db: d},
+		Teams:    &Teams{db: d},
+		Invites:  &Invites{db: d},
+		Projects: &Projects{db: d, stores: stores},
+
+		AppTokens: &AppTokens{db: d},
+		AppUsers:  &AppUsers{db: d, stores: stores},
+
+		AuditEvents: &AuditEvents{db: d},
+
+		Webhooks:          &Webhooks{db: d},
+		WebhookDeliveries: &WebhookDeliveries{db: d},
+	}
+}
+
+func newID() string {
+	return uuid.New().String()
+}
+
+// randomStores returns random store IDs without creating any stores.
+type randomStores struct{}
+
+func (randomStores) NewStore(context.Context) (string, error) {
+	return newID(), nil
+}
+
+func (randomStores) Start(context.Context, string) error {
+	return nil
+}
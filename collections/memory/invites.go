@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Invites struct {
+	db *db
+}
+
+func (i *Invites) Create(_ context.Context, teamID, fromID, toEmail string) (*c.Invite, error) {
+	i.db.Lock()
+	defer i.db.Unlock()
+	invite := &c.Invite{
+		ID:      newID(),
+		TeamID:  teamID,
+		FromID:  fromID,
+		ToEmail: toEmail,
+		Expiry:  int(time.Now().Add(c.InviteDuration).Unix()),
+	}
+	cp := *invite
+	i.db.invites[invite.ID] = &cp
+	return invite, nil
+}
+
+func (i *Invites) Get(_ context.Context, id string) (*c.Invite, error) {
+	i.db.RLock()
+	defer i.db.RUnlock()
+	invite, ok := i.db.invites[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	cp := *invite
+	return &cp, nil
+}
+
+func (i *Invites) Delete(_ context.Context, id string) error {
+	i.db.Lock()
+	defer i.db.Unlock()
+	delete(i.db.invites, id)
+	return nil
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Projects struct {
+	db     *db
+	stores c.StoreCreator
+}
+
+func (p *Projects) Create(ctx context.Context, name, scope, fcWalletAddress string) (*c.Project, error) {
+	proj := &c.Project{
+		ID:            newID(),
+		Name:          name,
+		Scope:         scope,
+		WalletAddress: fcWalletAddress,
+		Created:       time.Now().Unix(),
+	}
+	// Create a dedicated store for the project
+	var err error
+	proj.StoreID, err = p.stores.NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.db.Lock()
+	cp := *proj
+	p.db.projects[proj.ID] = &cp
+	p.db.Unlock()
+	if err = p.stores.Start(ctx, proj.StoreID); err != nil {
+		return nil, err
+	}
+	return proj, nil
+}
+
+func (p *Projects) Get(_ context.Context, id string) (*c.Project, error) {
+	p.db.RLock()
+	defer p.db.RUnlock()
+	proj, ok := p.db.projects[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	cp := *proj
+	return &cp, nil
+}
+
+func (p *Projects) List(_ context.Context, scope string) ([]*c.Project, error) {
+	p.db.RLock()
+	defer p.db.RUnlock()
+	var projs []*c.Project
+	for _, proj := range p.db.projects {
+		if proj.Scope == scope {
+			cp := *proj
+			projs = append(projs, &cp)
+		}
+	}
+	sort.SliceStable(projs, func(i, j int) bool {
+		return projs[i].Created < projs[j].Created
+	})
+	return projs, nil
+}
+
+func (p *Projects) SwitchScope(_ context.Context, proj *c.Project, scope string) error {
+	p.db.Lock()
+	defer p.db.Unlock()
+	stored, ok := p.db.projects[proj.ID]
+	if !ok {
+		return c.ErrNotFound
+	}
+	stored.Scope = scope
+	proj.Scope = scope
+	return nil
+}
+
+func (p *Projects) Rename(_ context.Context, proj *c.Project, name string) error {
+	p.db.Lock()
+	defer p.db.Unlock()
+	stored, ok := p.db.projects[proj.ID]
+	if !ok {
+		return c.ErrNotFound
+	}
+	stored.Name = name
+	proj.Name = name
+	return nil
+}
+
+func (p *Projects) Delete(_ context.Context, id string) error {
+	p.db.Lock()
+	defer p.db.Unlock()
+	delete(p.db.projects, id)
+	return nil
+}
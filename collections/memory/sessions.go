@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Sessions struct {
+	db *db
+}
+
+func (s *Sessions) Create(_ context.Context, userID, scope string) (*c.Session, error) {
+	s.db.Lock()
+	defer s.db.Unlock()
+	session := &c.Session{
+		ID:     newID(),
+		UserID: userID,
+		Scope:  scope,
+		Expiry: int(time.Now().Add(c.SessionDuration).Unix()),
+	}
+	cp := *session
+	s.db.sessions[session.ID] = &cp
+	return session, nil
+}
+
+func (s *Sessions) Get(_ context.Context, id string) (*c.Session, error) {
+	s.db.RLock()
+	defer s.db.RUnlock()
+	session, ok := s.db.sessions[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	cp := *session
+	return &cp, nil
+}
+
+func (s *Sessions) ListByUser(_ context.Context, userID string) ([]*c.Session, error) {
+	s.db.RLock()
+	defer s.db.RUnlock()
+	var sessions []*c.Session
+	for _, session := range s.db.sessions {
+		if session.UserID == userID {
+			cp := *session
+			sessions = append(sessions, &cp)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *Sessions) Touch(_ context.Context, session *c.Session) error {
+	session.Expiry = int(time.Now().Add(c.SessionDuration).Unix())
+	return s.save(session)
+}
+
+func (s *Sessions) SwitchScope(_ context.Context, session *c.Session, scope string) error {
+	session.Scope = scope
+	return s.save(session)
+}
+
+func (s *Sessions) SwitchUser(_ context.Context, session *c.Session, userID string) error {
+	session.UserID = userID
+	return s.save(session)
+}
+
+func (s *Sessions) save(session *c.Session) error {
+	s.db.Lock()
+	defer s.db.Unlock()
+	if _, ok := s.db.sessions[session.ID]; !ok {
+		return c.ErrNotFound
+	}
+	cp := *session
+	s.db.sessions[session.ID] = &cp
+	return nil
+}
+
+func (s *Sessions) Delete(_ context.Context, id string) error {
+	s.db.Lock()
+	defer s.db.Unlock()
+	delete(s.db.sessions, id)
+	return nil
+}
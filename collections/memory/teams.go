@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Teams struct {
+	db *db
+}
+
+func (t *Teams) Create(_ context.Context, ownerID, name string) (*c.Team, error) {
+	t.db.Lock()
+	defer t.db.Unlock()
+	if t.hasName(ownerID, name, "") {
+		return nil, c.ErrTeamExists
+	}
+	team := &c.Team{
+		ID:      newID(),
+		OwnerID: ownerID,
+		Name:    name,
+		Created: time.Now().Unix(),
+	}
+	cp := *team
+	t.db.teams[team.ID] = &cp
+	return team, nil
+}
+
+func (t *Teams) Get(_ context.Context, id string) (*c.Team, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+	team, ok := t.db.teams[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	cp := *team
+	return &cp, nil
+}
+
+func (t *Teams) ListByOwner(_ context.Context, ownerID string) ([]*c.Team, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+	var teams []*c.Team
+	for _, team := range t.db.teams {
+		if team.OwnerID == ownerID {
+			cp := *team
+			teams = append(teams, &cp)
+		}
+	}
+	return teams, nil
+}
+
+func (t *Teams) SwitchOwner(_ context.Context, team *c.Team, ownerID string) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+	stored, ok := t.db.teams[team.ID]
+	if !ok {
+		return c.ErrNotFound
+	}
+	if t.hasName(ownerID, stored.Name, stored.ID) {
+		return c.ErrTeamExists
+	}
+	stored.OwnerID = ownerID
+	team.OwnerID = ownerID
+	return nil
+}
+
+func (t *Teams) Update(_ context.Context, team *c.Team) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+	stored, ok := t.db.teams[team.ID]
+	if !ok {
+		return c.ErrNotFound
+	}
+	if t.hasName(stored.OwnerID, team.Name, stored.ID) {
+		return c.ErrTeamExists
+	}
+	stored.Name = team.Name
+	stored.Description = team.Description
+	stored.Website = team.Website
+	stored.Avatar = team.Avatar
+	return nil
+}
+
+// hasName must be called with the db lock held.
+func (t *Teams) hasName(ownerID, name, teamID string) bool {
+	var teams []*c.Team
+	for _, team := range t.db.teams {
+		if team.OwnerID == ownerID {
+			teams = append(teams, team)
+		}
+	}
+	return c.HasTeamName(teams, name, teamID)
+}
+
+func (t *Teams) Delete(_ context.Context, id string) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+	delete(t.db.teams, id)
+	return nil
+}
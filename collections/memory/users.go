@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Users struct {
+	db *db
+}
+
+func copyUser(user *c.User) *c.User {
+	cp := *user
+	cp.Teams = append([]string{}, user.Teams...)
+	return &cp
+}
+
+// Create a new user with the given email.
+// Returns c.ErrUserExists if a user with the normalized email already exists.
+func (u *Users) Create(_ context.Context, email string) (*c.User, error) {
+	u.db.Lock()
+	defer u.db.Unlock()
+	email = c.NormalizeEmail(email)
+	if u.getByEmail(email) != nil {
+		return nil, c.ErrUserExists
+	}
+	return u.create(email), nil
+}
+
+// GetOrCreate returns the user with the given email, creating one if needed.
+func (u *Users) GetOrCreate(_ context.Context, email string) (*c.User, error) {
+	u.db.Lock()
+	defer u.db.Unlock()
+	email = c.NormalizeEmail(email)
+	if user := u.getByEmail(email); user != nil {
+		return copyUser(user), nil
+	}
+	return u.create(email), nil
+}
+
+func (u *Users) create(email string) *c.User {
+	user := &c.User{
+		ID:      newID(),
+		Email:   email,
+		Teams:   []string{},
+		Created: time.Now().Unix(),
+	}
+	u.db.users[user.ID] = copyUser(user)
+	return user
+}
+
+func (u *Users) Get(_ context.Context, id string) (*c.User, error) {
+	u.db.RLock()
+	defer u.db.RUnlock()
+	user, ok := u.db.users[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	return copyUser(user), nil
+}
+
+// GetByEmail returns the user with the given email.
+func (u *Users) GetByEmail(_ context.Context, email string) (*c.User, error) {
+	u.db.RLock()
+	defer u.db.RUnlock()
+	user := u.getByEmail(c.NormalizeEmail(email))
+	if user == nil {
+		return nil, c.ErrNotFound
+	}
+	return copyUser(user), nil
+}
+
+// GetByUsername returns the user with the given username.
+func (u *Users) GetByUsername(_ context.Context, username string) (*c.User, error) {
+	u.db.RLock()
+	defer u.db.RUnlock()
+	username = c.NormalizeUsername(username)
+	for _, user := range u.db.users {
+		if username != "" && user.Username == username {
+			return copyUser(user), nil
+		}
+	}
+	return nil, c.ErrNotFound
+}
+
+func (u *Users) getByEmail(email string) *c.User {
+	for _, user := range u.db.users {
+		if user.Email == email {
+			return user
+		}
+	}
+	return nil
+}
+
+// List returns all users.
+func (u *Users) List(_ context.Context) ([]*c.User, error) {
+	return u.list(func(*c.User) bool { return true }), nil
+}
+
+func (u *Users) ListByTeam(_ context.Context, teamID string) ([]*c.User, error) {
+	return u.list(func(user *c.User) bool { return user.HasTeam(teamID) }), nil
+}
+
+// list returns matching users, oldest first.
+func (u *Users) list(match func(*c.User) bool) []*c.User {
+	u.db.RLock()
+	defer u.db.RUnlock()
+	var users []*c.User
+	for _, user := range u.db.users {
+		if match(user) {
+			users = append(users, copyUser(user))
+		}
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].Created < users[j].Created
+	})
+	return users
+}
+
+func (u *Users) JoinTeam(ctx context.Context, user *c.User, teamID string) error {
+	if user.HasTeam(teamID) {
+		return nil
+	}
+	user.Teams = append(user.Teams, teamID)
+	return u.Save(ctx, user)
+}
+
+func (u *Users) LeaveTeam(ctx context.Context, user *c.User, teamID string) error {
+	n := 0
+	for _, x := range user.Teams {
+		if x != teamID {
+			user.Teams[n] = x
+			n++
+		}
+	}
+	user.Teams = user.Teams[:n]
+	return u.Save(ctx, user)
+}
+
+// Save an existing user.
+// Returns c.ErrUserExists or c.ErrUsernameExists if another user has the email or username.
+func (u *Users) Save(_ context.Context, user *c.User) error {
+	u.db.Lock()
+	defer u.db.Unlock()
+	if _, ok := u.db.users[user.ID]; !ok {
+		return c.ErrNotFound
+	}
+	for _, other := range u.db.users {
+		if other.ID == user.ID {
+			continue
+		}
+		if other.Email == user.Email {
+			return c.ErrUserExists
+		}
+		if user.Username != "" && other.Username == user.Username {
+			return c.ErrUsernameExists
+		}
+	}
+	u.db.users[user.ID] = copyUser(user)
+	return nil
+}
+
+func (u *Users) Delete(_ context.Context, id string) error {
+	u.db.Lock()
+	defer u.db.Unlock()
+	delete(u.db.users, id)
+	return nil
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Webhooks struct {
+	db *db
+}
+
+func (w *Webhooks) Create(_ context.Context, projectID, url, secret string, events []string) (*c.Webhook, error) {
+	w.db.Lock()
+	defer w.db.Unlock()
+	hook := &c.Webhook{
+		ID:        newID(),
+		ProjectID: projectID,
+		URL:       url,
+		Secret:    secret,
+		Events:    append([]string(nil), events...),
+		Created:   time.Now().Unix(),
+	}
+	w.db.webhooks[hook.ID] = copyWebhook(hook)
+	return hook, nil
+}
+
+func (w *Webhooks) Get(_ context.Context, id string) (*c.Webhook, error) {
+	w.db.RLock()
+	defer w.db.RUnlock()
+	hook, ok := w.db.webhooks[id]
+	if !ok {
+		return nil, c.ErrNotFound
+	}
+	return copyWebhook(hook), nil
+}
+
+func (w *Webhooks) List(_ context.Context, projectID string) ([]*c.Webhook, error) {
+	w.db.RLock()
+	defer w.db.RUnlock()
+	var hooks []*c.Webhook
+	for _, hook := range w.db.webhooks {
+		if hook.ProjectID == projectID {
+			hooks = append(hooks, copyWebhook(hook))
+		}
+	}
+	return hooks, nil
+}
+
+func (w *Webhooks) Delete(_ context.Context, id string) error {
+	w.db.Lock()
+	defer w.db.Unlock()
+	delete(w.db.webhooks, id)
+	return nil
+}
+
+func copyWebhook(hook *c.Webhook) *c.Webhook {
+	cp := *hook
+	cp.Events = append([]string(nil), hook.Events...)
+	return &cp
+}
+
+type WebhookDeliveries struct {
+	db *db
+}
+
+func (w *WebhookDeliveries) Create(_ context.Context, d *c.WebhookDelivery) error {
+	w.db.Lock()
+	defer w.db.Unlock()
+	d.ID = newID()
+	d.Created = time.Now().Unix()
+	cp := *d
+	w.db.webhookDeliveries[d.ID] = &cp
+	return nil
+}
+
+func (w *WebhookDeliveries) ListDue(_ context.Context, now int64) ([]*c.WebhookDelivery, error) {
+	w.db.RLock()
+	defer w.db.RUnlock()
+	var list []*c.WebhookDelivery
+	for _, d := range w.db.webhookDeliveries {
+		if d.NextAttempt <= now {
+			cp := *d
+			list = append(list, &cp)
+		}
+	}
+	return list, nil
+}
+
+func (w *WebhookDeliveries) Save(_ context.Context, d *c.WebhookDelivery) error {
+	w.db.Lock()
+	defer w.db.Unlock()
+	if _, ok := w.db.webhookDeliveries[d.ID]; !ok {
+		return c.ErrNotFound
+	}
+	cp := *d
+	w.db.webhookDeliveries[d.ID] = &cp
+	return nil
+}
+
+func (w *WebhookDeliveries) Delete(_ context.Context, id string) error {
+	w.db.Lock()
+	defer w.db.Unlock()
+	delete(w.db.webhookDeliveries, id)
+	return nil
+}
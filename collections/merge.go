@@ -0,0 +1,123 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// UserMerge describes a group of users that share a normalized email.
+type UserMerge struct {
+	Email  string
+	UserID string   // the user that was kept
+	Merged []string // IDs of the removed duplicates
+}
+
+// MergeUsers consolidates users whose normalized emails collide into the oldest user.
+// Team memberships, team ownership, sessions, and personal projects of each duplicate
+// are moved to the kept user before the duplicate is deleted. Moved teams whose names
+// are taken by the kept user get the team ID appended to their name. The kept user's email
+// is rewritten in normalized form. If dryRun is true, merges are reported but not written.
+// Users must not be created while merging, i.e., the daemon must be stopped.
+func (c *Collections) MergeUsers(ctx context.Context, dryRun bool) ([]*UserMerge, error) {
+	users, err := c.Users.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string][]*User)
+	var emails []string
+	for _, u := range users {
+		email := NormalizeEmail(u.Email)
+		if _, ok := groups[email]; !ok {
+			emails = append(emails, email)
+		}
+		groups[email] = append(groups[email], u)
+	}
+	sort.Strings(emails)
+
+	var merges []*UserMerge
+	for _, email := range emails {
+		group := groups[email]
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Created < group[j].Created
+		})
+		keep, dups := group[0], group[1:]
+		if len(dups) == 0 && keep.Email == email {
+			continue
+		}
+		merge := &UserMerge{Email: email, UserID: keep.ID}
+		for _, dup := range dups {
+			merge.Merged = append(merge.Merged, dup.ID)
+		}
+		merges = append(merges, merge)
+		if dryRun {
+			continue
+		}
+
+		for _, dup := range dups {
+			if err = c.mergeUser(ctx, keep, dup); err != nil {
+				return nil, err
+			}
+		}
+		keep.Email = email
+		if err = c.Users.Save(ctx, keep); err != nil {
+			return nil, err
+		}
+		log.Infof("merged %d duplicate users into %s", len(dups), keep.ID)
+	}
+	return merges, nil
+}
+
+// mergeUser moves everything owned by dup to keep and deletes dup.
+func (c *Collections) mergeUser(ctx context.Context, keep, dup *User) error {
+	for _, t := range dup.Teams {
+		if !keep.HasTeam(t) {
+			keep.Teams = append(keep.Teams, t)
+		}
+	}
+
+	teams, err := c.Teams.ListByOwner(ctx, dup.ID)
+	if err != nil {
+		return err
+	}
+	for _, t := range teams {
+		err = c.Teams.SwitchOwner(ctx, t, keep.ID)
+		if errors.Is(err, ErrTeamExists) {
+			// Keep both teams by making the moved team's name unique.
+			t.Name = fmt.Sprintf("%s (%s)", t.Name, t.ID)
+			if err = c.Teams.Update(ctx, t); err != nil {
+				return err
+			}
+			err = c.Teams.SwitchOwner(ctx, t, keep.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	sessions, err := c.Sessions.ListByUser(ctx, dup.ID)
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if s.Scope == dup.ID {
+			s.Scope = keep.ID
+		}
+		if err = c.Sessions.SwitchUser(ctx, s, keep.ID); err != nil {
+			return err
+		}
+	}
+
+	projs, err := c.Projects.List(ctx, dup.ID)
+	if err != nil {
+		return err
+	}
+	for _, p := range projs {
+		if err = c.Projects.SwitchScope(ctx, p, keep.ID); err != nil {
+			return err
+		}
+	}
+
+	return c.Users.Delete(ctx, dup.ID)
+}
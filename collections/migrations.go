@@ -0,0 +1,8 @@
+package collections
+
+// Migration upgrades a backend from the previous schema version.
+// Each backend keeps its own ordered list of migrations.
+type Migration struct {
+	Version     int
+	Description string
+}
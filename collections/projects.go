@@ -1,13 +1,6 @@
 package collections
 
-import (
-	"context"
-	"time"
-
-	"github.com/google/uuid"
-	"github.com/textileio/go-threads/api/client"
-	s "github.com/textileio/go-threads/store"
-)
+import "context"
 
 type Project struct {
 	ID            string
@@ -18,67 +11,12 @@ type Project struct {
 	Created       int64
 }
 
-type Projects struct {
-	threads *client.Client
-	storeID *uuid.UUID
-	token   string
-}
-
-func (p *Projects) GetName() string {
-	return "Project"
-}
-
-func (p *Projects) GetInstance() interface{} {
-	return &Project{}
-}
-
-func (p *Projects) GetStoreID() *uuid.UUID {
-	return p.storeID
-}
-
-func (p *Projects) Create(ctx context.Context, name, scope, fcWalletAddress string) (*Project, error) {
-	ctx = AuthCtx(ctx, p.token)
-	proj := &Project{
-		Name:          name,
-		Scope:         scope,
-		WalletAddress: fcWalletAddress,
-		Created:       time.Now().Unix(),
-	}
-	// Create a dedicated store for the project
-	var err error
-	proj.StoreID, err = p.threads.NewStore(ctx)
-	if err != nil {
-		return nil, err
-	}
-	if err = p.threads.ModelCreate(ctx, p.storeID.String(), p.GetName(), proj); err != nil {
-		return nil, err
-	}
-	if err = p.threads.Start(ctx, proj.StoreID); err != nil {
-		return nil, err
-	}
-	return proj, nil
-}
-
-func (p *Projects) Get(ctx context.Context, id string) (*Project, error) {
-	ctx = AuthCtx(ctx, p.token)
-	proj := &Project{}
-	if err := p.threads.ModelFindByID(ctx, p.storeID.String(), p.GetName(), id, proj); err != nil {
-		return nil, err
-	}
-	return proj, nil
-}
-
-func (p *Projects) List(ctx context.Context, scope string) ([]*Project, error) {
-	ctx = AuthCtx(ctx, p.token)
-	query := s.JSONWhere("Scope").Eq(scope)
-	res, err := p.threads.ModelFind(ctx, p.storeID.String(), p.GetName(), query, []*Project{})
-	if err != nil {
-		return nil, err
-	}
-	return res.([]*Project), nil
-}
-
-func (p *Projects) Delete(ctx context.Context, id string) error {
-	ctx = AuthCtx(ctx, p.token)
-	return p.threads.ModelDelete(ctx, p.storeID.String(), p.GetName(), id)
+type Projects interface {
+	// Create a new project with a dedicated threads store.
+	Create(ctx context.Context, name, scope, fcWalletAddress string) (*Project, error)
+	Get(ctx context.Context, id string) (*Project, error)
+	List(ctx context.Context, scope string) ([]*Project, error)
+	SwitchScope(ctx context.Context, proj *Project, scope string) error
+	Rename(ctx context.Context, proj *Project, name string) error
+	Delete(ctx context.Context, id string) error
 }
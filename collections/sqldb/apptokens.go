@@ -0,0 +1,83 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type AppTokens struct {
+	db *sql.DB
+}
+
+func (a *AppTokens) Create(ctx context.Context, projectID string, allowedOrigins []string) (*c.AppToken, error) {
+	token := &c.AppToken{
+		ID:             newID(),
+		ProjectID:      projectID,
+		AllowedOrigins: allowedOrigins,
+	}
+	if err := a.insert(ctx, a.db, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (a *AppTokens) insert(ctx context.Context, q querier, token *c.AppToken) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO app_tokens (id, project_id, allowed_origins) VALUES ($1, $2, $3)`,
+		token.ID, token.ProjectID, joinOrigins(token.AllowedOrigins))
+	return err
+}
+
+func (a *AppTokens) Get(ctx context.Context, id string) (*c.AppToken, error) {
+	token := &c.AppToken{}
+	var origins string
+	if err := a.db.QueryRowContext(ctx,
+		`SELECT id, project_id, allowed_origins FROM app_tokens WHERE id = $1`, id).Scan(
+		&token.ID, &token.ProjectID, &origins); err != nil {
+		return nil, notFound(err)
+	}
+	token.AllowedOrigins = splitOrigins(origins)
+	return token, nil
+}
+
+func (a *AppTokens) List(ctx context.Context, projectID string) ([]*c.AppToken, error) {
+	return a.list(ctx, `SELECT id, project_id, allowed_origins FROM app_tokens WHERE project_id = $1`, projectID)
+}
+
+func (a *AppTokens) list(ctx context.Context, query string, args ...interface{}) ([]*c.AppToken, error) {
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tokens []*c.AppToken
+	for rows.Next() {
+		token := &c.AppToken{}
+		var origins string
+		if err = rows.Scan(&token.ID, &token.ProjectID, &origins); err != nil {
+			return nil, err
+		}
+		token.AllowedOrigins = splitOrigins(origins)
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (a *AppTokens) Delete(ctx context.Context, id string) error {
+	_, err := a.db.ExecContext(ctx, `DELETE FROM app_tokens WHERE id = $1`, id)
+	return err
+}
+
+// joinOrigins encodes allowed origins as a space separated list, which is
+// unambiguous since origins can't contain spaces.
+func joinOrigins(origins []string) string {
+	return strings.Join(origins, " ")
+}
+
+// splitOrigins decodes allowed origins encoded by joinOrigins.
+func splitOrigins(origins string) []string {
+	return strings.Fields(origins)
+}
@@ -0,0 +1,88 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type AppUsers struct {
+	db     *sql.DB
+	stores c.StoreCreator
+	token  string
+}
+
+func (u *AppUsers) GetOrCreate(ctx context.Context, projectID, deviceID string) (*c.AppUser, error) {
+	user, err := u.Get(ctx, deviceID)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, c.ErrNotFound) {
+		return nil, err
+	}
+	ctx = c.AuthCtx(ctx, u.token)
+	user = &c.AppUser{
+		ID:        deviceID,
+		ProjectID: projectID,
+		Created:   time.Now().Unix(),
+	}
+	user.StoreID, err = u.stores.NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = u.insert(ctx, u.db, user); err != nil {
+		return nil, err
+	}
+	if err = u.stores.Start(ctx, user.StoreID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *AppUsers) insert(ctx context.Context, q querier, user *c.AppUser) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO app_users (id, project_id, store_id, created) VALUES ($1, $2, $3, $4)`,
+		user.ID, user.ProjectID, user.StoreID, user.Created)
+	return err
+}
+
+func (u *AppUsers) Get(ctx context.Context, id string) (*c.AppUser, error) {
+	user := &c.AppUser{}
+	if err := u.db.QueryRowContext(ctx,
+		`SELECT id, project_id, store_id, created FROM app_users WHERE id = $1`, id).Scan(
+		&user.ID, &user.ProjectID, &user.StoreID, &user.Created); err != nil {
+		return nil, notFound(err)
+	}
+	return user, nil
+}
+
+func (u *AppUsers) List(ctx context.Context, projectID string) ([]*c.AppUser, error) {
+	return u.list(ctx, `SELECT id, project_id, store_id, created FROM app_users
+WHERE project_id = $1 ORDER BY created`, projectID)
+}
+
+func (u *AppUsers) list(ctx context.Context, query string, args ...interface{}) ([]*c.AppUser, error) {
+	rows, err := u.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var users []*c.AppUser
+	for rows.Next() {
+		user := &c.AppUser{}
+		if err = rows.Scan(&user.ID, &user.ProjectID, &user.StoreID, &user.Created); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// @todo: Delete associated sessions
+func (u *AppUsers) Delete(ctx context.Context, id string) error {
+	_, err := u.db.ExecContext(ctx, `DELETE FROM app_users WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,55 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+const auditEventColumns = `id, actor_id, scope, action, target_id, ip, created`
+
+type AuditEvents struct {
+	db *sql.DB
+}
+
+func (a *AuditEvents) Create(ctx context.Context, e *c.AuditEvent) error {
+	e.ID = newID()
+	e.Created = time.Now().Unix()
+	return a.insert(ctx, a.db, e)
+}
+
+func (a *AuditEvents) insert(ctx context.Context, q querier, e *c.AuditEvent) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO audit_events (`+auditEventColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		e.ID, e.ActorID, e.Scope, e.Action, e.TargetID, e.IP, e.Created)
+	return err
+}
+
+func (a *AuditEvents) List(ctx context.Context, scope string, since, until int64) ([]*c.AuditEvent, error) {
+	query := `SELECT ` + auditEventColumns + ` FROM audit_events WHERE scope = $1 AND created >= $2`
+	args := []interface{}{scope, since}
+	if until > 0 {
+		query += ` AND created < $3`
+		args = append(args, until)
+	}
+	return a.list(ctx, query+` ORDER BY created, id`, args...)
+}
+
+func (a *AuditEvents) list(ctx context.Context, query string, args ...interface{}) ([]*c.AuditEvent, error) {
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []*c.AuditEvent
+	for rows.Next() {
+		e := &c.AuditEvent{}
+		if err = rows.Scan(&e.ID, &e.ActorID, &e.Scope, &e.Action, &e.TargetID, &e.IP, &e.Created); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,239 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+// backendName identifies archives written by this backend.
+const backendName = "sql"
+
+// Model names used in archives, shared with the threads backend.
+const (
+	userModel     = "User"
+	sessionModel  = "Session"
+	teamModel     = "Team"
+	inviteModel   = "Invite"
+	projectModel  = "Project"
+	appTokenModel = "AppToken"
+	appUserModel  = "AppUser"
+
+	auditEventModel = "AuditEvent"
+
+	webhookModel         = "Webhook"
+	webhookDeliveryModel = "WebhookDelivery"
+)
+
+// Backup writes every collection to w as a gzipped tar archive.
+func (b *backend) Backup(ctx context.Context, w io.Writer) (*c.Manifest, error) {
+	version, err := b.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &c.Manifest{
+		Version:       c.BackupVersion,
+		Backend:       backendName,
+		SchemaVersion: version,
+		Created:       time.Now().Unix(),
+		Counts:        make(map[string]int),
+	}
+
+	users, err := b.users.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := b.sessions.list(ctx, `SELECT id, user_id, scope, expiry FROM sessions`)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := b.teams.list(ctx, `SELECT `+teamColumns+` FROM teams`)
+	if err != nil {
+		return nil, err
+	}
+	invites, err := b.invites.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projs, err := b.projects.list(ctx, `SELECT id, name, scope, store_id, wallet_address, created FROM projects`)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := b.appTokens.list(ctx, `SELECT id, project_id, allowed_origins FROM app_tokens`)
+	if err != nil {
+		return nil, err
+	}
+	appUsers, err := b.appUsers.list(ctx, `SELECT id, project_id, store_id, created FROM app_users`)
+	if err != nil {
+		return nil, err
+	}
+	events, err := b.auditEvents.list(ctx, `SELECT `+auditEventColumns+` FROM audit_events ORDER BY created, id`)
+	if err != nil {
+		return nil, err
+	}
+	hooks, err := b.webhooks.list(ctx, `SELECT `+webhookColumns+` FROM webhooks`)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := b.webhookDeliveries.list(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries`)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make(map[string][]byte)
+	for _, m := range []struct {
+		name      string
+		instances interface{}
+		count     int
+	}{
+		{name: userModel, instances: users, count: len(users)},
+		{name: sessionModel, instances: sessions, count: len(sessions)},
+		{name: teamModel, instances: teams, count: len(teams)},
+		{name: inviteModel, instances: invites, count: len(invites)},
+		{name: projectModel, instances: projs, count: len(projs)},
+		{name: appTokenModel, instances: tokens, count: len(tokens)},
+		{name: appUserModel, instances: appUsers, count: len(appUsers)},
+		{name: auditEventModel, instances: events, count: len(events)},
+		{name: webhookModel, instances: hooks, count: len(hooks)},
+		{name: webhookDeliveryModel, instances: deliveries, count: len(deliveries)},
+	} {
+		data, err := json.Marshal(m.instances)
+		if err != nil {
+			return nil, err
+		}
+		models[m.name] = data
+		manifest.Counts[m.name] = m.count
+	}
+	if err = c.WriteArchive(w, manifest, models); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Restore imports a backup archive written by Backup. Tables must be empty,
+// i.e., the database must be fresh. All instances are written in a single transaction.
+func (b *backend) Restore(ctx context.Context, r io.Reader) (*c.Manifest, error) {
+	manifest, models, err := c.ReadArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Backend != backendName {
+		return nil, fmt.Errorf("backup was not written by the %s backend", backendName)
+	}
+	if manifest.SchemaVersion != latestSchemaVersion() {
+		return nil, fmt.Errorf("backup schema version %d does not match current version %d",
+			manifest.SchemaVersion, latestSchemaVersion())
+	}
+	for _, table := range []string{"users", "sessions", "teams", "invites", "projects", "app_tokens", "app_users",
+		"audit_events", "webhooks", "webhook_deliveries"} {
+		var count int
+		if err = b.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table).Scan(&count); err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("%s table is not empty", table)
+		}
+	}
+
+	var (
+		users    []*c.User
+		sessions []*c.Session
+		teams    []*c.Team
+		invites  []*c.Invite
+		projs    []*c.Project
+		tokens   []*c.AppToken
+		appUsers []*c.AppUser
+		events   []*c.AuditEvent
+
+		hooks      []*c.Webhook
+		deliveries []*c.WebhookDelivery
+	)
+	for name, v := range map[string]interface{}{
+		userModel:     &users,
+		sessionModel:  &sessions,
+		teamModel:     &teams,
+		inviteModel:   &invites,
+		projectModel:  &projs,
+		appTokenModel: &tokens,
+		appUserModel:  &appUsers,
+
+		auditEventModel: &events,
+
+		webhookModel:         &hooks,
+		webhookDeliveryModel: &deliveries,
+	} {
+		data, ok := models[name]
+		if !ok {
+			return nil, fmt.Errorf("backup is missing %s instances", name)
+		}
+		if err = json.Unmarshal(data, v); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = withTx(ctx, b.db, func(tx *sql.Tx) error {
+		for _, x := range users {
+			if created, err := b.users.insert(ctx, tx, x); err != nil {
+				return err
+			} else if !created {
+				return fmt.Errorf("user %s: %w", x.ID, c.ErrUserExists)
+			}
+		}
+		for _, x := range sessions {
+			if err := b.sessions.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		for _, x := range teams {
+			if created, err := b.teams.insert(ctx, tx, x); err != nil {
+				return err
+			} else if !created {
+				return fmt.Errorf("team %s: %w", x.ID, c.ErrTeamExists)
+			}
+		}
+		for _, x := range invites {
+			if err := b.invites.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		for _, x := range projs {
+			if err := b.projects.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		for _, x := range tokens {
+			if err := b.appTokens.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		for _, x := range appUsers {
+			if err := b.appUsers.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		for _, x := range events {
+			if err := b.auditEvents.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		for _, x := range hooks {
+			if err := b.webhooks.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		for _, x := range deliveries {
+			if err := b.webhookDeliveries.insert(ctx, tx, x); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
@@ -0,0 +1,124 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	logging "github.com/ipfs/go-log"
+	c "github.com/textileio/textile/collections"
+)
+
+var (
+	log = logging.Logger("collections")
+)
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// backend implements c.Backend for collections stored in a SQL database.
+type backend struct {
+	db *sql.DB
+
+	users    *Users
+	sessions *Sessions
+	teams    *Teams
+	invites  *Invites
+	projects *Projects
+
+	appTokens *AppTokens
+	appUsers  *AppUsers
+
+	auditEvents *AuditEvents
+
+	webhooks          *Webhooks
+	webhookDeliveries *WebhookDeliveries
+}
+
+// NewCollections returns collections stored in db, which must be an SQLite or a Postgres
+// database. Queries only use syntax common to both. Dedicated project and app user stores
+// are created with stores, authorized by token.
+// Pending schema migrations are not applied, see Migrate.
+func NewCollections(ctx context.Context, db *sql.DB, stores c.StoreCreator, token string) (*c.Collections, error) {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return nil, err
+	}
+	b := &backend{
+		db: db,
+
+		users:    &Users{db: db},
+		sessions: &Sessions{db: db},
+		teams:    &Teams{db: db},
+		invites:  &Invites{db: db},
+		projects: &Projects{db: db, stores: stores, token: token},
+
+		appTokens: &AppTokens{db: db},
+		appUsers:  &AppUsers{db: db, stores: stores, token: token},
+
+		auditEvents: &AuditEvents{db: db},
+
+		webhooks:          &Webhooks{db: db},
+		webhookDeliveries: &WebhookDeliveries{db: db},
+	}
+	return &c.Collections{
+		Backend: b,
+
+		Users:    b.users,
+		Sessions: b.sessions,
+		Teams:    b.teams,
+		Invites:  b.invites,
+		Projects: b.projects,
+
+		AppTokens: b.appTokens,
+		AppUsers:  b.appUsers,
+
+		AuditEvents: b.auditEvents,
+
+		Webhooks:          b.webhooks,
+		WebhookDeliveries: b.webhookDeliveries,
+	}, nil
+}
+
+// Stats returns instance counts for monitoring.
+func (b *backend) Stats(ctx context.Context) (*c.Stats, error) {
+	stats := &c.Stats{}
+	if err := b.db.QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*) FROM users),
+		(SELECT COUNT(*) FROM projects),
+		(SELECT COUNT(*) FROM app_users),
+		(SELECT COUNT(*) FROM sessions WHERE expiry >= $1)`, time.Now().Unix()).Scan(
+		&stats.Users, &stats.Projects, &stats.AppUsers, &stats.ActiveSessions); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func newID() string {
+	return uuid.New().String()
+}
+
+// notFound maps sql.ErrNoRows to c.ErrNotFound.
+func notFound(err error) error {
+	if err == sql.ErrNoRows {
+		return c.ErrNotFound
+	}
+	return err
+}
+
+// withTx runs f in a transaction, which is committed if f succeeds.
+func withTx(ctx context.Context, db *sql.DB, f func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
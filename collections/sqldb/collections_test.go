@@ -0,0 +1,432 @@
+package sqldb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	c "github.com/textileio/textile/collections"
+)
+
+func TestUsers_GetOrCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols, done := setup(t)
+	defer done()
+
+	t.Run("test create user", func(t *testing.T) {
+		user, err := cols.Users.Create(ctx, " Jon@Doe.com ")
+		if err != nil {
+			t.Fatalf("create user should succeed: %v", err)
+		}
+		if user.Email != "jon@doe.com" {
+			t.Fatalf("got unnormalized email %s", user.Email)
+		}
+		if _, err = cols.Users.Create(ctx, "jon@doe.com"); !errors.Is(err, c.ErrUserExists) {
+			t.Fatalf("create duplicate user should fail with ErrUserExists, got: %v", err)
+		}
+	})
+
+	t.Run("test get or create user", func(t *testing.T) {
+		a, err := cols.Users.GetOrCreate(ctx, "jane@doe.com")
+		if err != nil {
+			t.Fatalf("get or create user should succeed: %v", err)
+		}
+		b, err := cols.Users.GetOrCreate(ctx, "JANE@doe.com")
+		if err != nil {
+			t.Fatalf("get or create user should succeed: %v", err)
+		}
+		if a.ID != b.ID {
+			t.Fatal("got different users for the same email")
+		}
+		named, err := cols.Users.GetOrCreate(ctx, "Jane Doe <jane@doe.com>")
+		if err != nil {
+			t.Fatalf("get or create user should succeed: %v", err)
+		}
+		if a.ID != named.ID {
+			t.Fatal("got different users for the same address with a display name")
+		}
+	})
+
+	t.Run("test get missing user", func(t *testing.T) {
+		if _, err := cols.Users.Get(ctx, "missing"); !errors.Is(err, c.ErrNotFound) {
+			t.Fatalf("get missing user should fail with ErrNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestUsers_GetByUsername(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols, done := setup(t)
+	defer done()
+
+	jon, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jane, err := cols.Users.Create(ctx, "jane@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test get by username", func(t *testing.T) {
+		jon.Name = "Jon Doe"
+		jon.Username = "jon"
+		if err := cols.Users.Save(ctx, jon); err != nil {
+			t.Fatalf("save user should succeed: %v", err)
+		}
+		got, err := cols.Users.GetByUsername(ctx, "JON")
+		if err != nil {
+			t.Fatalf("get by username should succeed: %v", err)
+		}
+		if got.ID != jon.ID || got.Name != "Jon Doe" {
+			t.Fatalf("got wrong user %v", got)
+		}
+	})
+
+	t.Run("test save taken username", func(t *testing.T) {
+		jane.Username = "jon"
+		if err := cols.Users.Save(ctx, jane); !errors.Is(err, c.ErrUsernameExists) {
+			t.Fatalf("save user with taken username should fail with ErrUsernameExists, got: %v", err)
+		}
+		jane.Username = ""
+	})
+
+	t.Run("test save taken email", func(t *testing.T) {
+		jane.Email = "jon@doe.com"
+		if err := cols.Users.Save(ctx, jane); !errors.Is(err, c.ErrUserExists) {
+			t.Fatalf("save user with taken email should fail with ErrUserExists, got: %v", err)
+		}
+	})
+
+	t.Run("test get by empty username", func(t *testing.T) {
+		if _, err := cols.Users.GetByUsername(ctx, ""); !errors.Is(err, c.ErrNotFound) {
+			t.Fatalf("get by empty username should fail with ErrNotFound, got: %v", err)
+		}
+	})
+}
+
+func TestUsers_JoinTeam(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols, done := setup(t)
+	defer done()
+
+	user, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := cols.Teams.Create(ctx, user.ID, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test join team", func(t *testing.T) {
+		if err := cols.Users.JoinTeam(ctx, user, team.ID); err != nil {
+			t.Fatalf("join team should succeed: %v", err)
+		}
+		members, err := cols.Users.ListByTeam(ctx, team.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(members) != 1 || members[0].ID != user.ID {
+			t.Fatal("got bad team members")
+		}
+		got, err := cols.Users.Get(ctx, user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.HasTeam(team.ID) {
+			t.Fatal("user should have team")
+		}
+	})
+
+	t.Run("test leave team", func(t *testing.T) {
+		if err := cols.Users.LeaveTeam(ctx, user, team.ID); err != nil {
+			t.Fatalf("leave team should succeed: %v", err)
+		}
+		members, err := cols.Users.ListByTeam(ctx, team.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(members) != 0 {
+			t.Fatal("team should not have members")
+		}
+	})
+}
+
+func TestTeams_Name(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols, done := setup(t)
+	defer done()
+
+	foo, err := cols.Teams.Create(ctx, "owner", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bar, err := cols.Teams.Create(ctx, "owner", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test create with taken name", func(t *testing.T) {
+		if _, err := cols.Teams.Create(ctx, "owner", "FOO"); !errors.Is(err, c.ErrTeamExists) {
+			t.Fatalf("create with taken name should fail with ErrTeamExists, got: %v", err)
+		}
+	})
+
+	t.Run("test create with other owner", func(t *testing.T) {
+		if _, err := cols.Teams.Create(ctx, "other", "foo"); err != nil {
+			t.Fatalf("create for other owner should succeed: %v", err)
+		}
+	})
+
+	t.Run("test update with taken name", func(t *testing.T) {
+		bar.Name = "Foo"
+		if err := cols.Teams.Update(ctx, bar); !errors.Is(err, c.ErrTeamExists) {
+			t.Fatalf("update with taken name should fail with ErrTeamExists, got: %v", err)
+		}
+	})
+
+	t.Run("test update with own name", func(t *testing.T) {
+		foo.Name = "Foo"
+		if err := cols.Teams.Update(ctx, foo); err != nil {
+			t.Fatalf("update with own name should succeed: %v", err)
+		}
+	})
+
+	t.Run("test switch owner with taken name", func(t *testing.T) {
+		if err := cols.Teams.SwitchOwner(ctx, foo, "other"); !errors.Is(err, c.ErrTeamExists) {
+			t.Fatalf("switch owner with taken name should fail with ErrTeamExists, got: %v", err)
+		}
+	})
+}
+
+func TestAuditEvents_List(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols, done := setup(t)
+	defer done()
+
+	for _, e := range []*c.AuditEvent{
+		{ActorID: "jon", Scope: "team", Action: c.ActionTeamAdd, TargetID: "team"},
+		{ActorID: "jon", Scope: "team", Action: c.ActionTeamUpdate, TargetID: "team"},
+		{ActorID: "jon", Scope: "jon", Action: c.ActionLogin, TargetID: "session"},
+	} {
+		if err := cols.AuditEvents.Create(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		if e.ID == "" || e.Created == 0 {
+			t.Fatal("create should set ID and created")
+		}
+	}
+	now := time.Now().Unix()
+
+	t.Run("test list by scope", func(t *testing.T) {
+		list, err := cols.AuditEvents.List(ctx, "team", 0, 0)
+		if err != nil {
+			t.Fatalf("list should succeed: %v", err)
+		}
+		if len(list) != 2 {
+			t.Fatalf("got %d events, expected 2", len(list))
+		}
+		for _, e := range list {
+			if e.Scope != "team" || e.ActorID != "jon" || e.TargetID != "team" {
+				t.Fatalf("got bad event %v", e)
+			}
+		}
+	})
+
+	t.Run("test list by time range", func(t *testing.T) {
+		list, err := cols.AuditEvents.List(ctx, "team", now+1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != 0 {
+			t.Fatal("events before since should be excluded")
+		}
+		list, err = cols.AuditEvents.List(ctx, "team", now-60, now+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != 2 {
+			t.Fatal("events in range should be included")
+		}
+		list, err = cols.AuditEvents.List(ctx, "team", 0, now-60)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != 0 {
+			t.Fatal("events at or after until should be excluded")
+		}
+	})
+}
+
+func TestBackend_Backup(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols, done := setup(t)
+	defer done()
+
+	user, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := cols.Teams.Create(ctx, user.ID, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cols.Users.JoinTeam(ctx, user, team.ID); err != nil {
+		t.Fatal(err)
+	}
+	proj, err := cols.Projects.Create(ctx, "bar", user.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := cols.AppTokens.Create(ctx, proj.ID, []string{"https://foo.com", "https://bar.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err = cols.Backup(ctx, &buf); err != nil {
+		t.Fatalf("backup should succeed: %v", err)
+	}
+
+	t.Run("test restore into non-empty tables", func(t *testing.T) {
+		if _, err := cols.Restore(ctx, bytes.NewReader(buf.Bytes())); err == nil {
+			t.Fatal("restore into non-empty tables should fail")
+		}
+	})
+
+	t.Run("test restore duplicate user email", func(t *testing.T) {
+		manifest, models, err := c.ReadArchive(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dup := *user
+		dup.ID = uuid.New().String()
+		dup.Username = ""
+		if models[userModel], err = json.Marshal([]*c.User{user, &dup}); err != nil {
+			t.Fatal(err)
+		}
+		var dupBuf bytes.Buffer
+		if err = c.WriteArchive(&dupBuf, manifest, models); err != nil {
+			t.Fatal(err)
+		}
+		fresh, done := setup(t)
+		defer done()
+		if _, err = fresh.Restore(ctx, &dupBuf); !errors.Is(err, c.ErrUserExists) {
+			t.Fatalf("expected ErrUserExists, got %v", err)
+		}
+	})
+
+	t.Run("test restore", func(t *testing.T) {
+		fresh, done := setup(t)
+		defer done()
+		manifest, err := fresh.Restore(ctx, bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("restore should succeed: %v", err)
+		}
+		if manifest.Counts["User"] != 1 || manifest.Counts["Project"] != 1 {
+			t.Fatal("got bad manifest counts")
+		}
+		got, err := fresh.Users.Get(ctx, user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Email != user.Email || !got.HasTeam(team.ID) {
+			t.Fatal("got bad restored user")
+		}
+		gotProj, err := fresh.Projects.Get(ctx, proj.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotProj.StoreID != proj.StoreID {
+			t.Fatal("got bad restored project")
+		}
+		gotToken, err := fresh.AppTokens.Get(ctx, token.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotToken.ProjectID != proj.ID || !reflect.DeepEqual(gotToken.AllowedOrigins, token.AllowedOrigins) {
+			t.Fatal("got bad restored app token")
+		}
+	})
+}
+
+func TestBackend_Stats(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols, done := setup(t)
+	defer done()
+
+	user, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cols.Projects.Create(ctx, "foo", user.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cols.Sessions.Create(ctx, user.ID, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	expired, err := cols.Sessions.Create(ctx, user.ID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired.Expiry = 0
+	if err = cols.Sessions.SwitchScope(ctx, expired, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := cols.Stats(ctx)
+	if err != nil {
+		t.Fatalf("stats should succeed: %v", err)
+	}
+	if stats.Users != 1 || stats.Projects != 1 || stats.AppUsers != 0 || stats.ActiveSessions != 1 {
+		t.Fatalf("got bad stats %+v", stats)
+	}
+}
+
+func setup(t *testing.T) (*c.Collections, func()) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each connection would get its own in-memory database.
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+	cols, err := NewCollections(ctx, db, &stores{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cols.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return cols, func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// stores fakes threads store creation.
+type stores struct{}
+
+func (s *stores) NewStore(context.Context) (string, error) {
+	return uuid.New().String(), nil
+}
+
+func (s *stores) Start(context.Context, string) error {
+	return nil
+}
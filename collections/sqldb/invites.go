@@ -0,0 +1,66 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Invites struct {
+	db *sql.DB
+}
+
+func (i *Invites) Create(ctx context.Context, teamID, fromID, toEmail string) (*c.Invite, error) {
+	invite := &c.Invite{
+		ID:      newID(),
+		TeamID:  teamID,
+		FromID:  fromID,
+		ToEmail: toEmail,
+		Expiry:  int(time.Now().Add(c.InviteDuration).Unix()),
+	}
+	if err := i.insert(ctx, i.db, invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (i *Invites) insert(ctx context.Context, q querier, invite *c.Invite) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO invites (id, team_id, from_id, to_email, expiry) VALUES ($1, $2, $3, $4, $5)`,
+		invite.ID, invite.TeamID, invite.FromID, invite.ToEmail, invite.Expiry)
+	return err
+}
+
+func (i *Invites) Get(ctx context.Context, id string) (*c.Invite, error) {
+	invite := &c.Invite{}
+	if err := i.db.QueryRowContext(ctx,
+		`SELECT id, team_id, from_id, to_email, expiry FROM invites WHERE id = $1`, id).Scan(
+		&invite.ID, &invite.TeamID, &invite.FromID, &invite.ToEmail, &invite.Expiry); err != nil {
+		return nil, notFound(err)
+	}
+	return invite, nil
+}
+
+func (i *Invites) list(ctx context.Context) ([]*c.Invite, error) {
+	rows, err := i.db.QueryContext(ctx, `SELECT id, team_id, from_id, to_email, expiry FROM invites`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var invites []*c.Invite
+	for rows.Next() {
+		invite := &c.Invite{}
+		if err = rows.Scan(&invite.ID, &invite.TeamID, &invite.FromID, &invite.ToEmail, &invite.Expiry); err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+	return invites, rows.Err()
+}
+
+func (i *Invites) Delete(ctx context.Context, id string) error {
+	_, err := i.db.ExecContext(ctx, `DELETE FROM invites WHERE id = $1`, id)
+	return err
+}
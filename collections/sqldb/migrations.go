@@ -0,0 +1,260 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	c "github.com/textileio/textile/collections"
+)
+
+// migration upgrades the database from the previous schema version.
+type migration struct {
+	c.Migration
+
+	stmts []string
+}
+
+// migrations are applied in order. Append new migrations to the end,
+// never modify or reorder existing ones.
+var migrations = []migration{
+	{
+		Migration: c.Migration{
+			Version:     1,
+			Description: "Create tables",
+		},
+		stmts: []string{
+			`CREATE TABLE users (
+	id TEXT PRIMARY KEY,
+	email TEXT NOT NULL UNIQUE,
+	created BIGINT NOT NULL
+)`,
+			`CREATE TABLE user_teams (
+	user_id TEXT NOT NULL,
+	team_id TEXT NOT NULL,
+	PRIMARY KEY (user_id, team_id)
+)`,
+			`CREATE INDEX user_teams_team_id ON user_teams (team_id)`,
+			`CREATE TABLE sessions (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	scope TEXT NOT NULL,
+	expiry BIGINT NOT NULL
+)`,
+			`CREATE INDEX sessions_user_id ON sessions (user_id)`,
+			`CREATE TABLE teams (
+	id TEXT PRIMARY KEY,
+	owner_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	created BIGINT NOT NULL
+)`,
+			`CREATE INDEX teams_owner_id ON teams (owner_id)`,
+			`CREATE TABLE invites (
+	id TEXT PRIMARY KEY,
+	team_id TEXT NOT NULL,
+	from_id TEXT NOT NULL,
+	to_email TEXT NOT NULL,
+	expiry BIGINT NOT NULL
+)`,
+			`CREATE TABLE projects (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	scope TEXT NOT NULL,
+	store_id TEXT NOT NULL,
+	wallet_address TEXT NOT NULL,
+	created BIGINT NOT NULL
+)`,
+			`CREATE INDEX projects_scope ON projects (scope)`,
+			`CREATE TABLE app_tokens (
+	id TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL
+)`,
+			`CREATE INDEX app_tokens_project_id ON app_tokens (project_id)`,
+			`CREATE TABLE app_users (
+	id TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL,
+	store_id TEXT NOT NULL,
+	created BIGINT NOT NULL
+)`,
+			`CREATE INDEX app_users_project_id ON app_users (project_id)`,
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     2,
+			Description: "Add allowed origins to app tokens",
+		},
+		stmts: []string{
+			`ALTER TABLE app_tokens ADD COLUMN allowed_origins TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     3,
+			Description: "Add description, website, and avatar to teams",
+		},
+		stmts: []string{
+			`ALTER TABLE teams ADD COLUMN description TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE teams ADD COLUMN website TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE teams ADD COLUMN avatar TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     4,
+			Description: "Add name and username to users",
+		},
+		stmts: []string{
+			`ALTER TABLE users ADD COLUMN name TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN username TEXT NOT NULL DEFAULT ''`,
+			`CREATE UNIQUE INDEX users_username ON users (username) WHERE username <> ''`,
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     5,
+			Description: "Create audit events table",
+		},
+		stmts: []string{
+			`CREATE TABLE audit_events (
+	id TEXT PRIMARY KEY,
+	actor_id TEXT NOT NULL,
+	scope TEXT NOT NULL,
+	action TEXT NOT NULL,
+	target_id TEXT NOT NULL,
+	ip TEXT NOT NULL,
+	created BIGINT NOT NULL
+)`,
+			`CREATE INDEX audit_events_scope_created ON audit_events (scope, created)`,
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     6,
+			Description: "Create webhooks and webhook deliveries tables",
+		},
+		stmts: []string{
+			`CREATE TABLE webhooks (
+	id TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL,
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	events TEXT NOT NULL,
+	created BIGINT NOT NULL
+)`,
+			`CREATE INDEX webhooks_project_id ON webhooks (project_id)`,
+			`CREATE TABLE webhook_deliveries (
+	id TEXT PRIMARY KEY,
+	webhook_id TEXT NOT NULL,
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	event TEXT NOT NULL,
+	data TEXT NOT NULL,
+	attempts INTEGER NOT NULL,
+	next_attempt BIGINT NOT NULL,
+	last_error TEXT NOT NULL,
+	created BIGINT NOT NULL
+)`,
+			`CREATE INDEX webhook_deliveries_next_attempt ON webhook_deliveries (next_attempt)`,
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     7,
+			Description: "Make team names unique per owner",
+		},
+		stmts: []string{
+			// Rename all but the oldest of each owner's teams with the same name.
+			`UPDATE teams SET name = name || ' (' || id || ')' WHERE EXISTS (
+	SELECT 1 FROM teams t WHERE t.owner_id = teams.owner_id AND lower(t.name) = lower(teams.name)
+	AND (t.created < teams.created OR (t.created = teams.created AND t.id < teams.id))
+)`,
+			`CREATE UNIQUE INDEX teams_owner_id_name ON teams (owner_id, lower(name))`,
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     8,
+			Description: "Make user emails unique case-insensitively",
+		},
+		stmts: []string{
+			// Emails are stored normalized, so this only guards against writes that skip normalization.
+			`CREATE UNIQUE INDEX users_email_normalized ON users (lower(email))`,
+		},
+	},
+}
+
+// latestSchemaVersion returns the version reached after all migrations have run.
+func latestSchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the current schema version.
+func (b *backend) SchemaVersion() (int, error) {
+	var version int
+	if err := b.db.QueryRow(`SELECT version FROM schema_version`).Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return version, nil
+}
+
+// PendingMigrations returns the migrations that have not been applied yet.
+func (b *backend) PendingMigrations() ([]c.Migration, error) {
+	pending, err := b.pendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]c.Migration, len(pending))
+	for i, m := range pending {
+		res[i] = m.Migration
+	}
+	return res, nil
+}
+
+func (b *backend) pendingMigrations() ([]migration, error) {
+	version, err := b.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	var pending []migration
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies pending migrations in order and returns the ones that were applied.
+// Each migration runs in a transaction together with the schema version update.
+func (b *backend) Migrate(ctx context.Context) ([]c.Migration, error) {
+	pending, err := b.pendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+	var applied []c.Migration
+	for _, m := range pending {
+		log.Infof("running migration %d: %s", m.Version, m.Description)
+		if err := withTx(ctx, b.db, func(tx *sql.Tx) error {
+			for _, stmt := range m.stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM schema_version`); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO schema_version (version) VALUES ($1)`, m.Version)
+			return err
+		}); err != nil {
+			return applied, err
+		}
+		applied = append(applied, m.Migration)
+	}
+	return applied, nil
+}
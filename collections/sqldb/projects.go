@@ -0,0 +1,101 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Projects struct {
+	db     *sql.DB
+	stores c.StoreCreator
+	token  string
+}
+
+func (p *Projects) Create(ctx context.Context, name, scope, fcWalletAddress string) (*c.Project, error) {
+	ctx = c.AuthCtx(ctx, p.token)
+	proj := &c.Project{
+		ID:            newID(),
+		Name:          name,
+		Scope:         scope,
+		WalletAddress: fcWalletAddress,
+		Created:       time.Now().Unix(),
+	}
+	// Create a dedicated store for the project
+	var err error
+	proj.StoreID, err = p.stores.NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.insert(ctx, p.db, proj); err != nil {
+		return nil, err
+	}
+	if err = p.stores.Start(ctx, proj.StoreID); err != nil {
+		return nil, err
+	}
+	return proj, nil
+}
+
+func (p *Projects) insert(ctx context.Context, q querier, proj *c.Project) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO projects (id, name, scope, store_id, wallet_address, created) VALUES ($1, $2, $3, $4, $5, $6)`,
+		proj.ID, proj.Name, proj.Scope, proj.StoreID, proj.WalletAddress, proj.Created)
+	return err
+}
+
+func (p *Projects) Get(ctx context.Context, id string) (*c.Project, error) {
+	proj := &c.Project{}
+	if err := p.db.QueryRowContext(ctx,
+		`SELECT id, name, scope, store_id, wallet_address, created FROM projects WHERE id = $1`, id).Scan(
+		&proj.ID, &proj.Name, &proj.Scope, &proj.StoreID, &proj.WalletAddress, &proj.Created); err != nil {
+		return nil, notFound(err)
+	}
+	return proj, nil
+}
+
+func (p *Projects) List(ctx context.Context, scope string) ([]*c.Project, error) {
+	return p.list(ctx, `SELECT id, name, scope, store_id, wallet_address, created FROM projects
+WHERE scope = $1 ORDER BY created`, scope)
+}
+
+func (p *Projects) list(ctx context.Context, query string, args ...interface{}) ([]*c.Project, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var projs []*c.Project
+	for rows.Next() {
+		proj := &c.Project{}
+		if err = rows.Scan(&proj.ID, &proj.Name, &proj.Scope, &proj.StoreID, &proj.WalletAddress, &proj.Created); err != nil {
+			return nil, err
+		}
+		projs = append(projs, proj)
+	}
+	return projs, rows.Err()
+}
+
+func (p *Projects) SwitchScope(ctx context.Context, proj *c.Project, scope string) error {
+	if _, err := p.db.ExecContext(ctx,
+		`UPDATE projects SET scope = $1 WHERE id = $2`, scope, proj.ID); err != nil {
+		return err
+	}
+	proj.Scope = scope
+	return nil
+}
+
+func (p *Projects) Rename(ctx context.Context, proj *c.Project, name string) error {
+	if _, err := p.db.ExecContext(ctx,
+		`UPDATE projects SET name = $1 WHERE id = $2`, name, proj.ID); err != nil {
+		return err
+	}
+	proj.Name = name
+	return nil
+}
+
+func (p *Projects) Delete(ctx context.Context, id string) error {
+	_, err := p.db.ExecContext(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	return err
+}
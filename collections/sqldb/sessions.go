@@ -0,0 +1,91 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+type Sessions struct {
+	db *sql.DB
+}
+
+func (s *Sessions) Create(ctx context.Context, userID, scope string) (*c.Session, error) {
+	session := &c.Session{
+		ID:     newID(),
+		UserID: userID,
+		Scope:  scope,
+		Expiry: int(time.Now().Add(c.SessionDuration).Unix()),
+	}
+	if err := s.insert(ctx, s.db, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *Sessions) insert(ctx context.Context, q querier, session *c.Session) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO sessions (id, user_id, scope, expiry) VALUES ($1, $2, $3, $4)`,
+		session.ID, session.UserID, session.Scope, session.Expiry)
+	return err
+}
+
+func (s *Sessions) Get(ctx context.Context, id string) (*c.Session, error) {
+	session := &c.Session{}
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT id, user_id, scope, expiry FROM sessions WHERE id = $1`, id).Scan(
+		&session.ID, &session.UserID, &session.Scope, &session.Expiry); err != nil {
+		return nil, notFound(err)
+	}
+	return session, nil
+}
+
+func (s *Sessions) ListByUser(ctx context.Context, userID string) ([]*c.Session, error) {
+	return s.list(ctx, `SELECT id, user_id, scope, expiry FROM sessions WHERE user_id = $1`, userID)
+}
+
+func (s *Sessions) list(ctx context.Context, query string, args ...interface{}) ([]*c.Session, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var sessions []*c.Session
+	for rows.Next() {
+		session := &c.Session{}
+		if err = rows.Scan(&session.ID, &session.UserID, &session.Scope, &session.Expiry); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func (s *Sessions) Touch(ctx context.Context, session *c.Session) error {
+	session.Expiry = int(time.Now().Add(c.SessionDuration).Unix())
+	return s.save(ctx, session)
+}
+
+func (s *Sessions) SwitchScope(ctx context.Context, session *c.Session, scope string) error {
+	session.Scope = scope
+	return s.save(ctx, session)
+}
+
+func (s *Sessions) SwitchUser(ctx context.Context, session *c.Session, userID string) error {
+	session.UserID = userID
+	return s.save(ctx, session)
+}
+
+func (s *Sessions) save(ctx context.Context, session *c.Session) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE sessions SET user_id = $1, scope = $2, expiry = $3 WHERE id = $4`,
+		session.UserID, session.Scope, session.Expiry, session.ID)
+	return err
+}
+
+func (s *Sessions) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = $1`, id)
+	return err
+}
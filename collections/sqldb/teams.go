@@ -0,0 +1,123 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+const teamColumns = `id, owner_id, name, description, website, avatar, created`
+
+type Teams struct {
+	db *sql.DB
+}
+
+// Create a new team.
+// Returns c.ErrTeamExists if the owner already has a team with the name.
+func (t *Teams) Create(ctx context.Context, ownerID, name string) (*c.Team, error) {
+	team := &c.Team{
+		ID:      newID(),
+		OwnerID: ownerID,
+		Name:    name,
+		Created: time.Now().Unix(),
+	}
+	created, err := t.insert(ctx, t.db, team)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, c.ErrTeamExists
+	}
+	return team, nil
+}
+
+// insert a team. Returns false if the owner already has a team with the name.
+func (t *Teams) insert(ctx context.Context, q querier, team *c.Team) (bool, error) {
+	res, err := q.ExecContext(ctx,
+		`INSERT INTO teams (id, owner_id, name, description, website, avatar, created)
+VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`,
+		team.ID, team.OwnerID, team.Name, team.Description, team.Website, team.Avatar, team.Created)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (t *Teams) Get(ctx context.Context, id string) (*c.Team, error) {
+	team := &c.Team{}
+	if err := t.db.QueryRowContext(ctx,
+		`SELECT `+teamColumns+` FROM teams WHERE id = $1`, id).Scan(
+		&team.ID, &team.OwnerID, &team.Name, &team.Description, &team.Website, &team.Avatar,
+		&team.Created); err != nil {
+		return nil, notFound(err)
+	}
+	return team, nil
+}
+
+func (t *Teams) ListByOwner(ctx context.Context, ownerID string) ([]*c.Team, error) {
+	return t.list(ctx, `SELECT `+teamColumns+` FROM teams WHERE owner_id = $1 ORDER BY created`, ownerID)
+}
+
+func (t *Teams) list(ctx context.Context, query string, args ...interface{}) ([]*c.Team, error) {
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var teams []*c.Team
+	for rows.Next() {
+		team := &c.Team{}
+		if err = rows.Scan(&team.ID, &team.OwnerID, &team.Name, &team.Description, &team.Website, &team.Avatar,
+			&team.Created); err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, rows.Err()
+}
+
+// SwitchOwner transfers the team to ownerID.
+// Returns c.ErrTeamExists if the new owner already has a team with the name.
+func (t *Teams) SwitchOwner(ctx context.Context, team *c.Team, ownerID string) error {
+	if _, err := t.db.ExecContext(ctx,
+		`UPDATE teams SET owner_id = $1 WHERE id = $2`, ownerID, team.ID); err != nil {
+		return t.nameConflict(ctx, err, ownerID, team.Name, team.ID)
+	}
+	team.OwnerID = ownerID
+	return nil
+}
+
+// Update saves the team.
+// Returns c.ErrTeamExists if the owner already has another team with the name.
+func (t *Teams) Update(ctx context.Context, team *c.Team) error {
+	if _, err := t.db.ExecContext(ctx,
+		`UPDATE teams SET name = $1, description = $2, website = $3, avatar = $4 WHERE id = $5`,
+		team.Name, team.Description, team.Website, team.Avatar, team.ID); err != nil {
+		return t.nameConflict(ctx, err, team.OwnerID, team.Name, team.ID)
+	}
+	return nil
+}
+
+// nameConflict returns c.ErrTeamExists if err was caused by the unique team name
+// index, i.e., another team of the owner has name. Otherwise, err is returned.
+// Drivers report constraint violations differently, so this checks the table.
+func (t *Teams) nameConflict(ctx context.Context, err error, ownerID, name, teamID string) error {
+	var exists bool
+	if qerr := t.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM teams WHERE owner_id = $1 AND lower(name) = lower($2) AND id <> $3)`,
+		ownerID, name, teamID).Scan(&exists); qerr == nil && exists {
+		return c.ErrTeamExists
+	}
+	return err
+}
+
+func (t *Teams) Delete(ctx context.Context, id string) error {
+	_, err := t.db.ExecContext(ctx, `DELETE FROM teams WHERE id = $1`, id)
+	return err
+}
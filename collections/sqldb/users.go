@@ -0,0 +1,259 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+const userColumns = `id, email, name, username, created`
+
+type Users struct {
+	db *sql.DB
+}
+
+// Create a new user with the given email.
+// Returns c.ErrUserExists if a user with the normalized email already exists.
+func (u *Users) Create(ctx context.Context, email string) (*c.User, error) {
+	user := &c.User{
+		ID:      newID(),
+		Email:   c.NormalizeEmail(email),
+		Teams:   []string{},
+		Created: time.Now().Unix(),
+	}
+	created, err := u.insert(ctx, u.db, user)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, c.ErrUserExists
+	}
+	return user, nil
+}
+
+// GetOrCreate returns the user with the given email, creating one if needed.
+// The unique email constraint makes concurrent calls resolve to the same user.
+func (u *Users) GetOrCreate(ctx context.Context, email string) (*c.User, error) {
+	email = c.NormalizeEmail(email)
+	if _, err := u.insert(ctx, u.db, &c.User{
+		ID:      newID(),
+		Email:   email,
+		Created: time.Now().Unix(),
+	}); err != nil {
+		return nil, err
+	}
+	return u.GetByEmail(ctx, email)
+}
+
+// insert a user and its team memberships. Returns false if the email is taken.
+func (u *Users) insert(ctx context.Context, q querier, user *c.User) (bool, error) {
+	res, err := q.ExecContext(ctx,
+		`INSERT INTO users (id, email, name, username, created) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (email) DO NOTHING`,
+		user.ID, user.Email, user.Name, user.Username, user.Created)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+	for _, t := range user.Teams {
+		if err = u.addTeam(ctx, q, user.ID, t); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (u *Users) Get(ctx context.Context, id string) (*c.User, error) {
+	return u.get(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+}
+
+// GetByEmail returns the user with the given email.
+func (u *Users) GetByEmail(ctx context.Context, email string) (*c.User, error) {
+	return u.get(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1`, c.NormalizeEmail(email))
+}
+
+// GetByUsername returns the user with the given username.
+func (u *Users) GetByUsername(ctx context.Context, username string) (*c.User, error) {
+	username = c.NormalizeUsername(username)
+	if username == "" {
+		return nil, c.ErrNotFound
+	}
+	return u.get(ctx, `SELECT `+userColumns+` FROM users WHERE username = $1`, username)
+}
+
+func (u *Users) get(ctx context.Context, query string, args ...interface{}) (*c.User, error) {
+	user := &c.User{}
+	if err := u.db.QueryRowContext(ctx, query, args...).Scan(
+		&user.ID, &user.Email, &user.Name, &user.Username, &user.Created); err != nil {
+		return nil, notFound(err)
+	}
+	var err error
+	user.Teams, err = u.listTeams(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// List returns all users.
+func (u *Users) List(ctx context.Context) ([]*c.User, error) {
+	return u.list(ctx, `SELECT `+userColumns+` FROM users ORDER BY created`)
+}
+
+func (u *Users) ListByTeam(ctx context.Context, teamID string) ([]*c.User, error) {
+	return u.list(ctx, `SELECT u.id, u.email, u.name, u.username, u.created FROM users u
+JOIN user_teams t ON t.user_id = u.id WHERE t.team_id = $1 ORDER BY u.created`, teamID)
+}
+
+func (u *Users) list(ctx context.Context, query string, args ...interface{}) ([]*c.User, error) {
+	rows, err := u.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var users []*c.User
+	for rows.Next() {
+		user := &c.User{}
+		if err = rows.Scan(&user.ID, &user.Email, &user.Name, &user.Username, &user.Created); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		user.Teams, err = u.listTeams(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+func (u *Users) listTeams(ctx context.Context, userID string) ([]string, error) {
+	rows, err := u.db.QueryContext(ctx,
+		`SELECT team_id FROM user_teams WHERE user_id = $1 ORDER BY team_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	teams := []string{}
+	for rows.Next() {
+		var t string
+		if err = rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		teams = append(teams, t)
+	}
+	return teams, rows.Err()
+}
+
+func (u *Users) addTeam(ctx context.Context, q querier, userID, teamID string) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO user_teams (user_id, team_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		userID, teamID)
+	return err
+}
+
+func (u *Users) JoinTeam(ctx context.Context, user *c.User, teamID string) error {
+	if user.HasTeam(teamID) {
+		return nil
+	}
+	if err := u.addTeam(ctx, u.db, user.ID, teamID); err != nil {
+		return err
+	}
+	user.Teams = append(user.Teams, teamID)
+	return nil
+}
+
+func (u *Users) LeaveTeam(ctx context.Context, user *c.User, teamID string) error {
+	if _, err := u.db.ExecContext(ctx,
+		`DELETE FROM user_teams WHERE user_id = $1 AND team_id = $2`, user.ID, teamID); err != nil {
+		return err
+	}
+	n := 0
+	for _, x := range user.Teams {
+		if x != teamID {
+			user.Teams[n] = x
+			n++
+		}
+	}
+	user.Teams = user.Teams[:n]
+	return nil
+}
+
+// Save an existing user.
+// Returns c.ErrUserExists or c.ErrUsernameExists if another user has the email or username.
+func (u *Users) Save(ctx context.Context, user *c.User) error {
+	var conflict error
+	if err := withTx(ctx, u.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE users SET email = $1, name = $2, username = $3, created = $4 WHERE id = $5`,
+			user.Email, user.Name, user.Username, user.Created, user.ID)
+		if err != nil {
+			conflict = err
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return c.ErrNotFound
+		}
+		if _, err = tx.ExecContext(ctx, `DELETE FROM user_teams WHERE user_id = $1`, user.ID); err != nil {
+			return err
+		}
+		for _, t := range user.Teams {
+			if err = u.addTeam(ctx, tx, user.ID, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		if conflict != nil {
+			// Check the table once the failed transaction is rolled back.
+			return u.conflict(ctx, conflict, user)
+		}
+		return err
+	}
+	return nil
+}
+
+// conflict returns c.ErrUserExists or c.ErrUsernameExists if err was caused by
+// the unique email or username index. Otherwise, err is returned.
+// Drivers report constraint violations differently, so this checks the table.
+func (u *Users) conflict(ctx context.Context, err error, user *c.User) error {
+	var email, username bool
+	if qerr := u.db.QueryRowContext(ctx, `SELECT
+EXISTS (SELECT 1 FROM users WHERE email = $1 AND id <> $2),
+EXISTS (SELECT 1 FROM users WHERE username = $3 AND username <> '' AND id <> $4)`,
+		user.Email, user.ID, user.Username, user.ID).Scan(&email, &username); qerr != nil {
+		return err
+	}
+	if email {
+		return c.ErrUserExists
+	}
+	if username {
+		return c.ErrUsernameExists
+	}
+	return err
+}
+
+// @todo: Add a destroy method that calls this. User must first delete projects and teams they own.
+func (u *Users) Delete(ctx context.Context, id string) error {
+	return withTx(ctx, u.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM user_teams WHERE user_id = $1`, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+		return err
+	})
+}
@@ -0,0 +1,141 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+const (
+	webhookColumns         = `id, project_id, url, secret, events, created`
+	webhookDeliveryColumns = `id, webhook_id, url, secret, event, data, attempts, next_attempt, last_error, created`
+)
+
+type Webhooks struct {
+	db *sql.DB
+}
+
+func (w *Webhooks) Create(ctx context.Context, projectID, url, secret string, events []string) (*c.Webhook, error) {
+	hook := &c.Webhook{
+		ID:        newID(),
+		ProjectID: projectID,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Created:   time.Now().Unix(),
+	}
+	if err := w.insert(ctx, w.db, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (w *Webhooks) insert(ctx context.Context, q querier, hook *c.Webhook) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO webhooks (`+webhookColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+		hook.ID, hook.ProjectID, hook.URL, hook.Secret, strings.Join(hook.Events, " "), hook.Created)
+	return err
+}
+
+func (w *Webhooks) Get(ctx context.Context, id string) (*c.Webhook, error) {
+	hook := &c.Webhook{}
+	var events string
+	if err := w.db.QueryRowContext(ctx,
+		`SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id).Scan(
+		&hook.ID, &hook.ProjectID, &hook.URL, &hook.Secret, &events, &hook.Created); err != nil {
+		return nil, notFound(err)
+	}
+	hook.Events = strings.Fields(events)
+	return hook, nil
+}
+
+func (w *Webhooks) List(ctx context.Context, projectID string) ([]*c.Webhook, error) {
+	return w.list(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE project_id = $1`, projectID)
+}
+
+func (w *Webhooks) list(ctx context.Context, query string, args ...interface{}) ([]*c.Webhook, error) {
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var hooks []*c.Webhook
+	for rows.Next() {
+		hook := &c.Webhook{}
+		var events string
+		if err = rows.Scan(&hook.ID, &hook.ProjectID, &hook.URL, &hook.Secret, &events, &hook.Created); err != nil {
+			return nil, err
+		}
+		hook.Events = strings.Fields(events)
+		hooks = append(hooks, hook)
+	}
+	return hooks, rows.Err()
+}
+
+func (w *Webhooks) Delete(ctx context.Context, id string) error {
+	_, err := w.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	return err
+}
+
+type WebhookDeliveries struct {
+	db *sql.DB
+}
+
+func (w *WebhookDeliveries) Create(ctx context.Context, d *c.WebhookDelivery) error {
+	d.ID = newID()
+	d.Created = time.Now().Unix()
+	return w.insert(ctx, w.db, d)
+}
+
+func (w *WebhookDeliveries) insert(ctx context.Context, q querier, d *c.WebhookDelivery) error {
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (`+webhookDeliveryColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		d.ID, d.WebhookID, d.URL, d.Secret, d.Event, d.Data, d.Attempts, d.NextAttempt, d.LastError, d.Created)
+	return err
+}
+
+func (w *WebhookDeliveries) ListDue(ctx context.Context, now int64) ([]*c.WebhookDelivery, error) {
+	return w.list(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE next_attempt <= $1
+ORDER BY next_attempt`, now)
+}
+
+func (w *WebhookDeliveries) list(ctx context.Context, query string, args ...interface{}) ([]*c.WebhookDelivery, error) {
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []*c.WebhookDelivery
+	for rows.Next() {
+		d := &c.WebhookDelivery{}
+		if err = rows.Scan(&d.ID, &d.WebhookID, &d.URL, &d.Secret, &d.Event, &d.Data, &d.Attempts,
+			&d.NextAttempt, &d.LastError, &d.Created); err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func (w *WebhookDeliveries) Save(ctx context.Context, d *c.WebhookDelivery) error {
+	res, err := w.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET attempts = $1, next_attempt = $2, last_error = $3 WHERE id = $4`,
+		d.Attempts, d.NextAttempt, d.LastError, d.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return c.ErrNotFound
+	}
+	return nil
+}
+
+func (w *WebhookDeliveries) Delete(ctx context.Context, id string) error {
+	_, err := w.db.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE id = $1`, id)
+	return err
+}
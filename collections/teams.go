@@ -2,60 +2,48 @@ package collections
 
 import (
 	"context"
-	"time"
+	"errors"
+	"strings"
+)
 
-	"github.com/google/uuid"
-	"github.com/textileio/go-threads/api/client"
+var (
+	// ErrTeamExists indicates the owner already has a team with the given name.
+	ErrTeamExists = errors.New("team with name already exists")
 )
 
 type Team struct {
-	ID      string
-	OwnerID string
-	Name    string
-	Created int64
-}
-
-type Teams struct {
-	threads *client.Client
-	storeID *uuid.UUID
-	token   string
-}
-
-func (t *Teams) GetName() string {
-	return "Team"
-}
-
-func (t *Teams) GetInstance() interface{} {
-	return &Team{}
-}
-
-func (t *Teams) GetStoreID() *uuid.UUID {
-	return t.storeID
-}
-
-func (t *Teams) Create(ctx context.Context, ownerID, name string) (*Team, error) {
-	ctx = AuthCtx(ctx, t.token)
-	team := &Team{
-		OwnerID: ownerID,
-		Name:    name,
-		Created: time.Now().Unix(),
-	}
-	if err := t.threads.ModelCreate(ctx, t.storeID.String(), t.GetName(), team); err != nil {
-		return nil, err
-	}
-	return team, nil
-}
-
-func (t *Teams) Get(ctx context.Context, id string) (*Team, error) {
-	ctx = AuthCtx(ctx, t.token)
-	team := &Team{}
-	if err := t.threads.ModelFindByID(ctx, t.storeID.String(), t.GetName(), id, team); err != nil {
-		return nil, err
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	Website     string
+	Avatar      string // CID of the avatar image in IPFS
+	Created     int64
+}
+
+// Team names are unique per owner, compared case-insensitively.
+type Teams interface {
+	// Create a new team.
+	// Returns ErrTeamExists if the owner already has a team with the name.
+	Create(ctx context.Context, ownerID, name string) (*Team, error)
+	Get(ctx context.Context, id string) (*Team, error)
+	ListByOwner(ctx context.Context, ownerID string) ([]*Team, error)
+	// SwitchOwner transfers the team to ownerID.
+	// Returns ErrTeamExists if the new owner already has a team with the name.
+	SwitchOwner(ctx context.Context, team *Team, ownerID string) error
+	// Update saves the team's name, description, website, and avatar.
+	// Returns ErrTeamExists if the owner already has another team with the name.
+	Update(ctx context.Context, team *Team) error
+	Delete(ctx context.Context, id string) error
+}
+
+// HasTeamName returns whether or not a team other than teamID has name.
+// Names are compared case-insensitively.
+func HasTeamName(teams []*Team, name, teamID string) bool {
+	for _, t := range teams {
+		if t.ID != teamID && strings.EqualFold(t.Name, name) {
+			return true
+		}
 	}
-	return team, nil
-}
-
-func (t *Teams) Delete(ctx context.Context, id string) error {
-	ctx = AuthCtx(ctx, t.token)
-	return t.threads.ModelDelete(ctx, t.storeID.String(), t.GetName(), id)
+	return false
 }
@@ -0,0 +1,67 @@
+package threads
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type AppTokens struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (a *AppTokens) GetName() string {
+	return "AppToken"
+}
+
+func (a *AppTokens) GetInstance() interface{} {
+	return &c.AppToken{}
+}
+
+func (a *AppTokens) GetStoreID() *uuid.UUID {
+	return a.storeID
+}
+
+func (a *AppTokens) Create(ctx context.Context, projectID string, allowedOrigins []string) (*c.AppToken, error) {
+	ctx = c.AuthCtx(ctx, a.token)
+	if allowedOrigins == nil {
+		allowedOrigins = []string{} // null doesn't validate against the array schema
+	}
+	token := &c.AppToken{
+		ProjectID:      projectID,
+		AllowedOrigins: allowedOrigins,
+	}
+	if err := a.threads.ModelCreate(ctx, a.storeID.String(), a.GetName(), token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (a *AppTokens) Get(ctx context.Context, id string) (*c.AppToken, error) {
+	ctx = c.AuthCtx(ctx, a.token)
+	token := &c.AppToken{}
+	if err := a.threads.ModelFindByID(ctx, a.storeID.String(), a.GetName(), id, token); err != nil {
+		return nil, notFound(err)
+	}
+	return token, nil
+}
+
+func (a *AppTokens) List(ctx context.Context, projectID string) ([]*c.AppToken, error) {
+	ctx = c.AuthCtx(ctx, a.token)
+	query := s.JSONWhere("ProjectID").Eq(projectID)
+	res, err := a.threads.ModelFind(ctx, a.storeID.String(), a.GetName(), query, []*c.AppToken{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.AppToken), nil
+}
+
+func (a *AppTokens) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, a.token)
+	return a.threads.ModelDelete(ctx, a.storeID.String(), a.GetName(), id)
+}
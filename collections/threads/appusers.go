@@ -0,0 +1,82 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type AppUsers struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (u *AppUsers) GetName() string {
+	return "AppUser"
+}
+
+func (u *AppUsers) GetInstance() interface{} {
+	return &c.AppUser{}
+}
+
+func (u *AppUsers) GetStoreID() *uuid.UUID {
+	return u.storeID
+}
+
+func (u *AppUsers) GetOrCreate(ctx context.Context, projectID, deviceID string) (user *c.AppUser, err error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	user, err = u.Get(ctx, deviceID)
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, c.ErrNotFound) {
+		return
+	}
+	user = &c.AppUser{
+		ID:        deviceID,
+		ProjectID: projectID,
+		Created:   time.Now().Unix(),
+	}
+	user.StoreID, err = u.threads.NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = u.threads.ModelCreate(ctx, u.storeID.String(), u.GetName(), user); err != nil {
+		return nil, err
+	}
+	if err = u.threads.Start(ctx, user.StoreID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *AppUsers) Get(ctx context.Context, id string) (*c.AppUser, error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	user := &c.AppUser{}
+	if err := u.threads.ModelFindByID(ctx, u.storeID.String(), u.GetName(), id, user); err != nil {
+		return nil, notFound(err)
+	}
+	return user, nil
+}
+
+func (u *AppUsers) List(ctx context.Context, projectID string) ([]*c.AppUser, error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	query := s.JSONWhere("ProjectID").Eq(projectID)
+	res, err := u.threads.ModelFind(ctx, u.storeID.String(), u.GetName(), query, []*c.AppUser{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.AppUser), nil
+}
+
+// @todo: Delete associated sessions
+func (u *AppUsers) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, u.token)
+	return u.threads.ModelDelete(ctx, u.storeID.String(), u.GetName(), id)
+}
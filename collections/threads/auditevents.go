@@ -0,0 +1,56 @@
+package threads
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	st "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type AuditEvents struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (a *AuditEvents) GetName() string {
+	return "AuditEvent"
+}
+
+func (a *AuditEvents) GetInstance() interface{} {
+	return &c.AuditEvent{}
+}
+
+func (a *AuditEvents) GetStoreID() *uuid.UUID {
+	return a.storeID
+}
+
+func (a *AuditEvents) Create(ctx context.Context, e *c.AuditEvent) error {
+	ctx = c.AuthCtx(ctx, a.token)
+	e.Created = time.Now().Unix()
+	return a.threads.ModelCreate(ctx, a.storeID.String(), a.GetName(), e)
+}
+
+func (a *AuditEvents) List(ctx context.Context, scope string, since, until int64) ([]*c.AuditEvent, error) {
+	ctx = c.AuthCtx(ctx, a.token)
+	query := st.JSONWhere("Scope").Eq(scope)
+	res, err := a.threads.ModelFind(ctx, a.storeID.String(), a.GetName(), query, []*c.AuditEvent{})
+	if err != nil {
+		return nil, err
+	}
+	var list []*c.AuditEvent
+	for _, e := range res.([]*c.AuditEvent) {
+		if e.Created < since || (until > 0 && e.Created >= until) {
+			continue
+		}
+		list = append(list, e)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Created < list[j].Created
+	})
+	return list, nil
+}
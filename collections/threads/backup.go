@@ -0,0 +1,102 @@
+package threads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+)
+
+// backendName identifies archives written by this backend.
+const backendName = "threads"
+
+// Backup writes every collection and the store ID mapping to w as a gzipped tar archive.
+func (b *backend) Backup(ctx context.Context, w io.Writer) (*c.Manifest, error) {
+	ctx = c.AuthCtx(ctx, b.token)
+	version, err := b.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &c.Manifest{
+		Version:       c.BackupVersion,
+		Backend:       backendName,
+		SchemaVersion: version,
+		Created:       time.Now().Unix(),
+		Stores:        make(map[string]string),
+		Counts:        make(map[string]int),
+	}
+
+	models := make(map[string][]byte)
+	for _, e := range b.entries() {
+		instances, err := b.findAll(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(instances)
+		if err != nil {
+			return nil, err
+		}
+		models[e.col.GetName()] = data
+		manifest.Stores[e.key.String()] = e.col.GetStoreID().String()
+		manifest.Counts[e.col.GetName()] = len(instances)
+	}
+	if err = c.WriteArchive(w, manifest, models); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Restore imports a backup archive written by Backup. Collections must be empty,
+// i.e., the repo must be fresh. Instance IDs are preserved, store IDs are not.
+func (b *backend) Restore(ctx context.Context, r io.Reader) (*c.Manifest, error) {
+	ctx = c.AuthCtx(ctx, b.token)
+	manifest, models, err := c.ReadArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	// Archives written before backends were pluggable don't name one.
+	if manifest.Backend != "" && manifest.Backend != backendName {
+		return nil, fmt.Errorf("backup was written by the %s backend", manifest.Backend)
+	}
+	if manifest.SchemaVersion != latestSchemaVersion() {
+		return nil, fmt.Errorf("backup schema version %d does not match current version %d",
+			manifest.SchemaVersion, latestSchemaVersion())
+	}
+
+	entries := b.entries()
+	for _, e := range entries {
+		existing, err := b.findAll(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("%s collection is not empty", e.col.GetName())
+		}
+	}
+	for _, e := range entries {
+		data, ok := models[e.col.GetName()]
+		if !ok {
+			return nil, fmt.Errorf("backup is missing %s instances", e.col.GetName())
+		}
+		var instances []*map[string]interface{}
+		if err = json.Unmarshal(data, &instances); err != nil {
+			return nil, err
+		}
+		if len(instances) == 0 {
+			continue
+		}
+		items := make([]interface{}, len(instances))
+		for i, inst := range instances {
+			items[i] = inst
+		}
+		if err = b.threads.ModelCreate(ctx, e.col.GetStoreID().String(), e.col.GetName(), items...); err != nil {
+			return nil, err
+		}
+		log.Infof("restored %d %s instances from store %s to %s", len(instances), e.col.GetName(),
+			manifest.Stores[e.key.String()], e.col.GetStoreID().String())
+	}
+	return manifest, nil
+}
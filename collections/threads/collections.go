@@ -0,0 +1,204 @@
+package threads
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/ipfs/go-datastore"
+	logging "github.com/ipfs/go-log"
+	"github.com/textileio/go-threads/api/client"
+	st "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	log = logging.Logger("collections")
+
+	dsUsersKey    = datastore.NewKey("/users")
+	dsSessionsKey = datastore.NewKey("/sessions")
+	dsTeamsKey    = datastore.NewKey("/teams")
+	dsInvitesKey  = datastore.NewKey("/invites")
+	dsProjectsKey = datastore.NewKey("/projects")
+
+	dsAppTokensKey = datastore.NewKey("/apptokens")
+	dsAppUsersKey  = datastore.NewKey("/appusers")
+
+	dsAuditEventsKey = datastore.NewKey("/auditevents")
+
+	dsWebhooksKey          = datastore.NewKey("/webhooks")
+	dsWebhookDeliveriesKey = datastore.NewKey("/webhookdeliveries")
+)
+
+type Collection interface {
+	GetName() string
+	GetInstance() interface{}
+	GetStoreID() *uuid.UUID
+}
+
+// backend implements c.Backend for collections stored in threads.
+type backend struct {
+	threads *client.Client
+	token   string
+	ds      datastore.Datastore
+
+	users    *Users
+	sessions *Sessions
+	teams    *Teams
+	invites  *Invites
+	projects *Projects
+
+	appTokens *AppTokens
+	appUsers  *AppUsers
+
+	auditEvents *AuditEvents
+
+	webhooks          *Webhooks
+	webhookDeliveries *WebhookDeliveries
+}
+
+// NewCollections gets or create store instances for active collections.
+// Pending schema migrations are not applied, see Migrate.
+func NewCollections(ctx context.Context, threads *client.Client, token string, ds datastore.Datastore) (*c.Collections, error) {
+	b := &backend{
+		threads: threads,
+		token:   token,
+		ds:      ds,
+
+		users:    &Users{threads: threads, token: token},
+		sessions: &Sessions{threads: threads, token: token},
+		teams:    &Teams{threads: threads, token: token},
+		invites:  &Invites{threads: threads, token: token},
+		projects: &Projects{threads: threads, token: token},
+
+		appTokens: &AppTokens{threads: threads, token: token},
+		appUsers:  &AppUsers{threads: threads, token: token},
+
+		auditEvents: &AuditEvents{threads: threads, token: token},
+
+		webhooks:          &Webhooks{threads: threads, token: token},
+		webhookDeliveries: &WebhookDeliveries{threads: threads, token: token},
+	}
+	ctx = c.AuthCtx(ctx, b.token)
+
+	// A fresh repo gets current schemas and needs no migrations.
+	exists, err := ds.Has(dsUsersKey)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err = b.setSchemaVersion(latestSchemaVersion()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, e := range b.entries() {
+		if *e.storeID, err = b.addCollection(ctx, e.col, e.key); err != nil {
+			return nil, err
+		}
+		log.Debugf("%s store: %s", e.col.GetName(), e.col.GetStoreID().String())
+	}
+
+	return &c.Collections{
+		Backend: b,
+
+		Users:    b.users,
+		Sessions: b.sessions,
+		Teams:    b.teams,
+		Invites:  b.invites,
+		Projects: b.projects,
+
+		AppTokens: b.appTokens,
+		AppUsers:  b.appUsers,
+
+		AuditEvents: b.auditEvents,
+
+		Webhooks:          b.webhooks,
+		WebhookDeliveries: b.webhookDeliveries,
+	}, nil
+}
+
+// Stats returns instance counts for monitoring. Threads can't count, so this
+// loads the counted instances.
+func (b *backend) Stats(ctx context.Context) (*c.Stats, error) {
+	ctx = c.AuthCtx(ctx, b.token)
+	stats := &c.Stats{}
+	for _, m := range []struct {
+		col   Collection
+		count *int
+	}{
+		{col: b.users, count: &stats.Users},
+		{col: b.projects, count: &stats.Projects},
+		{col: b.appUsers, count: &stats.AppUsers},
+	} {
+		instances, err := b.findAll(ctx, b.entry(m.col))
+		if err != nil {
+			return nil, err
+		}
+		*m.count = len(instances)
+	}
+	query := st.JSONWhere("Expiry").Ge(float64(time.Now().Unix()))
+	res, err := b.threads.ModelFind(ctx, b.sessions.storeID.String(), b.sessions.GetName(), query, []*c.Session{})
+	if err != nil {
+		return nil, err
+	}
+	stats.ActiveSessions = len(res.([]*c.Session))
+	return stats, nil
+}
+
+func (b *backend) addCollection(ctx context.Context, col Collection, key datastore.Key) (*uuid.UUID, error) {
+	storeID, err := storeIDAtKey(b.ds, key)
+	if err != nil {
+		return nil, err
+	}
+	if storeID == nil {
+		ids, err := b.threads.NewStore(ctx)
+		if err != nil {
+			return nil, err
+		}
+		id := uuid.MustParse(ids)
+		storeID = &id
+
+		schema, err := json.Marshal(jsonschema.Reflect(col.GetInstance()))
+		if err != nil {
+			panic(err)
+		}
+		if err = b.threads.RegisterSchema(ctx, storeID.String(), col.GetName(), string(schema)); err != nil {
+			return nil, err
+		}
+		if err = b.ds.Put(key, storeID[:]); err != nil {
+			return nil, err
+		}
+		if err = b.threads.Start(ctx, storeID.String()); err != nil {
+			return nil, err
+		}
+	}
+	return storeID, nil
+}
+
+// notFound maps the threads API's not found error to c.ErrNotFound.
+func notFound(err error) error {
+	if stat, ok := status.FromError(err); ok && stat.Message() == st.ErrNotFound.Error() {
+		return c.ErrNotFound
+	}
+	return err
+}
+
+func storeIDAtKey(ds datastore.Datastore, key datastore.Key) (*uuid.UUID, error) {
+	idv, err := ds.Get(key)
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	id := &uuid.UUID{}
+	if err = id.UnmarshalBinary(idv); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
@@ -0,0 +1,25 @@
+package threads
+
+import (
+	"errors"
+	"testing"
+
+	st "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNotFound(t *testing.T) {
+	// The threads API returns store errors as gRPC errors with the same message.
+	if err := notFound(status.Error(codes.Unknown, st.ErrNotFound.Error())); !errors.Is(err, c.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	other := status.Error(codes.Unavailable, "connection refused")
+	if err := notFound(other); err != other {
+		t.Fatalf("expected other errors to be returned as is, got %v", err)
+	}
+	if err := notFound(nil); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
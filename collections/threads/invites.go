@@ -0,0 +1,56 @@
+package threads
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	c "github.com/textileio/textile/collections"
+)
+
+type Invites struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (i *Invites) GetName() string {
+	return "Invite"
+}
+
+func (i *Invites) GetInstance() interface{} {
+	return &c.Invite{}
+}
+
+func (i *Invites) GetStoreID() *uuid.UUID {
+	return i.storeID
+}
+
+func (i *Invites) Create(ctx context.Context, teamID, fromID, toEmail string) (*c.Invite, error) {
+	ctx = c.AuthCtx(ctx, i.token)
+	invite := &c.Invite{
+		TeamID:  teamID,
+		FromID:  fromID,
+		ToEmail: toEmail,
+		Expiry:  int(time.Now().Add(c.InviteDuration).Unix()),
+	}
+	if err := i.threads.ModelCreate(ctx, i.storeID.String(), i.GetName(), invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (i *Invites) Get(ctx context.Context, id string) (*c.Invite, error) {
+	ctx = c.AuthCtx(ctx, i.token)
+	invite := &c.Invite{}
+	if err := i.threads.ModelFindByID(ctx, i.storeID.String(), i.GetName(), id, invite); err != nil {
+		return nil, notFound(err)
+	}
+	return invite, nil
+}
+
+func (i *Invites) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, i.token)
+	return i.threads.ModelDelete(ctx, i.storeID.String(), i.GetName(), id)
+}
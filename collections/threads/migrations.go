@@ -0,0 +1,316 @@
+package threads
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/google/uuid"
+	"github.com/ipfs/go-datastore"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+var dsSchemaVersionKey = datastore.NewKey("/schema_version")
+
+// migration upgrades the collections from the previous schema version.
+type migration struct {
+	c.Migration
+
+	run func(ctx context.Context, b *backend) error
+}
+
+// migrations are applied in order. Append new migrations to the end,
+// never modify or reorder existing ones.
+var migrations = []migration{
+	{
+		Migration: c.Migration{
+			Version:     1,
+			Description: "Re-register all schemas from their current types",
+		},
+		run: func(ctx context.Context, b *backend) error {
+			for _, e := range b.entries() {
+				if err := b.reregister(ctx, e, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     2,
+			Description: "Add AllowedOrigins to app tokens",
+		},
+		run: func(ctx context.Context, b *backend) error {
+			return b.reregister(ctx, b.entry(b.appTokens), func(inst map[string]interface{}) error {
+				if _, ok := inst["AllowedOrigins"]; !ok {
+					inst["AllowedOrigins"] = []string{}
+				}
+				return nil
+			})
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     3,
+			Description: "Add Description, Website, and Avatar to teams",
+		},
+		run: func(ctx context.Context, b *backend) error {
+			return b.reregister(ctx, b.entry(b.teams), func(inst map[string]interface{}) error {
+				for _, k := range []string{"Description", "Website", "Avatar"} {
+					if _, ok := inst[k]; !ok {
+						inst[k] = ""
+					}
+				}
+				return nil
+			})
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     4,
+			Description: "Add Name and Username to users",
+		},
+		run: func(ctx context.Context, b *backend) error {
+			return b.reregister(ctx, b.entry(b.users), func(inst map[string]interface{}) error {
+				for _, k := range []string{"Name", "Username"} {
+					if _, ok := inst[k]; !ok {
+						inst[k] = ""
+					}
+				}
+				return nil
+			})
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     5,
+			Description: "Add audit events",
+		},
+		// The store is created with the current schema on startup like any new collection.
+		run: func(context.Context, *backend) error {
+			return nil
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     6,
+			Description: "Add webhooks and webhook deliveries",
+		},
+		run: func(context.Context, *backend) error {
+			return nil
+		},
+	},
+	{
+		Migration: c.Migration{
+			Version:     7,
+			Description: "Make team names unique per owner",
+		},
+		run: func(ctx context.Context, b *backend) error {
+			b.teams.lock.Lock()
+			defer b.teams.lock.Unlock()
+			res, err := b.threads.ModelFind(ctx, b.teams.storeID.String(), b.teams.GetName(), &s.JSONQuery{},
+				[]*c.Team{})
+			if err != nil {
+				return err
+			}
+			for _, t := range duplicateTeams(res.([]*c.Team)) {
+				t.Name = t.Name + " (" + t.ID + ")"
+				if err = b.threads.ModelSave(ctx, b.teams.storeID.String(), b.teams.GetName(), t); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+}
+
+// duplicateTeams returns all but the oldest of each owner's teams with the same name.
+func duplicateTeams(teams []*c.Team) []*c.Team {
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].Created != teams[j].Created {
+			return teams[i].Created < teams[j].Created
+		}
+		return teams[i].ID < teams[j].ID
+	})
+	kept := make(map[string][]*c.Team)
+	var dups []*c.Team
+	for _, t := range teams {
+		if c.HasTeamName(kept[t.OwnerID], t.Name, "") {
+			dups = append(dups, t)
+		} else {
+			kept[t.OwnerID] = append(kept[t.OwnerID], t)
+		}
+	}
+	return dups
+}
+
+// latestSchemaVersion returns the version reached after all migrations have run.
+func latestSchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the current schema version.
+func (b *backend) SchemaVersion() (int, error) {
+	v, err := b.ds.Get(dsSchemaVersionKey)
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.Atoi(string(v))
+}
+
+func (b *backend) setSchemaVersion(version int) error {
+	return b.ds.Put(dsSchemaVersionKey, []byte(strconv.Itoa(version)))
+}
+
+// PendingMigrations returns the migrations that have not been applied yet.
+func (b *backend) PendingMigrations() ([]c.Migration, error) {
+	pending, err := b.pendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]c.Migration, len(pending))
+	for i, m := range pending {
+		res[i] = m.Migration
+	}
+	return res, nil
+}
+
+func (b *backend) pendingMigrations() ([]migration, error) {
+	version, err := b.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	var pending []migration
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies pending migrations in order and returns the ones that were applied.
+// The schema version is saved after each migration, so a failed run can be resumed.
+func (b *backend) Migrate(ctx context.Context) ([]c.Migration, error) {
+	pending, err := b.pendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+	ctx = c.AuthCtx(ctx, b.token)
+	var applied []c.Migration
+	for _, m := range pending {
+		log.Infof("running migration %d: %s", m.Version, m.Description)
+		if err := m.run(ctx, b); err != nil {
+			return applied, err
+		}
+		if err := b.setSchemaVersion(m.Version); err != nil {
+			return applied, err
+		}
+		applied = append(applied, m.Migration)
+	}
+	return applied, nil
+}
+
+// entry ties a collection to the datastore key that pins its store.
+type entry struct {
+	col     Collection
+	key     datastore.Key
+	storeID **uuid.UUID
+}
+
+func (b *backend) entries() []entry {
+	return []entry{
+		{col: b.users, key: dsUsersKey, storeID: &b.users.storeID},
+		{col: b.sessions, key: dsSessionsKey, storeID: &b.sessions.storeID},
+		{col: b.teams, key: dsTeamsKey, storeID: &b.teams.storeID},
+		{col: b.invites, key: dsInvitesKey, storeID: &b.invites.storeID},
+		{col: b.projects, key: dsProjectsKey, storeID: &b.projects.storeID},
+		{col: b.appTokens, key: dsAppTokensKey, storeID: &b.appTokens.storeID},
+		{col: b.appUsers, key: dsAppUsersKey, storeID: &b.appUsers.storeID},
+		{col: b.auditEvents, key: dsAuditEventsKey, storeID: &b.auditEvents.storeID},
+		{col: b.webhooks, key: dsWebhooksKey, storeID: &b.webhooks.storeID},
+		{col: b.webhookDeliveries, key: dsWebhookDeliveriesKey, storeID: &b.webhookDeliveries.storeID},
+	}
+}
+
+// entry returns the entry of a collection.
+func (b *backend) entry(col Collection) entry {
+	for _, e := range b.entries() {
+		if e.col == col {
+			return e
+		}
+	}
+	panic("unknown collection " + col.GetName())
+}
+
+// findAll returns all instances of a collection as generic JSON objects.
+func (b *backend) findAll(ctx context.Context, e entry) ([]*map[string]interface{}, error) {
+	res, err := b.threads.ModelFind(ctx, e.col.GetStoreID().String(), e.col.GetName(), &s.JSONQuery{},
+		[]*map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*map[string]interface{}), nil
+}
+
+// reregister moves a collection's instances to a new store registered with the
+// schema of the collection's current type. Registered schemas can't be replaced
+// in place. If transform is not nil, it's applied to each instance before it's
+// written to the new store. The old store is left untouched.
+func (b *backend) reregister(ctx context.Context, e entry, transform func(map[string]interface{}) error) error {
+	name := e.col.GetName()
+	oldID := e.col.GetStoreID().String()
+	instances, err := b.findAll(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	ids, err := b.threads.NewStore(ctx)
+	if err != nil {
+		return err
+	}
+	schema, err := json.Marshal(jsonschema.Reflect(e.col.GetInstance()))
+	if err != nil {
+		return err
+	}
+	if err = b.threads.RegisterSchema(ctx, ids, name, string(schema)); err != nil {
+		return err
+	}
+	if err = b.threads.Start(ctx, ids); err != nil {
+		return err
+	}
+
+	if len(instances) > 0 {
+		items := make([]interface{}, len(instances))
+		for i, inst := range instances {
+			if transform != nil {
+				if err = transform(*inst); err != nil {
+					return err
+				}
+			}
+			items[i] = inst
+		}
+		if err = b.threads.ModelCreate(ctx, ids, name, items...); err != nil {
+			return err
+		}
+	}
+
+	id := uuid.MustParse(ids)
+	if err = b.ds.Put(e.key, id[:]); err != nil {
+		return err
+	}
+	*e.storeID = &id
+	log.Infof("moved %d %s instances from store %s to %s", len(instances), name, oldID, ids)
+	return nil
+}
@@ -0,0 +1,35 @@
+package threads
+
+import (
+	"testing"
+
+	c "github.com/textileio/textile/collections"
+)
+
+func TestMigrations_Order(t *testing.T) {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Fatalf("migration at index %d has version %d, expected %d", i, m.Version, i+1)
+		}
+		if m.run == nil {
+			t.Fatalf("migration %d has no run function", m.Version)
+		}
+	}
+	if latestSchemaVersion() != len(migrations) {
+		t.Fatal("got bad latest schema version")
+	}
+}
+
+func TestDuplicateTeams(t *testing.T) {
+	teams := []*c.Team{
+		{ID: "d", OwnerID: "jon", Name: "Foo", Created: 2},
+		{ID: "c", OwnerID: "jon", Name: "foo", Created: 1},
+		{ID: "b", OwnerID: "jon", Name: "FOO", Created: 1},
+		{ID: "a", OwnerID: "jane", Name: "foo", Created: 3},
+		{ID: "e", OwnerID: "jon", Name: "bar", Created: 4},
+	}
+	dups := duplicateTeams(teams)
+	if len(dups) != 2 || dups[0].ID != "c" || dups[1].ID != "d" {
+		t.Fatal("got bad duplicate teams")
+	}
+}
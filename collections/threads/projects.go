@@ -0,0 +1,88 @@
+package threads
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type Projects struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (p *Projects) GetName() string {
+	return "Project"
+}
+
+func (p *Projects) GetInstance() interface{} {
+	return &c.Project{}
+}
+
+func (p *Projects) GetStoreID() *uuid.UUID {
+	return p.storeID
+}
+
+func (p *Projects) Create(ctx context.Context, name, scope, fcWalletAddress string) (*c.Project, error) {
+	ctx = c.AuthCtx(ctx, p.token)
+	proj := &c.Project{
+		Name:          name,
+		Scope:         scope,
+		WalletAddress: fcWalletAddress,
+		Created:       time.Now().Unix(),
+	}
+	// Create a dedicated store for the project
+	var err error
+	proj.StoreID, err = p.threads.NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.threads.ModelCreate(ctx, p.storeID.String(), p.GetName(), proj); err != nil {
+		return nil, err
+	}
+	if err = p.threads.Start(ctx, proj.StoreID); err != nil {
+		return nil, err
+	}
+	return proj, nil
+}
+
+func (p *Projects) Get(ctx context.Context, id string) (*c.Project, error) {
+	ctx = c.AuthCtx(ctx, p.token)
+	proj := &c.Project{}
+	if err := p.threads.ModelFindByID(ctx, p.storeID.String(), p.GetName(), id, proj); err != nil {
+		return nil, notFound(err)
+	}
+	return proj, nil
+}
+
+func (p *Projects) List(ctx context.Context, scope string) ([]*c.Project, error) {
+	ctx = c.AuthCtx(ctx, p.token)
+	query := s.JSONWhere("Scope").Eq(scope)
+	res, err := p.threads.ModelFind(ctx, p.storeID.String(), p.GetName(), query, []*c.Project{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.Project), nil
+}
+
+func (p *Projects) SwitchScope(ctx context.Context, proj *c.Project, scope string) error {
+	ctx = c.AuthCtx(ctx, p.token)
+	proj.Scope = scope
+	return p.threads.ModelSave(ctx, p.storeID.String(), p.GetName(), proj)
+}
+
+func (p *Projects) Rename(ctx context.Context, proj *c.Project, name string) error {
+	ctx = c.AuthCtx(ctx, p.token)
+	proj.Name = name
+	return p.threads.ModelSave(ctx, p.storeID.String(), p.GetName(), proj)
+}
+
+func (p *Projects) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, p.token)
+	return p.threads.ModelDelete(ctx, p.storeID.String(), p.GetName(), id)
+}
@@ -0,0 +1,84 @@
+package threads
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	st "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type Sessions struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (s *Sessions) GetName() string {
+	return "Session"
+}
+
+func (s *Sessions) GetInstance() interface{} {
+	return &c.Session{}
+}
+
+func (s *Sessions) GetStoreID() *uuid.UUID {
+	return s.storeID
+}
+
+func (s *Sessions) Create(ctx context.Context, userID, scope string) (*c.Session, error) {
+	ctx = c.AuthCtx(ctx, s.token)
+	session := &c.Session{
+		UserID: userID,
+		Scope:  scope,
+		Expiry: int(time.Now().Add(c.SessionDuration).Unix()),
+	}
+	if err := s.threads.ModelCreate(ctx, s.storeID.String(), s.GetName(), session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *Sessions) Get(ctx context.Context, id string) (*c.Session, error) {
+	ctx = c.AuthCtx(ctx, s.token)
+	session := &c.Session{}
+	if err := s.threads.ModelFindByID(ctx, s.storeID.String(), s.GetName(), id, session); err != nil {
+		return nil, notFound(err)
+	}
+	return session, nil
+}
+
+func (s *Sessions) ListByUser(ctx context.Context, userID string) ([]*c.Session, error) {
+	ctx = c.AuthCtx(ctx, s.token)
+	query := st.JSONWhere("UserID").Eq(userID)
+	res, err := s.threads.ModelFind(ctx, s.storeID.String(), s.GetName(), query, []*c.Session{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.Session), nil
+}
+
+func (s *Sessions) Touch(ctx context.Context, session *c.Session) error {
+	ctx = c.AuthCtx(ctx, s.token)
+	session.Expiry = int(time.Now().Add(c.SessionDuration).Unix())
+	return s.threads.ModelSave(ctx, s.storeID.String(), s.GetName(), session)
+}
+
+func (s *Sessions) SwitchScope(ctx context.Context, session *c.Session, scope string) error {
+	ctx = c.AuthCtx(ctx, s.token)
+	session.Scope = scope
+	return s.threads.ModelSave(ctx, s.storeID.String(), s.GetName(), session)
+}
+
+func (s *Sessions) SwitchUser(ctx context.Context, session *c.Session, userID string) error {
+	ctx = c.AuthCtx(ctx, s.token)
+	session.UserID = userID
+	return s.threads.ModelSave(ctx, s.storeID.String(), s.GetName(), session)
+}
+
+func (s *Sessions) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, s.token)
+	return s.threads.ModelDelete(ctx, s.storeID.String(), s.GetName(), id)
+}
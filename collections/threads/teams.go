@@ -0,0 +1,118 @@
+package threads
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type Teams struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+
+	// lock serializes team name checks with writes.
+	// All writes to the teams store go through this instance.
+	lock sync.Mutex
+}
+
+func (t *Teams) GetName() string {
+	return "Team"
+}
+
+func (t *Teams) GetInstance() interface{} {
+	return &c.Team{}
+}
+
+func (t *Teams) GetStoreID() *uuid.UUID {
+	return t.storeID
+}
+
+// Create a new team.
+// Returns c.ErrTeamExists if the owner already has a team with the name.
+func (t *Teams) Create(ctx context.Context, ownerID, name string) (*c.Team, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	ctx = c.AuthCtx(ctx, t.token)
+	if err := t.checkName(ctx, ownerID, name, ""); err != nil {
+		return nil, err
+	}
+	team := &c.Team{
+		OwnerID: ownerID,
+		Name:    name,
+		Created: time.Now().Unix(),
+	}
+	if err := t.threads.ModelCreate(ctx, t.storeID.String(), t.GetName(), team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (t *Teams) Get(ctx context.Context, id string) (*c.Team, error) {
+	ctx = c.AuthCtx(ctx, t.token)
+	team := &c.Team{}
+	if err := t.threads.ModelFindByID(ctx, t.storeID.String(), t.GetName(), id, team); err != nil {
+		return nil, notFound(err)
+	}
+	return team, nil
+}
+
+func (t *Teams) ListByOwner(ctx context.Context, ownerID string) ([]*c.Team, error) {
+	return t.listByOwner(c.AuthCtx(ctx, t.token), ownerID)
+}
+
+func (t *Teams) listByOwner(ctx context.Context, ownerID string) ([]*c.Team, error) {
+	query := s.JSONWhere("OwnerID").Eq(ownerID)
+	res, err := t.threads.ModelFind(ctx, t.storeID.String(), t.GetName(), query, []*c.Team{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.Team), nil
+}
+
+// SwitchOwner transfers the team to ownerID.
+// Returns c.ErrTeamExists if the new owner already has a team with the name.
+func (t *Teams) SwitchOwner(ctx context.Context, team *c.Team, ownerID string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	ctx = c.AuthCtx(ctx, t.token)
+	if err := t.checkName(ctx, ownerID, team.Name, team.ID); err != nil {
+		return err
+	}
+	team.OwnerID = ownerID
+	return t.threads.ModelSave(ctx, t.storeID.String(), t.GetName(), team)
+}
+
+// Update saves the team.
+// Returns c.ErrTeamExists if the owner already has another team with the name.
+func (t *Teams) Update(ctx context.Context, team *c.Team) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	ctx = c.AuthCtx(ctx, t.token)
+	if err := t.checkName(ctx, team.OwnerID, team.Name, team.ID); err != nil {
+		return err
+	}
+	return t.threads.ModelSave(ctx, t.storeID.String(), t.GetName(), team)
+}
+
+// checkName must be called with the lock held.
+func (t *Teams) checkName(ctx context.Context, ownerID, name, teamID string) error {
+	teams, err := t.listByOwner(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if c.HasTeamName(teams, name, teamID) {
+		return c.ErrTeamExists
+	}
+	return nil
+}
+
+func (t *Teams) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, t.token)
+	return t.threads.ModelDelete(ctx, t.storeID.String(), t.GetName(), id)
+}
@@ -0,0 +1,228 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type Users struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+
+	// lock serializes email and username lookups with user writes.
+	// All writes to the users store go through this instance.
+	lock sync.Mutex
+}
+
+func (u *Users) GetName() string {
+	return "User"
+}
+
+func (u *Users) GetInstance() interface{} {
+	return &c.User{}
+}
+
+func (u *Users) GetStoreID() *uuid.UUID {
+	return u.storeID
+}
+
+// Create a new user with the given email.
+// Returns ErrUserExists if a user with the normalized email already exists.
+func (u *Users) Create(ctx context.Context, email string) (*c.User, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	ctx = c.AuthCtx(ctx, u.token)
+	email = c.NormalizeEmail(email)
+	matches, err := u.getByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 0 {
+		return nil, c.ErrUserExists
+	}
+	return u.create(ctx, email)
+}
+
+// GetOrCreate returns the user with the given email, creating one if needed.
+// The lookup and creation are atomic, so concurrent calls with the same email
+// always resolve to the same user.
+func (u *Users) GetOrCreate(ctx context.Context, email string) (*c.User, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	ctx = c.AuthCtx(ctx, u.token)
+	email = c.NormalizeEmail(email)
+	matches, err := u.getByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 0 {
+		return matches[0], nil
+	}
+	return u.create(ctx, email)
+}
+
+func (u *Users) create(ctx context.Context, email string) (*c.User, error) {
+	user := &c.User{
+		Email:   email,
+		Teams:   []string{},
+		Created: time.Now().Unix(),
+	}
+	if err := u.threads.ModelCreate(ctx, u.storeID.String(), u.GetName(), user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *Users) Get(ctx context.Context, id string) (*c.User, error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	user := &c.User{}
+	if err := u.threads.ModelFindByID(ctx, u.storeID.String(), u.GetName(), id, user); err != nil {
+		return nil, notFound(err)
+	}
+	return user, nil
+}
+
+// GetByEmail returns the user with the given email.
+func (u *Users) GetByEmail(ctx context.Context, email string) (*c.User, error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	matches, err := u.getByEmail(ctx, c.NormalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, c.ErrNotFound
+	}
+	return matches[0], nil
+}
+
+// GetByUsername returns the user with the given username.
+func (u *Users) GetByUsername(ctx context.Context, username string) (*c.User, error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	username = c.NormalizeUsername(username)
+	if username == "" {
+		return nil, c.ErrNotFound
+	}
+	query := s.JSONWhere("Username").Eq(username)
+	res, err := u.threads.ModelFind(ctx, u.storeID.String(), u.GetName(), query, []*c.User{})
+	if err != nil {
+		return nil, err
+	}
+	users := res.([]*c.User)
+	if len(users) == 0 {
+		return nil, c.ErrNotFound
+	}
+	return users[0], nil
+}
+
+// getByEmail returns users with the given email, oldest first.
+// Users created before emails were normalized may contain duplicates.
+func (u *Users) getByEmail(ctx context.Context, email string) ([]*c.User, error) {
+	query := s.JSONWhere("Email").Eq(email)
+	res, err := u.threads.ModelFind(ctx, u.storeID.String(), u.GetName(), query, []*c.User{})
+	if err != nil {
+		return nil, err
+	}
+	users := res.([]*c.User)
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].Created < users[j].Created
+	})
+	return users, nil
+}
+
+// List returns all users.
+func (u *Users) List(ctx context.Context) ([]*c.User, error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	res, err := u.threads.ModelFind(ctx, u.storeID.String(), u.GetName(), &s.JSONQuery{}, []*c.User{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.User), nil
+}
+
+func (u *Users) ListByTeam(ctx context.Context, teamID string) ([]*c.User, error) {
+	ctx = c.AuthCtx(ctx, u.token)
+	res, err := u.threads.ModelFind(ctx, u.storeID.String(), u.GetName(), &s.JSONQuery{}, []*c.User{})
+	if err != nil {
+		return nil, err
+	}
+	// @todo: Enable indexes :)
+	// @todo: Enable a 'contains' query condition.
+	var users []*c.User
+loop:
+	for _, u := range res.([]*c.User) {
+		for _, t := range u.Teams {
+			if t == teamID {
+				users = append(users, u)
+				continue loop
+			}
+		}
+	}
+	return users, nil
+}
+
+func (u *Users) JoinTeam(ctx context.Context, user *c.User, teamID string) error {
+	for _, t := range user.Teams {
+		if t == teamID {
+			return nil
+		}
+	}
+	user.Teams = append(user.Teams, teamID)
+	return u.Save(ctx, user)
+}
+
+func (u *Users) LeaveTeam(ctx context.Context, user *c.User, teamID string) error {
+	n := 0
+	for _, x := range user.Teams {
+		if x != teamID {
+			user.Teams[n] = x
+			n++
+		}
+	}
+	user.Teams = user.Teams[:n]
+	return u.Save(ctx, user)
+}
+
+// Save an existing user.
+// Returns c.ErrUserExists or c.ErrUsernameExists if another user has the email or username.
+// Only changed values are checked, so users with legacy duplicate emails can still be saved.
+func (u *Users) Save(ctx context.Context, user *c.User) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	ctx = c.AuthCtx(ctx, u.token)
+	stored := &c.User{}
+	if err := u.threads.ModelFindByID(ctx, u.storeID.String(), u.GetName(), user.ID, stored); err != nil {
+		return notFound(err)
+	}
+	if user.Email != stored.Email {
+		matches, err := u.getByEmail(ctx, user.Email)
+		if err != nil {
+			return err
+		}
+		if len(matches) > 0 {
+			return c.ErrUserExists
+		}
+	}
+	if user.Username != "" && user.Username != stored.Username {
+		if _, err := u.GetByUsername(ctx, user.Username); err == nil {
+			return c.ErrUsernameExists
+		} else if !errors.Is(err, c.ErrNotFound) {
+			return err
+		}
+	}
+	return u.threads.ModelSave(ctx, u.storeID.String(), u.GetName(), user)
+}
+
+// @todo: Add a destroy method that calls this. User must first delete projects and teams they own.
+func (u *Users) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, u.token)
+	return u.threads.ModelDelete(ctx, u.storeID.String(), u.GetName(), id)
+}
@@ -0,0 +1,116 @@
+package threads
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/textileio/go-threads/api/client"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+)
+
+type Webhooks struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (w *Webhooks) GetName() string {
+	return "Webhook"
+}
+
+func (w *Webhooks) GetInstance() interface{} {
+	return &c.Webhook{}
+}
+
+func (w *Webhooks) GetStoreID() *uuid.UUID {
+	return w.storeID
+}
+
+func (w *Webhooks) Create(ctx context.Context, projectID, url, secret string, events []string) (*c.Webhook, error) {
+	ctx = c.AuthCtx(ctx, w.token)
+	if events == nil {
+		events = []string{} // null doesn't validate against the array schema
+	}
+	hook := &c.Webhook{
+		ProjectID: projectID,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Created:   time.Now().Unix(),
+	}
+	if err := w.threads.ModelCreate(ctx, w.storeID.String(), w.GetName(), hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (w *Webhooks) Get(ctx context.Context, id string) (*c.Webhook, error) {
+	ctx = c.AuthCtx(ctx, w.token)
+	hook := &c.Webhook{}
+	if err := w.threads.ModelFindByID(ctx, w.storeID.String(), w.GetName(), id, hook); err != nil {
+		return nil, notFound(err)
+	}
+	return hook, nil
+}
+
+func (w *Webhooks) List(ctx context.Context, projectID string) ([]*c.Webhook, error) {
+	ctx = c.AuthCtx(ctx, w.token)
+	query := s.JSONWhere("ProjectID").Eq(projectID)
+	res, err := w.threads.ModelFind(ctx, w.storeID.String(), w.GetName(), query, []*c.Webhook{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.Webhook), nil
+}
+
+func (w *Webhooks) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, w.token)
+	return w.threads.ModelDelete(ctx, w.storeID.String(), w.GetName(), id)
+}
+
+type WebhookDeliveries struct {
+	threads *client.Client
+	storeID *uuid.UUID
+	token   string
+}
+
+func (w *WebhookDeliveries) GetName() string {
+	return "WebhookDelivery"
+}
+
+func (w *WebhookDeliveries) GetInstance() interface{} {
+	return &c.WebhookDelivery{}
+}
+
+func (w *WebhookDeliveries) GetStoreID() *uuid.UUID {
+	return w.storeID
+}
+
+func (w *WebhookDeliveries) Create(ctx context.Context, d *c.WebhookDelivery) error {
+	ctx = c.AuthCtx(ctx, w.token)
+	d.Created = time.Now().Unix()
+	return w.threads.ModelCreate(ctx, w.storeID.String(), w.GetName(), d)
+}
+
+func (w *WebhookDeliveries) ListDue(ctx context.Context, now int64) ([]*c.WebhookDelivery, error) {
+	ctx = c.AuthCtx(ctx, w.token)
+	// JSON numbers are only compared as floats.
+	query := s.JSONWhere("NextAttempt").Le(float64(now))
+	res, err := w.threads.ModelFind(ctx, w.storeID.String(), w.GetName(), query, []*c.WebhookDelivery{})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*c.WebhookDelivery), nil
+}
+
+func (w *WebhookDeliveries) Save(ctx context.Context, d *c.WebhookDelivery) error {
+	ctx = c.AuthCtx(ctx, w.token)
+	return w.threads.ModelSave(ctx, w.storeID.String(), w.GetName(), d)
+}
+
+func (w *WebhookDeliveries) Delete(ctx context.Context, id string) error {
+	ctx = c.AuthCtx(ctx, w.token)
+	return w.threads.ModelDelete(ctx, w.storeID.String(), w.GetName(), id)
+}
@@ -2,93 +2,30 @@ package collections
 
 import (
 	"context"
-	"time"
+	"errors"
+	"net/mail"
+	"strings"
+)
 
-	"github.com/google/uuid"
-	"github.com/textileio/go-threads/api/client"
-	s "github.com/textileio/go-threads/store"
+var (
+	// ErrUserExists indicates a user with the given email already exists.
+	ErrUserExists = errors.New("user with email already exists")
+	// ErrUsernameExists indicates a user with the given username already exists.
+	ErrUsernameExists = errors.New("user with username already exists")
 )
 
 type User struct {
-	ID      string
-	Email   string
-	Teams   []string
-	Created int64
-}
-
-type Users struct {
-	threads *client.Client
-	storeID *uuid.UUID
-	token   string
-}
-
-func (u *Users) GetName() string {
-	return "User"
-}
-
-func (u *Users) GetInstance() interface{} {
-	return &User{}
+	ID       string
+	Email    string
+	Name     string // display name
+	Username string // unique if not empty
+	Teams    []string
+	Created  int64
 }
 
-func (u *Users) GetStoreID() *uuid.UUID {
-	return u.storeID
-}
-
-func (u *Users) Create(ctx context.Context, email string) (*User, error) {
-	ctx = AuthCtx(ctx, u.token)
-	user := &User{
-		Email:   email,
-		Teams:   []string{},
-		Created: time.Now().Unix(),
-	}
-	if err := u.threads.ModelCreate(ctx, u.storeID.String(), u.GetName(), user); err != nil {
-		return nil, err
-	}
-	return user, nil
-}
-
-func (u *Users) Get(ctx context.Context, id string) (*User, error) {
-	ctx = AuthCtx(ctx, u.token)
-	user := &User{}
-	if err := u.threads.ModelFindByID(ctx, u.storeID.String(), u.GetName(), id, user); err != nil {
-		return nil, err
-	}
-	return user, nil
-}
-
-func (u *Users) GetByEmail(ctx context.Context, email string) ([]*User, error) {
-	ctx = AuthCtx(ctx, u.token)
-	query := s.JSONWhere("Email").Eq(email)
-	res, err := u.threads.ModelFind(ctx, u.storeID.String(), u.GetName(), query, []*User{})
-	if err != nil {
-		return nil, err
-	}
-	return res.([]*User), nil
-}
-
-func (u *Users) ListByTeam(ctx context.Context, teamID string) ([]*User, error) {
-	ctx = AuthCtx(ctx, u.token)
-	res, err := u.threads.ModelFind(ctx, u.storeID.String(), u.GetName(), &s.JSONQuery{}, []*User{})
-	if err != nil {
-		return nil, err
-	}
-	// @todo: Enable indexes :)
-	// @todo: Enable a 'contains' query condition.
-	var users []*User
-loop:
-	for _, u := range res.([]*User) {
-		for _, t := range u.Teams {
-			if t == teamID {
-				users = append(users, u)
-				continue loop
-			}
-		}
-	}
-	return users, nil
-}
-
-func (u *Users) HasTeam(user *User, teamID string) bool {
-	for _, t := range user.Teams {
+// HasTeam returns whether or not the user is a member of the team.
+func (u *User) HasTeam(teamID string) bool {
+	for _, t := range u.Teams {
 		if teamID == t {
 			return true
 		}
@@ -96,32 +33,41 @@ func (u *Users) HasTeam(user *User, teamID string) bool {
 	return false
 }
 
-func (u *Users) JoinTeam(ctx context.Context, user *User, teamID string) error {
-	ctx = AuthCtx(ctx, u.token)
-	for _, t := range user.Teams {
-		if t == teamID {
-			return nil
-		}
-	}
-	user.Teams = append(user.Teams, teamID)
-	return u.threads.ModelSave(ctx, u.storeID.String(), u.GetName(), user)
+type Users interface {
+	// Create a new user with the given email.
+	// Returns ErrUserExists if a user with the normalized email already exists.
+	Create(ctx context.Context, email string) (*User, error)
+	// GetOrCreate returns the user with the given email, creating one if needed.
+	// The lookup and creation are atomic, so concurrent calls with the same email
+	// always resolve to the same user.
+	GetOrCreate(ctx context.Context, email string) (*User, error)
+	Get(ctx context.Context, id string) (*User, error)
+	// GetByEmail returns the user with the given email.
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	// GetByUsername returns the user with the given username.
+	GetByUsername(ctx context.Context, username string) (*User, error)
+	// List returns all users.
+	List(ctx context.Context) ([]*User, error)
+	ListByTeam(ctx context.Context, teamID string) ([]*User, error)
+	JoinTeam(ctx context.Context, user *User, teamID string) error
+	LeaveTeam(ctx context.Context, user *User, teamID string) error
+	// Save an existing user.
+	// The uniqueness checks and the write are atomic. Returns ErrUserExists if
+	// another user has the email, or ErrUsernameExists if another user has the username.
+	Save(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id string) error
 }
 
-func (u *Users) LeaveTeam(ctx context.Context, user *User, teamID string) error {
-	ctx = AuthCtx(ctx, u.token)
-	n := 0
-	for _, x := range user.Teams {
-		if x != teamID {
-			user.Teams[n] = x
-			n++
-		}
-	}
-	user.Teams = user.Teams[:n]
-	return u.threads.ModelSave(ctx, u.storeID.String(), u.GetName(), user)
+// NormalizeUsername returns the canonical form of a username used for lookups.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
 }
 
-// @todo: Add a destroy method that calls this. User must first delete projects and teams they own.
-func (u *Users) Delete(ctx context.Context, id string) error {
-	ctx = AuthCtx(ctx, u.token)
-	return u.threads.ModelDelete(ctx, u.storeID.String(), u.GetName(), id)
+// NormalizeEmail returns the canonical form of an email address used for lookups.
+// Display names are dropped, so "Jon <jon@doe.com>" and "jon@doe.com" are the same.
+func NormalizeEmail(email string) string {
+	if addr, err := mail.ParseAddress(email); err == nil {
+		email = addr.Address
+	}
+	return strings.ToLower(strings.TrimSpace(email))
 }
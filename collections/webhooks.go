@@ -0,0 +1,76 @@
+package collections
+
+import (
+	"context"
+)
+
+// Webhook events.
+const (
+	EventAppUserRegister = "app_user.register"
+	EventTeamMemberJoin  = "team.member_join"
+	EventProjectRemove   = "project.remove"
+
+	// EventPing is only sent when testing a webhook.
+	EventPing = "ping"
+)
+
+// WebhookEvents are the events a webhook can subscribe to.
+var WebhookEvents = []string{
+	EventAppUserRegister,
+	EventTeamMemberJoin,
+	EventProjectRemove,
+}
+
+// Webhook posts a project's events to URL. Deliveries are signed with Secret.
+type Webhook struct {
+	ID        string
+	ProjectID string
+	URL       string
+	Secret    string
+	Events    []string
+	Created   int64
+}
+
+// Subscribed returns whether or not the webhook receives event.
+func (w *Webhook) Subscribed(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type Webhooks interface {
+	Create(ctx context.Context, projectID, url, secret string, events []string) (*Webhook, error)
+	Get(ctx context.Context, id string) (*Webhook, error)
+	List(ctx context.Context, projectID string) ([]*Webhook, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookDelivery is a queued event for a webhook. The webhook's URL and secret
+// are copied so that deliveries outlive their webhook, e.g., on project removal.
+type WebhookDelivery struct {
+	ID        string
+	WebhookID string
+	URL       string
+	Secret    string
+	Event     string
+	// Data is the JSON encoded event data.
+	Data        string
+	Attempts    int
+	NextAttempt int64
+	LastError   string
+	Created     int64
+}
+
+// WebhookDeliveries is the queue of pending deliveries.
+type WebhookDeliveries interface {
+	// Create sets the ID and creation time of d and adds it to the queue.
+	Create(ctx context.Context, d *WebhookDelivery) error
+	// ListDue returns the deliveries whose next attempt is at or before now.
+	ListDue(ctx context.Context, now int64) ([]*WebhookDelivery, error)
+	// Save updates the attempts, next attempt, and last error of d.
+	Save(ctx context.Context, d *WebhookDelivery) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+
+	"github.com/ipfs/go-datastore"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	threadsclient "github.com/textileio/go-threads/api/client"
+	c "github.com/textileio/textile/collections"
+	"github.com/textileio/textile/collections/sqldb"
+	"github.com/textileio/textile/collections/threads"
+)
+
+// Collections backends.
+const (
+	// CollectionsThreads stores collections in threads (default).
+	CollectionsThreads = "threads"
+	// CollectionsSQLite stores collections in an embedded SQLite database.
+	CollectionsSQLite = "sqlite3"
+	// CollectionsPostgres stores collections in a Postgres database.
+	CollectionsPostgres = "postgres"
+)
+
+// newCollections opens the collections backend selected by conf.
+// The returned database is nil for the threads backend.
+func newCollections(
+	ctx context.Context,
+	conf Config,
+	ds datastore.Datastore,
+	threadsClient *threadsclient.Client,
+) (*c.Collections, *sql.DB, error) {
+	switch conf.CollectionsBackend {
+	case "", CollectionsThreads:
+		collections, err := threads.NewCollections(ctx, threadsClient, conf.ThreadsInternalToken, ds)
+		return collections, nil, err
+	case CollectionsSQLite, CollectionsPostgres:
+		dsn := conf.CollectionsDSN
+		if dsn == "" {
+			if conf.CollectionsBackend != CollectionsSQLite {
+				return nil, nil, fmt.Errorf("a data source name is required for the %s backend",
+					conf.CollectionsBackend)
+			}
+			dsn = path.Join(conf.RepoPath, "collections.db")
+		}
+		db, err := sql.Open(conf.CollectionsBackend, dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		if conf.CollectionsBackend == CollectionsSQLite {
+			// SQLite allows one writer at a time.
+			db.SetMaxOpenConns(1)
+		}
+		if err = db.PingContext(ctx); err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		collections, err := sqldb.NewCollections(ctx, db, threadsClient, conf.ThreadsInternalToken)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		return collections, db, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown collections backend %s", conf.CollectionsBackend)
+	}
+}
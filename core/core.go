@@ -2,10 +2,14 @@ package core
 
 import (
 	"context"
+	"crypto/tls"
+	"database/sql"
+	"net/url"
 	"os"
 	"path"
 	"time"
 
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/ipfs/go-datastore"
 	badger "github.com/ipfs/go-ds-badger"
@@ -17,13 +21,20 @@ import (
 	fc "github.com/textileio/filecoin/api/client"
 	threadsapi "github.com/textileio/go-threads/api"
 	threadsclient "github.com/textileio/go-threads/api/client"
+	pb "github.com/textileio/go-threads/api/pb"
 	serviceapi "github.com/textileio/go-threads/service/api"
 	s "github.com/textileio/go-threads/store"
 	"github.com/textileio/go-threads/util"
 	"github.com/textileio/textile/api"
+	"github.com/textileio/textile/certs"
 	c "github.com/textileio/textile/collections"
 	"github.com/textileio/textile/dns"
 	"github.com/textileio/textile/email"
+	"github.com/textileio/textile/gateway"
+	"github.com/textileio/textile/metrics"
+	"github.com/textileio/textile/ratelimit"
+	"github.com/textileio/textile/tracing"
+	"github.com/textileio/textile/webhooks"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -38,6 +49,7 @@ type clientReqKey string
 
 type Textile struct {
 	ds          datastore.Datastore
+	db          *sql.DB
 	collections *c.Collections
 
 	ipfs iface.CoreAPI
@@ -47,15 +59,20 @@ type Textile struct {
 	threadsServiceServer *serviceapi.Server
 	threadsServer        *threadsapi.Server
 	threadsClient        *threadsclient.Client
+	threadsConn          *grpc.ClientConn
 	threadsInternalToken string
 
-	server *api.Server
+	server      *api.Server
+	certManager *certs.Manager
+	exporter    *tracing.Exporter
 }
 
 type Config struct {
 	RepoPath string
 
 	AddrApi                    ma.Multiaddr
+	AddrApiProxy               ma.Multiaddr
+	AddrApiProxyUrl            string
 	AddrThreadsHost            ma.Multiaddr
 	AddrThreadsServiceApi      ma.Multiaddr
 	AddrThreadsServiceApiProxy ma.Multiaddr
@@ -65,6 +82,16 @@ type Config struct {
 	AddrGatewayHost            ma.Multiaddr
 	AddrGatewayUrl             string
 	AddrFilecoinApi            ma.Multiaddr
+	AddrMetrics                ma.Multiaddr // Prometheus metrics are disabled if nil
+
+	GatewayCORS    gateway.CORSConfig
+	GatewayHeaders gateway.HeadersConfig
+
+	RateLimits ratelimit.Config
+
+	// TLS enables TLS for the API and the gateway. ACME certificates are
+	// cached in the repo and need the DNS settings for DNS-01 challenges.
+	TLS certs.Config
 
 	DNSDomain string
 	DNSZoneID string
@@ -77,13 +104,33 @@ type Config struct {
 	SessionSecret        string
 	ThreadsInternalToken string
 
+	CollectionsBackend string // CollectionsThreads, CollectionsSQLite, or CollectionsPostgres
+	CollectionsDSN     string // data source name for SQL backends
+
+	// Webhooks specifies how webhook deliveries are retried and which addresses are allowed.
+	Webhooks webhooks.Config
+
+	// Tracing specifies how request traces are sampled and exported.
+	Tracing tracing.Config
+
 	Debug bool
 }
 
 func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
-	if err := util.SetLogLevels(map[string]logging.LogLevel{
+	levels := map[string]logging.LogLevel{
 		"core": logging.LevelDebug,
-	}); err != nil {
+	}
+	if conf.Debug {
+		// Log each request. The daemon's own requests are logged at debug level,
+		// so they are still left out.
+		levels["requests"] = logging.LevelInfo
+	}
+	if err := util.SetLogLevels(levels); err != nil {
+		return nil, err
+	}
+
+	exporter, err := tracing.Start(conf.Tracing)
+	if err != nil {
 		return nil, err
 	}
 
@@ -103,6 +150,7 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 
 	t := &Textile{
 		threadsInternalToken: conf.ThreadsInternalToken,
+		exporter:             exporter,
 	}
 	threadservice, err := s.DefaultService(
 		conf.RepoPath,
@@ -115,8 +163,7 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 		Addr:      conf.AddrThreadsServiceApi,
 		ProxyAddr: conf.AddrThreadsServiceApiProxy,
 		Debug:     conf.Debug,
-	}, grpc.UnaryInterceptor(auth.UnaryServerInterceptor(t.clientAuthFunc)),
-		grpc.StreamInterceptor(auth.StreamServerInterceptor(t.clientAuthFunc)))
+	}, t.serverOptions("threads_service")...)
 	if err != nil {
 		return nil, err
 	}
@@ -125,8 +172,7 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 		Addr:      conf.AddrThreadsApi,
 		ProxyAddr: conf.AddrThreadsApiProxy,
 		Debug:     conf.Debug,
-	}, grpc.UnaryInterceptor(auth.UnaryServerInterceptor(t.clientAuthFunc)),
-		grpc.StreamInterceptor(auth.StreamServerInterceptor(t.clientAuthFunc)))
+	}, t.serverOptions("threads")...)
 	if err != nil {
 		return nil, err
 	}
@@ -135,17 +181,26 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 	if err != nil {
 		return nil, err
 	}
-	threadsClient, err := threadsclient.NewClient(threadsTarget, grpc.WithInsecure(),
-		grpc.WithPerRPCCredentials(c.TokenAuth{}))
+	threadsOpts := append([]grpc.DialOption{grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(c.TokenAuth{})}, tracing.DialOptions()...)
+	threadsClient, err := threadsclient.NewClient(threadsTarget, threadsOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	collections, err := c.NewCollections(ctx, threadsClient, conf.ThreadsInternalToken, ds)
+	threadsConn, err := grpc.Dial(threadsTarget, threadsOpts...)
 	if err != nil {
 		return nil, err
 	}
 
+	collections, db, err := newCollections(ctx, conf, ds, threadsClient)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = collections.Migrate(ctx); err != nil {
+		return nil, err
+	}
+
 	var filecoinClient *fc.Client
 	if conf.AddrFilecoinApi != nil {
 		filecoinClient, err = fc.NewClient(conf.AddrFilecoinApi)
@@ -156,10 +211,32 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 
 	var dnsManager *dns.Manager
 	if conf.DNSToken != "" {
-		dnsManager, err = dns.NewManager(conf.DNSDomain, conf.DNSZoneID, conf.DNSToken, conf.Debug)
+		// Websites are served by the gateway, so their subdomains point at it.
+		gatewayUrl, err := url.Parse(conf.AddrGatewayUrl)
 		if err != nil {
 			return nil, err
 		}
+		dnsManager, err = dns.NewManager(conf.DNSDomain, conf.DNSZoneID, conf.DNSToken, gatewayUrl.Hostname(), conf.Debug)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var tlsConfig *tls.Config
+	if conf.TLS.Enabled() {
+		certsConf := conf.TLS
+		if certsConf.CacheDir == "" {
+			certsConf.CacheDir = path.Join(conf.RepoPath, "certs")
+		}
+		if dnsManager != nil {
+			certsConf.DNS = dnsManager
+		}
+		certManager, err := certs.NewManager(ctx, certsConf)
+		if err != nil {
+			return nil, err
+		}
+		t.certManager = certManager
+		tlsConfig = certManager.TLSConfig()
 	}
 
 	emailClient, err := email.NewClient(
@@ -170,14 +247,29 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 
 	server, err := api.NewServer(ctx, api.Config{
 		Addr:            conf.AddrApi,
+		AddrProxy:       conf.AddrApiProxy,
+		AddrProxyUrl:    conf.AddrApiProxyUrl,
 		AddrGatewayHost: conf.AddrGatewayHost,
 		AddrGatewayUrl:  conf.AddrGatewayUrl,
+		AddrMetrics:     conf.AddrMetrics,
 		Collections:     collections,
-		DNSManager:      dnsManager,
-		EmailClient:     emailClient,
-		FilecoinClient:  filecoinClient,
-		SessionSecret:   conf.SessionSecret,
-		Debug:           conf.Debug,
+		IPFSClient:      ipfs,
+		Stores: &stores{
+			threads: threadsClient,
+			api:     pb.NewAPIClient(threadsConn),
+			token:   conf.ThreadsInternalToken,
+		},
+		DNSManager:     dnsManager,
+		DNSDomain:      conf.DNSDomain,
+		GatewayCORS:    conf.GatewayCORS,
+		GatewayHeaders: conf.GatewayHeaders,
+		RateLimits:     conf.RateLimits,
+		Webhooks:       conf.Webhooks,
+		TLSConfig:      tlsConfig,
+		EmailClient:    emailClient,
+		FilecoinClient: filecoinClient,
+		SessionSecret:  conf.SessionSecret,
+		Debug:          conf.Debug,
 	})
 	if err != nil {
 		return nil, err
@@ -186,6 +278,7 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 	log.Info("started")
 
 	t.ds = ds
+	t.db = db
 	t.collections = collections
 	t.ipfs = ipfs
 	t.threadservice = threadservice
@@ -193,6 +286,7 @@ func NewTextile(ctx context.Context, conf Config) (*Textile, error) {
 	t.threadsServiceServer = serviceServer
 	t.threadsServer = threadsServer
 	t.threadsClient = threadsClient
+	t.threadsConn = threadsConn
 	t.server = server
 
 	return t, nil
@@ -206,6 +300,9 @@ func (t *Textile) Close() error {
 	if err := t.threadsClient.Close(); err != nil {
 		return err
 	}
+	if err := t.threadsConn.Close(); err != nil {
+		return err
+	}
 	if err := t.threadservice.Close(); err != nil {
 		return err
 	}
@@ -213,6 +310,15 @@ func (t *Textile) Close() error {
 	if err := t.server.Close(); err != nil {
 		return err
 	}
+	if t.db != nil {
+		if err := t.db.Close(); err != nil {
+			return err
+		}
+	}
+	if t.certManager != nil {
+		t.certManager.Close()
+	}
+	t.exporter.Close()
 	return t.ds.Close()
 }
 
@@ -220,12 +326,31 @@ func (t *Textile) HostID() peer.ID {
 	return t.threadservice.Host().ID()
 }
 
+// serverOptions returns the interceptors of a threads server, which authorize
+// requests, and trace and record metrics labeled with server.
+func (t *Textile) serverOptions(server string) []grpc.ServerOption {
+	authFunc := metrics.AuthFunc(server, t.clientAuthFunc)
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(grpcmiddleware.ChainUnaryServer(
+			tracing.UnaryServerInterceptor(server),
+			metrics.UnaryServerInterceptor(server),
+			auth.UnaryServerInterceptor(authFunc))),
+		grpc.StreamInterceptor(grpcmiddleware.ChainStreamServer(
+			tracing.StreamServerInterceptor(server),
+			metrics.StreamServerInterceptor(server),
+			auth.StreamServerInterceptor(authFunc))),
+	}
+}
+
 func (t *Textile) clientAuthFunc(ctx context.Context) (context.Context, error) {
 	token, err := auth.AuthFromMD(ctx, "bearer")
 	if err != nil {
 		return nil, err
 	}
 	if token == t.threadsInternalToken {
+		// The daemon polls threads, e.g., for webhook deliveries, so these would flood the log.
+		tracing.Quiet(ctx)
+		tracing.Annotate(ctx, "user", "internal")
 		return ctx, nil
 	}
 
@@ -248,6 +373,8 @@ func (t *Textile) clientAuthFunc(ctx context.Context) (context.Context, error) {
 	if err := t.collections.Sessions.Touch(ctx, session); err != nil {
 		return nil, err
 	}
+	tracing.Annotate(ctx, "user", user.ID)
+	tracing.Annotate(ctx, "project", proj.ID)
 
 	newCtx := context.WithValue(ctx, clientReqKey("user"), user)
 	newCtx = context.WithValue(newCtx, clientReqKey("project"), proj)
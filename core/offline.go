@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path"
+
+	auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	badger "github.com/ipfs/go-ds-badger"
+	threadsapi "github.com/textileio/go-threads/api"
+	threadsclient "github.com/textileio/go-threads/api/client"
+	s "github.com/textileio/go-threads/store"
+	"github.com/textileio/go-threads/util"
+	c "github.com/textileio/textile/collections"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OpenCollections starts only the threads machinery needed to read and write the
+// control-plane collections in conf.RepoPath. It's intended for maintenance tasks
+// that run while the daemon is stopped. Call the returned function when done.
+func OpenCollections(ctx context.Context, conf Config) (*c.Collections, func() error, error) {
+	dsPath := path.Join(conf.RepoPath, "textile")
+	if err := os.MkdirAll(dsPath, os.ModePerm); err != nil {
+		return nil, nil, err
+	}
+	ds, err := badger.NewDatastore(dsPath, &badger.DefaultOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	threadservice, err := s.DefaultService(
+		conf.RepoPath,
+		s.WithServiceHostAddr(conf.AddrThreadsHost),
+		s.WithServiceDebug(conf.Debug))
+	if err != nil {
+		return nil, nil, err
+	}
+	internalAuthFunc := func(ctx context.Context) (context.Context, error) {
+		token, err := auth.AuthFromMD(ctx, "bearer")
+		if err != nil {
+			return nil, err
+		}
+		if token != conf.ThreadsInternalToken {
+			return nil, status.Error(codes.Unauthenticated, "Invalid auth token")
+		}
+		return ctx, nil
+	}
+	threadsServer, err := threadsapi.NewServer(ctx, threadservice, threadsapi.Config{
+		RepoPath:  conf.RepoPath,
+		Addr:      conf.AddrThreadsApi,
+		ProxyAddr: conf.AddrThreadsApiProxy,
+		Debug:     conf.Debug,
+	}, grpc.UnaryInterceptor(auth.UnaryServerInterceptor(internalAuthFunc)),
+		grpc.StreamInterceptor(auth.StreamServerInterceptor(internalAuthFunc)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	threadsTarget, err := util.TCPAddrFromMultiAddr(conf.AddrThreadsApi)
+	if err != nil {
+		return nil, nil, err
+	}
+	threadsClient, err := threadsclient.NewClient(threadsTarget, grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(c.TokenAuth{}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	collections, db, err := newCollections(ctx, conf, ds, threadsClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return collections, func() error {
+		if err := threadsClient.Close(); err != nil {
+			return err
+		}
+		if err := threadservice.Close(); err != nil {
+			return err
+		}
+		threadsServer.Close()
+		if db != nil {
+			if err := db.Close(); err != nil {
+				return err
+			}
+		}
+		return ds.Close()
+	}, nil
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	threadsclient "github.com/textileio/go-threads/api/client"
+	pb "github.com/textileio/go-threads/api/pb"
+	s "github.com/textileio/go-threads/store"
+	c "github.com/textileio/textile/collections"
+	"github.com/textileio/textile/gateway"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stores reads model instances from thread stores.
+type stores struct {
+	threads *threadsclient.Client
+	// api is used directly for listening, since the client's listen
+	// events don't expose their actions.
+	api   pb.APIClient
+	token string
+}
+
+// ModelFind returns the instances of a model matching query.
+func (st *stores) ModelFind(ctx context.Context, storeID, model string, query []byte) ([]json.RawMessage, error) {
+	q := &s.JSONQuery{}
+	if len(query) > 0 {
+		if err := json.Unmarshal(query, q); err != nil {
+			return nil, err
+		}
+	}
+	ctx = c.AuthCtx(ctx, st.token)
+	res, err := st.threads.ModelFind(ctx, storeID, model, q, []*json.RawMessage{})
+	if err != nil {
+		return nil, err
+	}
+	list := res.([]*json.RawMessage)
+	instances := make([]json.RawMessage, len(list))
+	for i, inst := range list {
+		instances[i] = *inst
+	}
+	return instances, nil
+}
+
+// ModelFindByID returns a model instance.
+func (st *stores) ModelFindByID(ctx context.Context, storeID, model, id string) (json.RawMessage, error) {
+	ctx = c.AuthCtx(ctx, st.token)
+	var instance json.RawMessage
+	if err := st.threads.ModelFindByID(ctx, storeID, model, id, &instance); err != nil {
+		if stat, ok := status.FromError(err); ok && stat.Message() == s.ErrNotFound.Error() {
+			return nil, c.ErrNotFound
+		}
+		return nil, err
+	}
+	return instance, nil
+}
+
+// Listen returns a channel of events in a store matching filter.
+func (st *stores) Listen(ctx context.Context, storeID string, filter gateway.ListenFilter) (<-chan gateway.StoreEvent, error) {
+	var action pb.ListenRequest_Filter_Action
+	switch filter.Action {
+	case "":
+		action = pb.ListenRequest_Filter_ALL
+	case "create":
+		action = pb.ListenRequest_Filter_CREATE
+	case "save":
+		action = pb.ListenRequest_Filter_SAVE
+	case "delete":
+		action = pb.ListenRequest_Filter_DELETE
+	default:
+		return nil, fmt.Errorf("unknown action %s", filter.Action)
+	}
+	stream, err := st.api.Listen(c.AuthCtx(ctx, st.token), &pb.ListenRequest{
+		StoreID: storeID,
+		Filters: []*pb.ListenRequest_Filter{{
+			ModelName: filter.Model,
+			EntityID:  filter.InstanceID,
+			Action:    action,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan gateway.StoreEvent)
+	go func() {
+		defer close(events)
+		for {
+			reply, err := stream.Recv()
+			if err != nil {
+				if status.Code(err) != codes.Canceled {
+					log.Errorf("error listening to store %s: %v", storeID, err)
+				}
+				return
+			}
+			e := gateway.StoreEvent{
+				Model:      reply.GetModelName(),
+				InstanceID: reply.GetEntityID(),
+				Instance:   reply.GetEntity(),
+			}
+			switch reply.GetAction() {
+			case pb.ListenReply_CREATE:
+				e.Action = "create"
+			case pb.ListenReply_SAVE:
+				e.Action = "save"
+			case pb.ListenReply_DELETE:
+				e.Action = "delete"
+			}
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
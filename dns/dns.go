@@ -17,7 +17,8 @@ var (
 	log         = logging.Logger("dns")
 )
 
-const ipfsGateway = "www.cloudflare-ipfs.com" // future could be set to project's gateway
+// ipfsGateway is the default target of DNSLink CNAME records.
+const ipfsGateway = "www.cloudflare-ipfs.com"
 const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 
 func init() {
@@ -30,10 +31,11 @@ func init() {
 
 // Manager wraps a CloudflareClient client.
 type Manager struct {
-	api    *cloudflare.API
-	domain string
-	zoneID string
-	debug  bool
+	api     *cloudflare.API
+	domain  string
+	zoneID  string
+	gateway string
+	debug   bool
 }
 
 type Record struct {
@@ -45,7 +47,8 @@ type Record struct {
 }
 
 // NewManager return a cloudflare-backed dns updating client.
-func NewManager(domain string, zoneID string, token string, debug bool) (*Manager, error) {
+// DNSLink subdomains point at gateway, or the Cloudflare IPFS gateway if empty.
+func NewManager(domain string, zoneID string, token string, gateway string, debug bool) (*Manager, error) {
 	if debug {
 		if err := util.SetLogLevels(map[string]logging.LogLevel{
 			"dns": logging.LevelDebug,
@@ -58,11 +61,15 @@ func NewManager(domain string, zoneID string, token string, debug bool) (*Manage
 	if err != nil {
 		return nil, err
 	}
+	if gateway == "" {
+		gateway = ipfsGateway
+	}
 	client := &Manager{
-		domain: domain,
-		debug:  debug,
-		api:    api,
-		zoneID: zoneID,
+		domain:  domain,
+		debug:   debug,
+		api:     api,
+		zoneID:  zoneID,
+		gateway: gateway,
 	}
 
 	return client, nil
@@ -100,7 +107,7 @@ func (m *Manager) NewTXT(name string, content string) (*Record, error) {
 
 // NewDNSLink enters a two dns records to enable DNS link
 func (m *Manager) NewDNSLink(subdomain string, hash string) ([]*Record, error) {
-	cname, err := m.NewCNAME(subdomain, ipfsGateway)
+	cname, err := m.NewCNAME(subdomain, m.gateway)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +167,15 @@ func CreateDNSLinkContent(hash string) string {
 	return fmt.Sprintf("dnslink=/ipfs/%s", hash)
 }
 
+// ParseDNSLinkContent returns the hash in dnslink TXT content created by CreateDNSLinkContent.
+func ParseDNSLinkContent(content string) (string, error) {
+	hash := strings.TrimPrefix(content, "dnslink=/ipfs/")
+	if hash == content || hash == "" {
+		return "", fmt.Errorf("invalid dnslink content %s", content)
+	}
+	return hash, nil
+}
+
 // CreateURLSafeSubdomain returns a url safe subdomain with optional suffix.
 func CreateURLSafeSubdomain(subdomain string, suffix int) (string, error) {
 	var sfx string
@@ -10,6 +10,7 @@ import (
 	logging "github.com/ipfs/go-log"
 	mailgun "github.com/mailgun/mailgun-go/v3"
 	"github.com/textileio/go-threads/util"
+	"github.com/textileio/textile/metrics"
 )
 
 var (
@@ -21,6 +22,7 @@ type Client struct {
 	from            string
 	gun             *mailgun.MailgunImpl
 	verificationTmp *template.Template
+	emailChangeTmp  *template.Template
 	inviteTmp       *template.Template
 	debug           bool
 }
@@ -43,6 +45,10 @@ func NewClient(from, domain, apiKey string, debug bool) (*Client, error) {
 	if err != nil {
 		log.Fatal(err)
 	}
+	ct, err := template.New("emailChange").Parse(emailChangeMsg)
+	if err != nil {
+		log.Fatal(err)
+	}
 	it, err := template.New("invite").Parse(inviteMsg)
 	if err != nil {
 		log.Fatal(err)
@@ -51,6 +57,7 @@ func NewClient(from, domain, apiKey string, debug bool) (*Client, error) {
 	client := &Client{
 		from:            from,
 		verificationTmp: vt,
+		emailChangeTmp:  ct,
 		inviteTmp:       it,
 		debug:           debug,
 	}
@@ -74,7 +81,19 @@ func (e *Client) ConfirmAddress(ctx context.Context, to, url, secret string) err
 		return err
 	}
 
-	return e.send(ctx, to, "Textile Login Verification", tpl.String())
+	return e.send(ctx, "verification", to, "Textile Login Verification", tpl.String())
+}
+
+// ConfirmEmailChange sends a confirmation link to the new email address of a user.
+func (e *Client) ConfirmEmailChange(ctx context.Context, to, url, secret string) error {
+	var tpl bytes.Buffer
+	if err := e.emailChangeTmp.Execute(&tpl, &confirmData{
+		Link: fmt.Sprintf("%s/confirm/%s", url, secret),
+	}); err != nil {
+		return err
+	}
+
+	return e.send(ctx, "email_change", to, "Textile Email Change Verification", tpl.String())
 }
 
 type inviteData struct {
@@ -94,14 +113,15 @@ func (e *Client) InviteAddress(ctx context.Context, team, from, to, url, inviteI
 		return err
 	}
 
-	return e.send(ctx, to, "Textile Team Invitation", tpl.String())
+	return e.send(ctx, "invite", to, "Textile Team Invitation", tpl.String())
 }
 
-// send wraps the MailGun client's send method.
-func (e *Client) send(ctx context.Context, recipient, subject, body string) error {
+// send wraps the MailGun client's send method. Sends are counted by kind.
+func (e *Client) send(ctx context.Context, kind, recipient, subject, body string) error {
 	if e.gun == nil {
 		return nil
 	}
 	_, _, err := e.gun.Send(ctx, e.gun.NewMessage(e.from, subject, body, recipient))
+	metrics.EmailsSent.Inc(kind, metrics.Result(err))
 	return err
 }
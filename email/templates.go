@@ -14,6 +14,14 @@ To complete the login process, follow the link below:
 {{.Link}}
 ` + footerMsg
 
+const emailChangeMsg = headerMsg + `
+To confirm this address as the new email of your Textile account, follow the link below:
+
+{{.Link}}
+
+If you didn’t request this change, simply ignore this email.
+` + footerMsg
+
 const inviteMsg = headerMsg + `
 {{.From}} has invited you to the {{.Team}} team on Textile.
 
@@ -0,0 +1,168 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	gopath "path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	files "github.com/ipfs/go-ipfs-files"
+	iface "github.com/ipfs/interface-go-ipfs-core"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+)
+
+const (
+	// indexFile is served in place of a directory listing if present.
+	indexFile = "index.html"
+
+	// immutableCacheControl is used for content addressed responses.
+	immutableCacheControl = "public, max-age=29030400, immutable"
+)
+
+// link is a directory listing entry.
+type link struct {
+	Name  string
+	Path  string
+	Size  string
+	IsDir bool
+}
+
+// ipfsHandler serves immutable content by CID.
+func (g *Gateway) ipfsHandler(c *gin.Context) {
+	pth := path.New(gopath.Join("/ipfs", c.Param("cid"), c.Param("path")))
+	g.serveContent(c, pth, true)
+}
+
+// ipnsHandler serves mutable content by IPNS name.
+func (g *Gateway) ipnsHandler(c *gin.Context) {
+	pth := path.New(gopath.Join("/ipns", c.Param("name"), c.Param("path")))
+	g.serveContent(c, pth, false)
+}
+
+// serveContent writes the file or directory at pth. Files are served with
+// content type detection and range request support. Directories are served by
+// their index file if present, otherwise they are listed.
+func (g *Gateway) serveContent(c *gin.Context, pth path.Path, immutable bool) {
+	if g.ipfs == nil {
+		g.renderError(c, newError(http.StatusServiceUnavailable, CodeUnavailable, "Content is not available"))
+		return
+	}
+	if err := pth.IsValid(); err != nil {
+		g.renderError(c, &Error{
+			Status:  http.StatusBadRequest,
+			Code:    CodeBadRequest,
+			Message: "Invalid path",
+			Err:     err,
+		})
+		return
+	}
+
+	rctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	defer cancel()
+	resolved, err := g.ipfs.ResolvePath(rctx, pth)
+	if err != nil {
+		g.render404(c)
+		return
+	}
+
+	etag := `"` + resolved.Cid().String() + `"`
+	if immutable {
+		c.Header("Cache-Control", immutableCacheControl)
+	}
+	c.Header("Etag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	// File reads are streamed for as long as the request lives.
+	node, err := g.ipfs.Unixfs().Get(c.Request.Context(), resolved)
+	if err != nil {
+		g.renderError(c, err)
+		return
+	}
+	defer node.Close()
+	switch n := node.(type) {
+	case files.File:
+		http.ServeContent(c.Writer, c.Request, gopath.Base(pth.String()), time.Time{}, n)
+	case files.Directory:
+		g.serveDirectory(c, pth, resolved)
+	default:
+		g.renderError(c, newError(http.StatusUnsupportedMediaType, CodeUnsupportedMedia, "Unsupported content type"))
+	}
+}
+
+// serveDirectory writes the index file of a directory, or a listing if there is none.
+func (g *Gateway) serveDirectory(c *gin.Context, pth path.Path, resolved path.Resolved) {
+	reqPath := c.Request.URL.Path
+	if !strings.HasSuffix(reqPath, "/") {
+		c.Redirect(http.StatusMovedPermanently, reqPath+"/")
+		return
+	}
+
+	index, err := g.ipfs.Unixfs().Get(c.Request.Context(), path.Join(resolved, indexFile))
+	if err == nil {
+		defer index.Close()
+		if f, ok := index.(files.File); ok {
+			http.ServeContent(c.Writer, c.Request, indexFile, time.Time{}, f)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	defer cancel()
+	entries, err := g.ipfs.Unixfs().Ls(ctx, resolved)
+	if err != nil {
+		g.renderError(c, err)
+		return
+	}
+	var links []link
+	for e := range entries {
+		if e.Err != nil {
+			g.renderError(c, e.Err)
+			return
+		}
+		l := link{
+			Name:  e.Name,
+			Path:  gopath.Join(reqPath, e.Name),
+			Size:  byteCountDecimal(int64(e.Size)),
+			IsDir: e.Type == iface.TDirectory,
+		}
+		if l.IsDir {
+			l.Path += "/"
+			l.Size = ""
+		}
+		links = append(links, l)
+	}
+
+	var back string
+	if reqPath != "/" {
+		back = gopath.Dir(strings.TrimSuffix(reqPath, "/"))
+		if back != "/" {
+			back += "/"
+		}
+	}
+	c.HTML(http.StatusOK, "/public/html/directory.gohtml", gin.H{
+		"Title": pth.String(),
+		"Root":  resolved.Cid().String(),
+		"Back":  back,
+		"Links": links,
+	})
+}
+
+// byteCountDecimal formats a size in bytes for display.
+func byteCountDecimal(b int64) string {
+	const unit = 1000
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "kMGTPE"[exp])
+}
@@ -0,0 +1,229 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	gopath "path"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	files "github.com/ipfs/go-ipfs-files"
+	iface "github.com/ipfs/interface-go-ipfs-core"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	"github.com/phayes/freeport"
+	"github.com/textileio/textile/util"
+)
+
+const (
+	rootCid = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+	textCid = "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"
+	pageCid = "QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn"
+	siteCid = "QmdfTbBqBPQ7VNxZEYEj14VmRuZBkqFbiwReogJgS1zR1n"
+)
+
+// fakeIPFS serves a fixed tree under rootCid. Only the methods used by the
+// gateway are implemented.
+type fakeIPFS struct {
+	iface.CoreAPI
+}
+
+func (fakeIPFS) ResolvePath(_ context.Context, p path.Path) (path.Resolved, error) {
+	resolved := map[string]string{
+		"/ipfs/" + rootCid + "/hello.txt": textCid,
+		"/ipfs/" + rootCid + "/page":      pageCid,
+		"/ipfs/" + rootCid + "/site":      siteCid,
+		"/ipfs/" + siteCid:                siteCid,
+	}
+	id, ok := resolved[gopath.Clean(p.String())]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", p)
+	}
+	c, err := cid.Decode(id)
+	if err != nil {
+		return nil, err
+	}
+	return path.IpfsPath(c), nil
+}
+
+func (fakeIPFS) Unixfs() iface.UnixfsAPI {
+	return fakeUnixfs{}
+}
+
+// file is a seekable files.File, like those of the IPFS HTTP client.
+type file struct {
+	*bytes.Reader
+}
+
+func newFile(data []byte) files.File {
+	return file{Reader: bytes.NewReader(data)}
+}
+
+func (f file) Size() (int64, error) {
+	return f.Reader.Size(), nil
+}
+
+func (file) Close() error {
+	return nil
+}
+
+type fakeUnixfs struct {
+	iface.UnixfsAPI
+}
+
+func (fakeUnixfs) Get(_ context.Context, p path.Path) (files.Node, error) {
+	switch gopath.Clean(p.String()) {
+	case "/ipfs/" + textCid:
+		return newFile([]byte("hello world")), nil
+	case "/ipfs/" + pageCid:
+		return newFile([]byte("<html><body>page</body></html>")), nil
+	case "/ipfs/" + siteCid:
+		return files.NewMapDirectory(map[string]files.Node{
+			indexFile: newFile([]byte("<html><body>index</body></html>")),
+		}), nil
+	case "/ipfs/" + siteCid + "/" + indexFile:
+		return newFile([]byte("<html><body>index</body></html>")), nil
+	case "/ipfs/" + siteCid + "/" + notFoundFile:
+		return newFile([]byte("<html><body>custom 404</body></html>")), nil
+	default:
+		return nil, fmt.Errorf("%s not found", p)
+	}
+}
+
+func TestGateway_Content(t *testing.T) {
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{
+		Addr:       util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)),
+		Url:        url,
+		IPFSClient: fakeIPFS{},
+	})
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, url)
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		status  int
+		ctype   string
+		body    string
+		expect  map[string]string
+	}{
+		{
+			name:   "content type from extension",
+			path:   "/ipfs/" + rootCid + "/hello.txt",
+			status: http.StatusOK,
+			ctype:  "text/plain; charset=utf-8",
+			body:   "hello world",
+			expect: map[string]string{
+				"Cache-Control": immutableCacheControl,
+				"Etag":          `"` + textCid + `"`,
+				"Accept-Ranges": "bytes",
+			},
+		},
+		{
+			name:   "content type from content",
+			path:   "/ipfs/" + rootCid + "/page",
+			status: http.StatusOK,
+			ctype:  "text/html; charset=utf-8",
+			body:   "<html><body>page</body></html>",
+		},
+		{
+			name:    "range",
+			path:    "/ipfs/" + rootCid + "/hello.txt",
+			headers: map[string]string{"Range": "bytes=0-4"},
+			status:  http.StatusPartialContent,
+			ctype:   "text/plain; charset=utf-8",
+			body:    "hello",
+			expect:  map[string]string{"Content-Range": "bytes 0-4/11"},
+		},
+		{
+			name:    "suffix range",
+			path:    "/ipfs/" + rootCid + "/hello.txt",
+			headers: map[string]string{"Range": "bytes=-5"},
+			status:  http.StatusPartialContent,
+			body:    "world",
+			expect:  map[string]string{"Content-Range": "bytes 6-10/11"},
+		},
+		{
+			name:    "unsatisfiable range",
+			path:    "/ipfs/" + rootCid + "/hello.txt",
+			headers: map[string]string{"Range": "bytes=100-200"},
+			status:  http.StatusRequestedRangeNotSatisfiable,
+			expect:  map[string]string{"Content-Range": "bytes */11"},
+		},
+		{
+			name:    "not modified",
+			path:    "/ipfs/" + rootCid + "/hello.txt",
+			headers: map[string]string{"If-None-Match": `"` + textCid + `"`},
+			status:  http.StatusNotModified,
+		},
+		{
+			name:   "directory redirect",
+			path:   "/ipfs/" + rootCid + "/site",
+			status: http.StatusMovedPermanently,
+			expect: map[string]string{"Location": "/ipfs/" + rootCid + "/site/"},
+		},
+		{
+			name:   "directory index",
+			path:   "/ipfs/" + rootCid + "/site/",
+			status: http.StatusOK,
+			ctype:  "text/html; charset=utf-8",
+			body:   "<html><body>index</body></html>",
+		},
+		{
+			name:   "missing",
+			path:   "/ipfs/" + rootCid + "/missing",
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Accept", "application/json")
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			res, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, res.StatusCode)
+			}
+			if tt.ctype != "" && res.Header.Get("Content-Type") != tt.ctype {
+				t.Fatalf("expected content type %s, got %s", tt.ctype, res.Header.Get("Content-Type"))
+			}
+			for k, v := range tt.expect {
+				if res.Header.Get(k) != v {
+					t.Fatalf("expected %s %q, got %q", k, v, res.Header.Get(k))
+				}
+			}
+			if tt.body != "" {
+				body, err := ioutil.ReadAll(res.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(body) != tt.body {
+					t.Fatalf("expected body %q, got %q", tt.body, body)
+				}
+			}
+		})
+	}
+}
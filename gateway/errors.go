@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/textileio/textile/collections"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Stable error codes returned to clients.
+const (
+	CodeBadRequest       = "bad_request"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeExpired          = "expired"
+	CodeTooManyRequests  = "too_many_requests"
+	CodeUnsupportedMedia = "unsupported_media_type"
+	CodeUnavailable      = "unavailable"
+	CodeTimeout          = "timeout"
+	CodeInternal         = "internal"
+)
+
+// Error is a gateway error with an HTTP status, a stable code, and a message
+// that's safe to show clients. The underlying cause is logged but never sent.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Err     error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError returns an error with status, code, and public message msg.
+func newError(status int, code, msg string) *Error {
+	return &Error{Status: status, Code: code, Message: msg}
+}
+
+var (
+	errNotFound        = newError(http.StatusNotFound, CodeNotFound, "Not found")
+	errTooManyRequests = newError(http.StatusTooManyRequests, CodeTooManyRequests, "Too many requests")
+	errTimeout         = newError(http.StatusGatewayTimeout, CodeTimeout, "Request timed out")
+	errInternal        = newError(http.StatusInternalServerError, CodeInternal, "Internal error")
+)
+
+// publicError maps err to a gateway error. Collection and threads errors are
+// mapped by kind, and anything else is an internal error.
+func publicError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	if errors.Is(err, collections.ErrNotFound) {
+		return errNotFound
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errTimeout
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.NotFound:
+			return errNotFound
+		case codes.InvalidArgument:
+			return newError(http.StatusBadRequest, CodeBadRequest, "Invalid request")
+		case codes.Unauthenticated:
+			return newError(http.StatusUnauthorized, CodeUnauthorized, "Unauthorized")
+		case codes.PermissionDenied:
+			return newError(http.StatusForbidden, CodeForbidden, "Forbidden")
+		case codes.DeadlineExceeded:
+			return errTimeout
+		case codes.Unavailable:
+			return newError(http.StatusServiceUnavailable, CodeUnavailable, "Service unavailable")
+		}
+	}
+	return errInternal
+}
+
+// render404 responds with a not found error.
+func (g *Gateway) render404(c *gin.Context) {
+	g.renderError(c, errNotFound)
+}
+
+// renderError responds with err, preferring the HTML error pages
+// unless the client only accepts JSON.
+func (g *Gateway) renderError(c *gin.Context, err error) {
+	respondError(c, err, gin.MIMEHTML, gin.MIMEJSON)
+}
+
+// abort responds with err, preferring JSON unless the client only accepts HTML.
+func abort(c *gin.Context, err error) {
+	respondError(c, err, gin.MIMEJSON, gin.MIMEHTML)
+}
+
+// respondError aborts the request with the public form of err in the format
+// negotiated from offered. Internal errors are logged, and err is attached to
+// the request's log entry.
+func respondError(c *gin.Context, err error, offered ...string) {
+	_ = c.Error(err)
+	e := publicError(err)
+	if e.Status >= http.StatusInternalServerError {
+		log.Errorf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	if c.NegotiateFormat(offered...) == gin.MIMEHTML {
+		c.Abort()
+		if e.Status == http.StatusNotFound {
+			c.HTML(e.Status, "/public/html/404.gohtml", nil)
+			return
+		}
+		c.HTML(e.Status, "/public/html/error.gohtml", gin.H{
+			"Code":  e.Status,
+			"Error": e.Message,
+		})
+		return
+	}
+	c.AbortWithStatusJSON(e.Status, gin.H{
+		"code":  e.Code,
+		"error": e.Message,
+	})
+}
@@ -0,0 +1,113 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/phayes/freeport"
+	"github.com/textileio/textile/collections"
+	"github.com/textileio/textile/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPublicError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"gateway error", newError(http.StatusForbidden, CodeForbidden, "Nope"), http.StatusForbidden, CodeForbidden},
+		{"wrapped gateway error", fmt.Errorf("wrapped: %w", errTooManyRequests), http.StatusTooManyRequests, CodeTooManyRequests},
+		{"collection not found", fmt.Errorf("get: %w", collections.ErrNotFound), http.StatusNotFound, CodeNotFound},
+		{"deadline", context.DeadlineExceeded, http.StatusGatewayTimeout, CodeTimeout},
+		{"threads not found", status.Error(codes.NotFound, "instance not found"), http.StatusNotFound, CodeNotFound},
+		{"threads internal", status.Error(codes.Internal, "badger: closed"), http.StatusInternalServerError, CodeInternal},
+		{"other", errors.New("dial tcp 10.0.0.1:5001: refused"), http.StatusInternalServerError, CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := publicError(tt.err)
+			if e.Status != tt.status || e.Code != tt.code {
+				t.Fatalf("expected %d %s, got %d %s", tt.status, tt.code, e.Status, e.Code)
+			}
+			if e.Err != nil {
+				t.Fatal("public error must not carry a cause from mapping")
+			}
+		})
+	}
+}
+
+func TestGateway_ErrorNegotiation(t *testing.T) {
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{
+		Addr: util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)),
+		Url:  url,
+	})
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, url)
+
+	tests := []struct {
+		name   string
+		path   string
+		accept string
+		status int
+		html   bool
+	}{
+		{"html route in browser", "/ipfs/foo", "text/html,*/*;q=0.8", http.StatusServiceUnavailable, true},
+		{"html route as json", "/ipfs/foo", "application/json", http.StatusServiceUnavailable, false},
+		{"json route by default", "/stores/foo/models/bar", "", http.StatusServiceUnavailable, false},
+		{"json route in browser", "/stores/foo/models/bar", "text/html", http.StatusServiceUnavailable, true},
+		{"not found as json", "/foo", "application/json", http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, res.StatusCode)
+			}
+			if res.Header.Get("X-Request-Id") == "" {
+				t.Fatal("expected a request ID")
+			}
+			ctype := res.Header.Get("Content-Type")
+			if tt.html {
+				if !strings.HasPrefix(ctype, "text/html") {
+					t.Fatalf("expected html, got %s", ctype)
+				}
+				return
+			}
+			var body struct {
+				Code  string `json:"code"`
+				Error string `json:"error"`
+			}
+			if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Code == "" || body.Error == "" {
+				t.Fatalf("expected code and error, got %+v", body)
+			}
+		})
+	}
+}
@@ -2,24 +2,30 @@ package gateway
 
 import (
 	"context"
-	"fmt"
+	"crypto/tls"
 	"html/template"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-contrib/location"
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
 	logger "github.com/ipfs/go-log"
+	iface "github.com/ipfs/interface-go-ipfs-core"
 	assets "github.com/jessevdk/go-assets"
 	ma "github.com/multiformats/go-multiaddr"
-	"github.com/rs/cors"
-	gincors "github.com/rs/cors/wrapper/gin"
 	"github.com/textileio/go-threads/broadcast"
 	"github.com/textileio/go-threads/util"
 	"github.com/textileio/textile/collections"
+	"github.com/textileio/textile/metrics"
+	"github.com/textileio/textile/ratelimit"
+	"github.com/textileio/textile/tracing"
+	"github.com/textileio/textile/webhooks"
 )
 
 const handlerTimeout = 5 * time.Second
@@ -44,20 +50,78 @@ func (f *fileSystem) Exists(prefix, path string) bool {
 
 // Gateway provides HTTP-based access to Textile.
 type Gateway struct {
-	addr        ma.Multiaddr
-	url         string
-	server      *http.Server
-	collections *collections.Collections
-	sessionBus  *broadcast.Broadcaster
+	addr         ma.Multiaddr
+	url          string
+	server       *http.Server
+	collections  *collections.Collections
+	ipfs         iface.CoreAPI
+	stores       Stores
+	dnsDomain    string
+	resolver     txtResolver
+	apiUrl       string
+	cors         CORSConfig
+	headers      HeadersConfig
+	ipLimiter    *ratelimit.Limiter
+	tokenLimiter *ratelimit.Limiter
+	tlsConfig    *tls.Config
+	sessionBus   *broadcast.Broadcaster
+	webhooks     *webhooks.Dispatcher
+
+	ticketsLock sync.Mutex
+	tickets     map[string]*listenTicket
+}
+
+// Config specifies gateway settings.
+type Config struct {
+	Addr        ma.Multiaddr
+	Url         string
+	Collections *collections.Collections
+
+	// IPFSClient serves content. Content routes are unavailable if nil.
+	IPFSClient iface.CoreAPI
+	// Stores reads thread store data. Store routes are unavailable if nil.
+	Stores Stores
+	// DNSDomain is the parent domain of websites, which are served from the
+	// subdomains created by dns.Manager.NewDNSLink. A website's content is
+	// the IPFS path in its DNSLink record. Websites are unavailable if empty.
+	DNSDomain string
+
+	// ApiUrl is the public URL of the API's REST proxy, which is called by
+	// the dashboard. The dashboard is unavailable if empty.
+	ApiUrl string
+
+	// CORS specifies which cross-origin requests are allowed.
+	CORS CORSConfig
+	// Headers specifies security headers added to all responses.
+	Headers HeadersConfig
+	// RateLimits limits unauthenticated requests by client IP address
+	// and app user registrations by app token.
+	RateLimits ratelimit.Config
+	// TLSConfig enables TLS if not nil.
+	TLSConfig *tls.Config
+	// Webhooks queues events for project webhooks. Events are dropped if nil.
+	Webhooks *webhooks.Dispatcher
 }
 
 // NewGateway returns a new gateway.
-func NewGateway(addr ma.Multiaddr, url string, collections *collections.Collections) *Gateway {
+func NewGateway(conf Config) *Gateway {
 	return &Gateway{
-		addr:        addr,
-		url:         url,
-		collections: collections,
-		sessionBus:  broadcast.NewBroadcaster(0),
+		addr:         conf.Addr,
+		url:          conf.Url,
+		collections:  conf.Collections,
+		ipfs:         conf.IPFSClient,
+		stores:       conf.Stores,
+		dnsDomain:    conf.DNSDomain,
+		resolver:     net.DefaultResolver,
+		apiUrl:       conf.ApiUrl,
+		cors:         conf.CORS,
+		headers:      conf.Headers,
+		ipLimiter:    ratelimit.NewLimiter(conf.RateLimits.IP),
+		tokenLimiter: ratelimit.NewLimiter(conf.RateLimits.Token),
+		tlsConfig:    conf.TLSConfig,
+		sessionBus:   broadcast.NewBroadcaster(0),
+		webhooks:     conf.Webhooks,
+		tickets:      make(map[string]*listenTicket),
 	}
 }
 
@@ -69,12 +133,15 @@ func (g *Gateway) Start() {
 	}
 
 	gin.SetMode(gin.ReleaseMode)
-	router := gin.Default()
+	// Requests are logged by traceRequest rather than gin's logger.
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(traceRequest)
+	router.Use(metrics.Gin)
 	router.Use(location.Default())
 
-	// @todo: Config based headers
-	options := cors.Options{}
-	router.Use(gincors.New(options))
+	router.Use(g.securityHeaders)
+	router.Use(g.corsHandler())
 
 	temp, err := loadTemplate()
 	if err != nil {
@@ -82,29 +149,53 @@ func (g *Gateway) Start() {
 	}
 	router.SetHTMLTemplate(temp)
 
+	// Websites take precedence over all other routes.
+	router.Use(g.subdomainHandler)
+
 	router.Use(static.Serve("", &fileSystem{Assets}))
 
 	router.GET("/health", func(c *gin.Context) {
 		c.Writer.WriteHeader(http.StatusNoContent)
 	})
 
-	router.GET("/confirm/:secret", g.confirmEmail)
-	router.GET("/consent/:invite", g.consentInvite)
+	router.GET("/confirm/:secret", g.limitIP, g.confirmEmail)
+	router.GET("/consent/:invite", g.limitIP, g.consentInvite)
+
+	router.POST("/register", g.limitIP, g.registerAppUser)
+
+	router.GET("/dashboard", g.dashboardHandler)
 
-	router.POST("/register", g.registerAppUser)
+	router.GET("/ipfs/:cid", g.ipfsHandler)
+	router.GET("/ipfs/:cid/*path", g.ipfsHandler)
+	router.HEAD("/ipfs/:cid", g.ipfsHandler)
+	router.HEAD("/ipfs/:cid/*path", g.ipfsHandler)
+	router.GET("/ipns/:name", g.ipnsHandler)
+	router.GET("/ipns/:name/*path", g.ipnsHandler)
+	router.HEAD("/ipns/:name", g.ipnsHandler)
+	router.HEAD("/ipns/:name/*path", g.ipnsHandler)
+
+	router.GET("/stores/:id/listen", g.storeListenHandler)
+	router.POST("/stores/:id/listen/ticket", g.listenTicketHandler)
+	router.GET("/stores/:id/models/:model", g.storeModelHandler)
+	router.GET("/stores/:id/models/:model/:instance", g.storeInstanceHandler)
 
 	router.NoRoute(func(c *gin.Context) {
 		g.render404(c)
 	})
 
 	g.server = &http.Server{
-		Addr:    addr,
-		Handler: router,
+		Addr:      addr,
+		Handler:   router,
+		TLSConfig: g.tlsConfig,
 	}
 
 	errc := make(chan error)
 	go func() {
-		errc <- g.server.ListenAndServe()
+		if g.tlsConfig != nil {
+			errc <- g.server.ListenAndServeTLS("", "")
+		} else {
+			errc <- g.server.ListenAndServe()
+		}
 		close(errc)
 	}()
 	go func() {
@@ -162,7 +253,7 @@ func (g *Gateway) confirmEmail(c *gin.Context) {
 		return
 	}
 	if err := g.sessionBus.Send(secret); err != nil {
-		g.renderError(c, http.StatusInternalServerError, err)
+		g.renderError(c, err)
 		return
 	}
 
@@ -171,7 +262,7 @@ func (g *Gateway) confirmEmail(c *gin.Context) {
 
 // consentInvite adds a user to a team.
 func (g *Gateway) consentInvite(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
 	defer cancel()
 
 	inviteID := c.Param("invite")
@@ -185,25 +276,15 @@ func (g *Gateway) consentInvite(c *gin.Context) {
 		return
 	}
 	if invite.Expiry < int(time.Now().Unix()) {
-		g.renderError(c, http.StatusPreconditionFailed, fmt.Errorf("this invitation has expired"))
+		g.renderError(c, newError(http.StatusPreconditionFailed, CodeExpired, "This invitation has expired"))
 		return
 	}
 
-	matches, err := g.collections.Users.GetByEmail(ctx, invite.ToEmail)
+	user, err := g.collections.Users.GetOrCreate(ctx, invite.ToEmail)
 	if err != nil {
-		g.renderError(c, http.StatusInternalServerError, err)
+		g.renderError(c, err)
 		return
 	}
-	var user *collections.User
-	if len(matches) == 0 {
-		user, err = g.collections.Users.Create(ctx, invite.ToEmail)
-		if err != nil {
-			g.renderError(c, http.StatusInternalServerError, err)
-			return
-		}
-	} else {
-		user = matches[0]
-	}
 
 	team, err := g.collections.Teams.Get(ctx, invite.TeamID)
 	if err != nil {
@@ -211,9 +292,17 @@ func (g *Gateway) consentInvite(c *gin.Context) {
 		return
 	}
 	if err = g.collections.Users.JoinTeam(ctx, user, team.ID); err != nil {
-		g.renderError(c, http.StatusInternalServerError, err)
+		g.renderError(c, err)
 		return
 	}
+	g.audit(ctx, c, &collections.AuditEvent{
+		ActorID: user.ID, Scope: team.ID, Action: collections.ActionInviteAccept, TargetID: invite.ID})
+	if err = g.webhooks.EnqueueScope(ctx, team.ID, collections.EventTeamMemberJoin, gin.H{
+		"team_id": team.ID,
+		"user_id": user.ID,
+	}); err != nil {
+		log.Errorf("queueing %s webhooks: %v", collections.EventTeamMemberJoin, err)
+	}
 
 	c.HTML(http.StatusOK, "/public/html/consent.gohtml", gin.H{
 		"Team": team.Name,
@@ -230,34 +319,56 @@ func (g *Gateway) registerAppUser(c *gin.Context) {
 	var params registrationParams
 	err := c.BindJSON(&params)
 	if err != nil {
-		abort(c, http.StatusBadRequest, err)
+		abort(c, &Error{
+			Status:  http.StatusBadRequest,
+			Code:    CodeBadRequest,
+			Message: "Token and device_id are required",
+			Err:     err,
+		})
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
 	defer cancel()
 
+	if !g.tokenLimiter.Allow(params.Token) {
+		abort(c, errTooManyRequests)
+		return
+	}
 	token, err := g.collections.AppTokens.Get(ctx, params.Token)
 	if err != nil {
-		abort(c, http.StatusNotFound, fmt.Errorf("token not found"))
+		abort(c, newError(http.StatusNotFound, CodeNotFound, "Token not found"))
+		return
+	}
+	if origin := c.GetHeader("Origin"); origin != "" && !g.allowTokenOrigin(token, origin) {
+		abort(c, newError(http.StatusForbidden, CodeForbidden, "Origin not allowed"))
 		return
 	}
 	proj, err := g.collections.Projects.Get(ctx, token.ProjectID)
 	if err != nil {
-		abort(c, http.StatusNotFound, fmt.Errorf("project not found"))
+		abort(c, newError(http.StatusNotFound, CodeNotFound, "Project not found"))
 		return
 	}
 	user, err := g.collections.AppUsers.GetOrCreate(ctx, proj.ID, params.DeviceID)
 	if err != nil {
-		abort(c, http.StatusInternalServerError, err)
+		abort(c, err)
 		return
 	}
 
 	session, err := g.collections.Sessions.Create(ctx, user.ID, user.ID)
 	if err != nil {
-		abort(c, http.StatusInternalServerError, err)
+		abort(c, err)
 		return
 	}
+	g.audit(ctx, c, &collections.AuditEvent{
+		ActorID: user.ID, Scope: proj.Scope, Action: collections.ActionAppUserRegister, TargetID: session.ID})
+	if err = g.webhooks.Enqueue(ctx, proj.ID, collections.EventAppUserRegister, gin.H{
+		"project_id":  proj.ID,
+		"app_user_id": user.ID,
+		"created":     user.Created,
+	}); err != nil {
+		log.Errorf("queueing %s webhooks: %v", collections.EventAppUserRegister, err)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"id":         user.ID,
@@ -265,23 +376,76 @@ func (g *Gateway) registerAppUser(c *gin.Context) {
 	})
 }
 
-// render404 renders the 404 template.
-func (g *Gateway) render404(c *gin.Context) {
-	c.HTML(http.StatusNotFound, "/public/html/404.gohtml", nil)
+// audit records an event with the client IP address of the request.
+// Failures are only logged since the audited action was already taken.
+func (g *Gateway) audit(ctx context.Context, c *gin.Context, e *collections.AuditEvent) {
+	e.IP = remoteIP(c.Request)
+	if err := g.collections.AuditEvents.Create(ctx, e); err != nil {
+		log.Errorf("recording %s audit event: %v", e.Action, err)
+	}
 }
 
-// renderError renders the error template.
-func (g *Gateway) renderError(c *gin.Context, code int, err error) {
-	c.HTML(code, "/public/html/error.gohtml", gin.H{
-		"Code":  code,
-		"Error": formatError(err),
-	})
+// limitIP aborts requests from client IP addresses that exceed their rate limit.
+func (g *Gateway) limitIP(c *gin.Context) {
+	if !g.ipLimiter.Allow(remoteIP(c.Request)) {
+		abort(c, errTooManyRequests)
+	}
 }
 
-// abort the request with code and error.
-func abort(c *gin.Context, code int, err error) {
-	c.AbortWithStatusJSON(code, gin.H{
-		"error": err.Error(),
+// remoteIP returns the IP address of the peer that sent r. Unlike
+// gin.Context.ClientIP, forwarding headers are ignored since any client can
+// set them.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// allowTokenOrigin returns whether or not origin may register app users with
+// token. The gateway config applies if the token doesn't restrict origins.
+func (g *Gateway) allowTokenOrigin(token *collections.AppToken, origin string) bool {
+	if len(token.AllowedOrigins) == 0 {
+		return g.allowOrigin(origin)
+	}
+	for _, o := range token.AllowedOrigins {
+		if strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// traceRequest traces and logs a request, continuing the caller's trace if
+// any. The request ID is returned in the X-Request-ID header.
+func traceRequest(c *gin.Context) {
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	ctx, r := tracing.StartRequest(c.Request.Context(), "gateway",
+		c.Request.Method+" "+route, c.GetHeader(tracing.TraceparentHeader))
+	c.Request = c.Request.WithContext(ctx)
+	c.Header(tracing.RequestIDHeader, r.ID())
+	c.Next()
+
+	var err error
+	if len(c.Errors) > 0 {
+		err = c.Errors.Last()
+	}
+	r.Finish(strconv.Itoa(c.Writer.Status()), err)
+}
+
+// dashboardHandler renders the dashboard, which runs in the browser against
+// the API's REST proxy.
+func (g *Gateway) dashboardHandler(c *gin.Context) {
+	if g.apiUrl == "" {
+		g.render404(c)
+		return
+	}
+	c.HTML(http.StatusOK, "/public/html/dashboard.gohtml", gin.H{
+		"ApiUrl": g.apiUrl,
 	})
 }
 
@@ -303,10 +467,3 @@ func loadTemplate() (*template.Template, error) {
 	}
 	return t, nil
 }
-
-// formatError formats a go error for browser display.
-func formatError(err error) string {
-	words := strings.SplitN(err.Error(), " ", 2)
-	words[0] = strings.Title(words[0])
-	return strings.Join(words, " ") + "."
-}
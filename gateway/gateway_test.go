@@ -2,9 +2,12 @@ package gateway
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/phayes/freeport"
+	"github.com/textileio/textile/ratelimit"
 	"github.com/textileio/textile/util"
 )
 
@@ -15,7 +18,7 @@ func TestNewGateway(t *testing.T) {
 	}
 	addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
 	url := fmt.Sprintf("http://127.0.0.1:%d", port)
-	gateway := NewGateway(addr, url, nil)
+	gateway := NewGateway(Config{Addr: addr, Url: url})
 
 	t.Run("test start", func(t *testing.T) {
 		gateway.Start()
@@ -31,3 +34,37 @@ func TestNewGateway(t *testing.T) {
 		}
 	})
 }
+
+func TestGateway_limitIP(t *testing.T) {
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{
+		Addr:       util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)),
+		Url:        url,
+		RateLimits: ratelimit.Config{IP: ratelimit.Limit{Every: time.Hour, Burst: 1}},
+	})
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, url)
+
+	// Forwarding headers are set by clients, so they mustn't reset the limit.
+	for i, ip := range []string{"1.1.1.1", "2.2.2.2"} {
+		req, err := http.NewRequest(http.MethodGet, url+"/confirm/secret", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Forwarded-For", ip)
+		req.Header.Set("X-Real-Ip", ip)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if i == 1 && res.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, res.StatusCode)
+		}
+	}
+}
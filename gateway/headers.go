@@ -0,0 +1,83 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/cors"
+	gincors "github.com/rs/cors/wrapper/gin"
+)
+
+// CORSConfig specifies which cross-origin requests are allowed.
+type CORSConfig struct {
+	// AllowedOrigins may contain "*". All origins are allowed if empty.
+	AllowedOrigins []string
+	// AllowedMethods defaults to GET, POST, and HEAD if empty.
+	AllowedMethods []string
+	// AllowedHeaders defaults to Origin, Accept, Content-Type, and
+	// X-Requested-With if empty.
+	AllowedHeaders []string
+}
+
+// HeadersConfig specifies security headers added to all responses.
+// Empty values are not sent.
+type HeadersConfig struct {
+	// HSTSMaxAge is the max-age of the Strict-Transport-Security header.
+	HSTSMaxAge time.Duration
+	// ContentSecurityPolicy is the value of the Content-Security-Policy header.
+	ContentSecurityPolicy string
+	// FrameOptions is the value of the X-Frame-Options header.
+	FrameOptions string
+}
+
+// corsHandler returns middleware handling cross-origin requests.
+func (g *Gateway) corsHandler() gin.HandlerFunc {
+	return gincors.New(cors.Options{
+		AllowOriginRequestFunc: g.allowOriginRequest,
+		AllowedMethods:         g.cors.AllowedMethods,
+		AllowedHeaders:         g.cors.AllowedHeaders,
+	})
+}
+
+// allowOriginRequest returns whether or not a request from origin is allowed.
+// App user registration is allowed from any origin here, since it can only be
+// checked against the app token's allowed origins by registerAppUser.
+func (g *Gateway) allowOriginRequest(r *http.Request, origin string) bool {
+	if r.URL.Path == "/register" {
+		return true
+	}
+	return g.allowOrigin(origin)
+}
+
+// allowOrigin returns whether or not origin is allowed by the gateway config.
+func (g *Gateway) allowOrigin(origin string) bool {
+	if len(g.cors.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, o := range g.cors.AllowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// securityHeaders adds the configured security headers to responses.
+func (g *Gateway) securityHeaders(c *gin.Context) {
+	h := c.Writer.Header()
+	h.Set("X-Content-Type-Options", "nosniff")
+	if g.headers.HSTSMaxAge > 0 {
+		h.Set("Strict-Transport-Security",
+			fmt.Sprintf("max-age=%d; includeSubDomains", int64(g.headers.HSTSMaxAge.Seconds())))
+	}
+	if g.headers.ContentSecurityPolicy != "" {
+		h.Set("Content-Security-Policy", g.headers.ContentSecurityPolicy)
+	}
+	if g.headers.FrameOptions != "" {
+		h.Set("X-Frame-Options", g.headers.FrameOptions)
+	}
+	c.Next()
+}
@@ -0,0 +1,103 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/phayes/freeport"
+	"github.com/textileio/textile/collections/memory"
+	"github.com/textileio/textile/util"
+)
+
+func TestGateway_Origins(t *testing.T) {
+	ctx := context.Background()
+	cols := memory.NewCollections(nil)
+	user, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj, err := cols.Projects.Create(ctx, "foo", user.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	openToken, err := cols.AppTokens.Create(ctx, proj.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appToken, err := cols.AppTokens.Create(ctx, proj.ID, []string{"https://app.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{
+		Addr:        addr,
+		Url:         url,
+		Collections: cols,
+		CORS:        CORSConfig{AllowedOrigins: []string{"https://textile.io"}},
+		Headers:     HeadersConfig{FrameOptions: "DENY"},
+	})
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, url)
+
+	t.Run("security headers", func(t *testing.T) {
+		res, err := http.Get(url + "/health")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.Header.Get("X-Frame-Options") != "DENY" {
+			t.Errorf("expected X-Frame-Options DENY, got %s", res.Header.Get("X-Frame-Options"))
+		}
+		if res.Header.Get("Strict-Transport-Security") != "" {
+			t.Error("expected no Strict-Transport-Security header")
+		}
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		origin string
+		code   int
+	}{
+		{"no origin", appToken.ID, "", http.StatusOK},
+		{"token origin", appToken.ID, "https://app.com", http.StatusOK},
+		{"gateway origin with restricted token", appToken.ID, "https://textile.io", http.StatusForbidden},
+		{"gateway origin with open token", openToken.ID, "https://textile.io", http.StatusOK},
+		{"other origin with open token", openToken.ID, "https://evil.com", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(registrationParams{Token: tt.token, DeviceID: tt.name})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err := http.NewRequest(http.MethodPost, url+"/register", bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+			if res.StatusCode != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, res.StatusCode)
+			}
+		})
+	}
+}
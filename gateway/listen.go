@@ -0,0 +1,205 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// StoreEvent describes a change to a model instance in a store.
+type StoreEvent struct {
+	Model      string          `json:"model"`
+	InstanceID string          `json:"instance_id"`
+	Action     string          `json:"action"` // create, save, or delete
+	Instance   json.RawMessage `json:"instance,omitempty"`
+}
+
+// ListenFilter narrows the events of a store listener.
+// Empty fields match all values.
+type ListenFilter struct {
+	Model      string
+	InstanceID string
+	Action     string
+}
+
+// ticketDuration is how long a listen ticket can be used.
+const ticketDuration = time.Second * 30
+
+// listenTicket authorizes a single listen request to a store on behalf of a
+// session. Browsers can't set headers on WebSocket or EventSource requests,
+// so they exchange their session token for a ticket, which is given with the
+// ticket query param instead of the token itself.
+type listenTicket struct {
+	token   string
+	scope   string
+	storeID string
+	expiry  time.Time
+}
+
+// listenTicketHandler issues a listen ticket for the store at
+// /stores/:id/listen/ticket to an authorized session.
+func (g *Gateway) listenTicketHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	defer cancel()
+	storeID, ok := g.authorizeStore(ctx, c)
+	if !ok {
+		return
+	}
+	id := uuid.New().String()
+	ticket := &listenTicket{
+		token:   strings.SplitN(c.GetHeader("Authorization"), " ", 2)[1],
+		scope:   c.GetHeader("X-Scope"),
+		storeID: storeID,
+		expiry:  time.Now().Add(ticketDuration),
+	}
+
+	g.ticketsLock.Lock()
+	for k, t := range g.tickets {
+		if time.Now().After(t.expiry) {
+			delete(g.tickets, k)
+		}
+	}
+	g.tickets[id] = ticket
+	g.ticketsLock.Unlock()
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticket": id,
+		"expiry": ticket.expiry.Unix(),
+	})
+}
+
+// useTicket removes and returns the valid ticket with id for storeID.
+func (g *Gateway) useTicket(id, storeID string) (*listenTicket, bool) {
+	g.ticketsLock.Lock()
+	defer g.ticketsLock.Unlock()
+	ticket, ok := g.tickets[id]
+	if !ok {
+		return nil, false
+	}
+	delete(g.tickets, id)
+	if ticket.storeID != storeID || time.Now().After(ticket.expiry) {
+		return nil, false
+	}
+	return ticket, true
+}
+
+// checkOrigin returns whether or not a WebSocket handshake is allowed by the
+// gateway's CORS config. Requests without an Origin aren't from browsers.
+func (g *Gateway) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || g.allowOrigin(origin)
+}
+
+// storeListenHandler streams the store events at /stores/:id/listen to
+// WebSocket or Server-Sent Events clients. Browsers can't set headers on
+// either, so they may authorize with a ticket query param, see
+// listenTicketHandler. The model, instance, and action query params filter
+// events.
+func (g *Gateway) storeListenHandler(c *gin.Context) {
+	if id := c.Query("ticket"); id != "" {
+		ticket, ok := g.useTicket(id, c.Param("id"))
+		if !ok {
+			abort(c, newError(http.StatusUnauthorized, CodeUnauthorized, "Invalid listen ticket"))
+			return
+		}
+		c.Request.Header.Set("Authorization", "Bearer "+ticket.token)
+		if ticket.scope != "" {
+			c.Request.Header.Set("X-Scope", ticket.scope)
+		}
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	storeID, ok := g.authorizeStore(ctx, c)
+	cancel()
+	if !ok {
+		return
+	}
+
+	filter := ListenFilter{
+		Model:      c.Query("model"),
+		InstanceID: c.Query("instance"),
+		Action:     strings.ToLower(c.Query("action")),
+	}
+	switch filter.Action {
+	case "", "all":
+		filter.Action = ""
+	case "create", "save", "delete":
+	default:
+		abort(c, newError(http.StatusBadRequest, CodeBadRequest, "Unknown action "+filter.Action))
+		return
+	}
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		g.listenWebSocket(c, storeID, filter)
+	} else {
+		g.listenEventStream(c, storeID, filter)
+	}
+}
+
+// listenWebSocket writes store events as JSON messages to a WebSocket.
+func (g *Gateway) listenWebSocket(c *gin.Context, storeID string, filter ListenFilter) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     g.checkOrigin,
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Errorf("error upgrading listener: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// Hijacked connections don't cancel the request context, so we watch
+	// for the client going away by reading until an error.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	events, err := g.stores.Listen(ctx, storeID, filter)
+	if err != nil {
+		log.Errorf("error listening to store %s: %v", storeID, err)
+		_ = conn.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, publicError(err).Message))
+		return
+	}
+	for e := range events {
+		if err = conn.WriteJSON(e); err != nil {
+			return
+		}
+	}
+	_ = conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// listenEventStream writes store events as Server-Sent Events named by action.
+func (g *Gateway) listenEventStream(c *gin.Context, storeID string, filter ListenFilter) {
+	events, err := g.stores.Listen(c.Request.Context(), storeID, filter)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		e, ok := <-events
+		if !ok {
+			return false
+		}
+		c.SSEvent(e.Action, e)
+		return true
+	})
+}
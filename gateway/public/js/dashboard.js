@@ -0,0 +1,229 @@
+// Textile dashboard. Calls the REST/JSON API proxy with the session token from
+// login and the selected team in the X-Scope header, just like the CLI.
+(function () {
+    'use strict';
+
+    var root = document.getElementById('dashboard');
+    var api = root.getAttribute('data-api').replace(/\/$/, '');
+    var store = window.localStorage;
+    var project = null;
+
+    function $(id) {
+        return document.getElementById(id);
+    }
+
+    function show(id, visible) {
+        $(id).classList.toggle('hidden', !visible);
+    }
+
+    function session() {
+        return store.getItem('textile.session');
+    }
+
+    function scope() {
+        return store.getItem('textile.scope') || '';
+    }
+
+    function call(method, path, body) {
+        var headers = {'Content-Type': 'application/json'};
+        if (session()) {
+            headers['Authorization'] = 'Bearer ' + session();
+        }
+        if (scope()) {
+            headers['X-Scope'] = scope();
+        }
+        return fetch(api + path, {
+            method: method,
+            headers: headers,
+            body: body ? JSON.stringify(body) : undefined
+        }).then(function (res) {
+            return res.json().then(function (data) {
+                if (!res.ok) {
+                    if (res.status === 401) {
+                        reset();
+                    }
+                    throw new Error(data.message || res.statusText);
+                }
+                return data;
+            });
+        });
+    }
+
+    function fail(err) {
+        $('error').textContent = err.message;
+    }
+
+    function clear(id) {
+        var el = $(id);
+        while (el.firstChild) {
+            el.removeChild(el.firstChild);
+        }
+        return el;
+    }
+
+    function item(text, detail, action, onAction) {
+        var li = document.createElement('li');
+        li.appendChild(text instanceof Node ? text : document.createTextNode(text));
+        var right = document.createElement('span');
+        right.className = 'right';
+        if (detail) {
+            right.appendChild(document.createTextNode(detail + ' '));
+        }
+        if (action) {
+            var a = document.createElement('a');
+            a.href = '#';
+            a.textContent = action;
+            a.addEventListener('click', function (e) {
+                e.preventDefault();
+                onAction();
+            });
+            right.appendChild(a);
+        }
+        li.appendChild(right);
+        return li;
+    }
+
+    function date(secs) {
+        return new Date(Number(secs) * 1000).toLocaleString();
+    }
+
+    function reset() {
+        store.removeItem('textile.session');
+        store.removeItem('textile.scope');
+        show('main', false);
+        show('login', true);
+    }
+
+    function login(e) {
+        e.preventDefault();
+        var email = e.target.elements.email.value;
+        $('login-status').textContent = 'We sent an email to ' + email + '. Please follow the steps provided inside it.';
+        call('POST', '/v1/login', {email: email}).then(function (reply) {
+            store.setItem('textile.session', reply.sessionID);
+            $('login-status').textContent = '';
+            load();
+        }).catch(function (err) {
+            $('login-status').textContent = err.message;
+        });
+    }
+
+    function logout(e) {
+        e.preventDefault();
+        call('POST', '/v1/logout').catch(function () {}).then(reset);
+    }
+
+    function load() {
+        show('login', false);
+        show('main', true);
+        $('error').textContent = '';
+        Promise.all([call('GET', '/v1/whoami'), call('GET', '/v1/teams')]).then(function (replies) {
+            var who = replies[0];
+            $('whoami').textContent = who.Email;
+            var scopes = clear('scopes');
+            var personal = document.createElement('option');
+            personal.value = who.ID;
+            personal.textContent = 'Personal';
+            scopes.appendChild(personal);
+            replies[1].list.forEach(function (team) {
+                var opt = document.createElement('option');
+                opt.value = team.ID;
+                opt.textContent = team.name;
+                scopes.appendChild(opt);
+            });
+            scopes.value = scope() || who.teamID || who.ID;
+            loadProjects();
+        }).catch(fail);
+    }
+
+    function switchScope() {
+        store.setItem('textile.scope', $('scopes').value);
+        call('POST', '/v1/switch').then(loadProjects).catch(fail);
+    }
+
+    function loadProjects() {
+        show('project', false);
+        call('GET', '/v1/projects').then(function (reply) {
+            var list = clear('projects');
+            reply.list.forEach(function (p) {
+                list.appendChild(item(p.name, date(p.created), 'inspect', function () {
+                    inspect(p.ID);
+                }));
+            });
+        }).catch(fail);
+    }
+
+    function addProject(e) {
+        e.preventDefault();
+        call('POST', '/v1/projects', {name: e.target.elements.name.value}).then(function () {
+            e.target.reset();
+            loadProjects();
+        }).catch(fail);
+    }
+
+    function removeProject(e) {
+        e.preventDefault();
+        if (project && window.confirm('Remove project ' + project.name + '?')) {
+            call('DELETE', '/v1/projects/' + project.ID).then(loadProjects).catch(fail);
+        }
+    }
+
+    function inspect(id) {
+        call('GET', '/v1/projects/' + id).then(function (p) {
+            project = p;
+            $('project-name').textContent = p.name;
+            $('project-id').textContent = p.ID;
+            $('project-store').textContent = p.storeID;
+            $('project-wallet').textContent = p.walletAddress || 'none';
+            $('project-balance').textContent = p.walletAddress ? p.walletBalance : '-';
+            show('project', true);
+            loadTokens();
+            loadUsers();
+        }).catch(fail);
+    }
+
+    function loadTokens() {
+        call('GET', '/v1/projects/' + project.ID + '/tokens').then(function (reply) {
+            var list = clear('tokens');
+            reply.list.forEach(function (token) {
+                list.appendChild(item(token, '', 'remove', function () {
+                    call('DELETE', '/v1/tokens/' + token).then(loadTokens).catch(fail);
+                }));
+            });
+        }).catch(fail);
+    }
+
+    function addToken(e) {
+        e.preventDefault();
+        var origins = e.target.elements.origins.value.split(',').map(function (o) {
+            return o.trim();
+        }).filter(Boolean);
+        call('POST', '/v1/projects/' + project.ID + '/tokens', {allowedOrigins: origins}).then(function () {
+            e.target.reset();
+            loadTokens();
+        }).catch(fail);
+    }
+
+    function loadUsers() {
+        call('GET', '/v1/projects/' + project.ID + '/users').then(function (reply) {
+            var list = clear('users');
+            reply.list.forEach(function (user) {
+                list.appendChild(item(user.ID, date(user.created), 'remove', function () {
+                    call('DELETE', '/v1/users/' + user.ID).then(loadUsers).catch(fail);
+                }));
+            });
+        }).catch(fail);
+    }
+
+    $('login-form').addEventListener('submit', login);
+    $('logout').addEventListener('click', logout);
+    $('scopes').addEventListener('change', switchScope);
+    $('project-form').addEventListener('submit', addProject);
+    $('project-remove').addEventListener('click', removeProject);
+    $('token-form').addEventListener('submit', addToken);
+
+    if (session()) {
+        load();
+    } else {
+        reset();
+    }
+})();
@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	files "github.com/ipfs/go-ipfs-files"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	"github.com/textileio/textile/dns"
+)
+
+// notFoundFile is served by websites in place of the default 404 page.
+const notFoundFile = "404.html"
+
+// txtResolver looks up DNS TXT records. It's satisfied by net.Resolver.
+type txtResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// subdomainHandler serves websites for requests whose host is a subdomain
+// of the DNS domain. Other requests are passed to the next handler.
+func (g *Gateway) subdomainHandler(c *gin.Context) {
+	sub, ok := g.subdomain(c.Request.Host)
+	if !ok {
+		c.Next()
+		return
+	}
+	defer c.Abort()
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		g.render404(c)
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	defer cancel()
+	root, err := g.siteRoot(ctx, sub)
+	if err != nil {
+		g.render404(c)
+		return
+	}
+	g.serveSite(c, root, strings.TrimPrefix(c.Request.URL.Path, "/"))
+}
+
+// subdomain returns the subdomain label of host under the DNS domain.
+func (g *Gateway) subdomain(host string) (string, bool) {
+	if g.dnsDomain == "" {
+		return "", false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	suffix := "." + strings.ToLower(g.dnsDomain)
+	if !strings.HasSuffix(host, suffix) {
+		return "", false
+	}
+	sub := strings.TrimSuffix(host, suffix)
+	if sub == "" || strings.Contains(sub, ".") {
+		return "", false
+	}
+	return sub, true
+}
+
+// siteRoot returns the content path of a website from the DNSLink record
+// created for its subdomain by dns.Manager.NewDNSLink.
+func (g *Gateway) siteRoot(ctx context.Context, sub string) (path.Path, error) {
+	records, err := g.resolver.LookupTXT(ctx, dns.CreateDNSLinkName(sub)+"."+g.dnsDomain)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if hash, err := dns.ParseDNSLinkContent(r); err == nil {
+			return path.New("/ipfs/" + hash), nil
+		}
+	}
+	return nil, fmt.Errorf("no dnslink record for %s", sub)
+}
+
+// serveSite writes the content at pth, relative to root, in a website. Missing content is
+// answered with the website's 404 file if it has one.
+func (g *Gateway) serveSite(c *gin.Context, root path.Path, pth string) {
+	if g.ipfs == nil {
+		g.render404(c)
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	defer cancel()
+	if _, err := g.ipfs.ResolvePath(ctx, path.Join(root, pth)); err == nil {
+		g.serveContent(c, path.Join(root, pth), false)
+		return
+	}
+
+	node, err := g.ipfs.Unixfs().Get(c.Request.Context(), path.Join(root, notFoundFile))
+	if err != nil {
+		g.render404(c)
+		return
+	}
+	defer node.Close()
+	f, ok := node.(files.File)
+	if !ok {
+		g.render404(c)
+		return
+	}
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(http.StatusNotFound)
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+	if _, err = io.Copy(c.Writer, f); err != nil {
+		log.Errorf("error writing %s: %v", notFoundFile, err)
+	}
+}
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/phayes/freeport"
+	"github.com/textileio/textile/dns"
+	"github.com/textileio/textile/util"
+)
+
+// fakeResolver answers TXT lookups from a fixed set of records.
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("no such host %s", name)
+	}
+	return records, nil
+}
+
+func TestGateway_subdomain(t *testing.T) {
+	g := &Gateway{dnsDomain: "Textile.Site"}
+	tests := []struct {
+		host string
+		sub  string
+		ok   bool
+	}{
+		{"www.textile.site", "www", true},
+		{"WWW.TEXTILE.SITE", "www", true},
+		{"www.textile.site:8006", "www", true},
+		{"www.textile.site.", "www", true},
+		{"textile.site", "", false},
+		{"TEXTILE.SITE", "", false},
+		{"localhost", "", false},
+		{"LOCALHOST:8006", "", false},
+		{"a.b.textile.site", "", false},
+		{"www.other.site", "", false},
+		{"wwwtextile.site", "", false},
+	}
+	for _, tt := range tests {
+		sub, ok := g.subdomain(tt.host)
+		if sub != tt.sub || ok != tt.ok {
+			t.Errorf("host %s: expected (%q, %v), got (%q, %v)", tt.host, tt.sub, tt.ok, sub, ok)
+		}
+	}
+
+	if _, ok := (&Gateway{}).subdomain("www.textile.site"); ok {
+		t.Fatal("subdomains should be ignored without a DNS domain")
+	}
+}
+
+func TestGateway_Sites(t *testing.T) {
+	// Sites are served from the subdomains and DNSLink records created by the DNS manager.
+	manager, err := dns.NewManager("textile.site", "zone", "token", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	site, err := url.Parse(manager.GetDomain("www"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{
+		Addr:       util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)),
+		Url:        addr,
+		IPFSClient: fakeIPFS{},
+		DNSDomain:  "textile.site",
+	})
+	gateway.resolver = fakeResolver{
+		dns.CreateDNSLinkName("www") + ".textile.site":  {"v=spf1 -all", dns.CreateDNSLinkContent(siteCid)},
+		dns.CreateDNSLinkName("blog") + ".textile.site": {"v=spf1 -all"},
+	}
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, addr)
+
+	tests := []struct {
+		name   string
+		host   string
+		path   string
+		status int
+		body   string
+	}{
+		{"index", site.Host, "/", http.StatusOK, "<html><body>index</body></html>"},
+		{"uppercase host", strings.ToUpper(site.Host), "/", http.StatusOK, "<html><body>index</body></html>"},
+		{"custom 404", site.Host, "/missing", http.StatusNotFound, "<html><body>custom 404</body></html>"},
+		{"no dnslink record", "blog.textile.site", "/", http.StatusNotFound, ""},
+		{"unknown site", "docs.textile.site", "/", http.StatusNotFound, ""},
+		{"gateway host", "127.0.0.1", "/health", http.StatusNoContent, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, addr+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Host = tt.host
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, res.StatusCode)
+			}
+			if tt.body != "" {
+				body, err := ioutil.ReadAll(res.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(body) != tt.body {
+					t.Fatalf("expected body %q, got %q", tt.body, body)
+				}
+			}
+		})
+	}
+}
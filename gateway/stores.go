@@ -0,0 +1,154 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/textileio/textile/collections"
+)
+
+// Stores reads model instances from thread stores.
+type Stores interface {
+	// ModelFind returns the JSON encoded instances of a model matching a JSON
+	// encoded query. An empty query matches all instances.
+	ModelFind(ctx context.Context, storeID, model string, query []byte) ([]json.RawMessage, error)
+	// ModelFindByID returns a JSON encoded model instance.
+	// collections.ErrNotFound is returned if it doesn't exist.
+	ModelFindByID(ctx context.Context, storeID, model, id string) (json.RawMessage, error)
+	// Listen returns a channel of events in a store matching filter.
+	// The channel is closed when ctx is done or the listener fails.
+	Listen(ctx context.Context, storeID string, filter ListenFilter) (<-chan StoreEvent, error)
+}
+
+// storeModelHandler lists the instances of a model at /stores/:id/models/:model.
+// An optional JSON query may be sent as the request body.
+func (g *Gateway) storeModelHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	defer cancel()
+	storeID, ok := g.authorizeStore(ctx, c)
+	if !ok {
+		return
+	}
+
+	query, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		abort(c, &Error{
+			Status:  http.StatusBadRequest,
+			Code:    CodeBadRequest,
+			Message: "Invalid request body",
+			Err:     err,
+		})
+		return
+	}
+	if len(query) > 0 && !json.Valid(query) {
+		abort(c, newError(http.StatusBadRequest, CodeBadRequest, "Query is not valid JSON"))
+		return
+	}
+	instances, err := g.stores.ModelFind(ctx, storeID, c.Param("model"), query)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+	if instances == nil {
+		instances = []json.RawMessage{}
+	}
+	c.JSON(http.StatusOK, instances)
+}
+
+// storeInstanceHandler returns a model instance at /stores/:id/models/:model/:instance.
+func (g *Gateway) storeInstanceHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), handlerTimeout)
+	defer cancel()
+	storeID, ok := g.authorizeStore(ctx, c)
+	if !ok {
+		return
+	}
+
+	instance, err := g.stores.ModelFindByID(ctx, storeID, c.Param("model"), c.Param("instance"))
+	if err != nil {
+		if errors.Is(err, collections.ErrNotFound) {
+			err = newError(http.StatusNotFound, CodeNotFound, "Instance not found")
+		}
+		abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, instance)
+}
+
+// authorizeStore checks that the request's session has access to the store
+// named by the id param. Users may read the stores of projects in their
+// current scope, which can be set with the X-Scope header. App users may read
+// their own store and their project's store. The request is aborted if access
+// is not allowed.
+func (g *Gateway) authorizeStore(ctx context.Context, c *gin.Context) (storeID string, ok bool) {
+	if g.stores == nil || g.collections == nil {
+		abort(c, newError(http.StatusServiceUnavailable, CodeUnavailable, "Stores are not available"))
+		return
+	}
+	storeID = c.Param("id")
+
+	parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") || parts[1] == "" {
+		abort(c, newError(http.StatusUnauthorized, CodeUnauthorized, "Missing auth token"))
+		return
+	}
+	session, err := g.collections.Sessions.Get(ctx, parts[1])
+	if err != nil {
+		abort(c, newError(http.StatusUnauthorized, CodeUnauthorized, "Invalid auth token"))
+		return
+	}
+	if session.Expiry < int(time.Now().Unix()) {
+		abort(c, newError(http.StatusUnauthorized, CodeExpired, "Expired auth token"))
+		return
+	}
+
+	var stores []string
+	if user, err := g.collections.Users.Get(ctx, session.UserID); err == nil {
+		scope := c.GetHeader("X-Scope")
+		if scope == "" {
+			scope = session.Scope
+		}
+		if scope != user.ID && !user.HasTeam(scope) {
+			abort(c, newError(http.StatusForbidden, CodeForbidden, "User is not a member of scope"))
+			return
+		}
+		projs, err := g.collections.Projects.List(ctx, scope)
+		if err != nil {
+			abort(c, err)
+			return
+		}
+		for _, p := range projs {
+			stores = append(stores, p.StoreID)
+		}
+	} else if user, err := g.collections.AppUsers.Get(ctx, session.UserID); err == nil {
+		proj, err := g.collections.Projects.Get(ctx, user.ProjectID)
+		if err != nil {
+			abort(c, newError(http.StatusForbidden, CodeForbidden, "Project not found"))
+			return
+		}
+		stores = append(stores, proj.StoreID, user.StoreID)
+	} else {
+		abort(c, newError(http.StatusForbidden, CodeForbidden, "User not found"))
+		return
+	}
+
+	// Stores outside of the session's projects are reported as missing so
+	// their existence isn't leaked.
+	for _, s := range stores {
+		if s == storeID {
+			if err = g.collections.Sessions.Touch(ctx, session); err != nil {
+				abort(c, err)
+				return
+			}
+			return storeID, true
+		}
+	}
+	abort(c, newError(http.StatusNotFound, CodeNotFound, "Store not found"))
+	return
+}
@@ -0,0 +1,296 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/phayes/freeport"
+	"github.com/textileio/textile/collections"
+	"github.com/textileio/textile/collections/memory"
+	"github.com/textileio/textile/util"
+)
+
+type fakeStores struct{}
+
+func (fakeStores) ModelFind(_ context.Context, storeID, model string, _ []byte) ([]json.RawMessage, error) {
+	return []json.RawMessage{json.RawMessage(`{"ID":"1"}`)}, nil
+}
+
+func (fakeStores) ModelFindByID(_ context.Context, storeID, model, id string) (json.RawMessage, error) {
+	if id != "1" {
+		return nil, collections.ErrNotFound
+	}
+	return json.RawMessage(`{"ID":"1"}`), nil
+}
+
+func (fakeStores) Listen(ctx context.Context, storeID string, _ ListenFilter) (<-chan StoreEvent, error) {
+	events := make(chan StoreEvent, 1)
+	events <- StoreEvent{Model: "Foo", InstanceID: "1", Action: "create", Instance: json.RawMessage(`{"ID":"1"}`)}
+	close(events)
+	return events, nil
+}
+
+func TestGateway_Stores(t *testing.T) {
+	ctx := context.Background()
+	cols := memory.NewCollections(nil)
+	user, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := cols.Users.Create(ctx, "jane@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj, err := cols.Projects.Create(ctx, "foo", user.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	appUser, err := cols.AppUsers.GetOrCreate(ctx, proj.ID, "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	userSession, err := cols.Sessions.Create(ctx, user.ID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSession, err := cols.Sessions.Create(ctx, other.ID, other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appSession, err := cols.Sessions.Create(ctx, appUser.ID, appUser.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{Addr: addr, Url: url, Collections: cols, Stores: fakeStores{}})
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, url)
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		code  int
+	}{
+		{"no token", "", "/stores/" + proj.StoreID + "/models/Foo", http.StatusUnauthorized},
+		{"invalid token", "bad", "/stores/" + proj.StoreID + "/models/Foo", http.StatusUnauthorized},
+		{"user project store", userSession.ID, "/stores/" + proj.StoreID + "/models/Foo", http.StatusOK},
+		{"user instance", userSession.ID, "/stores/" + proj.StoreID + "/models/Foo/1", http.StatusOK},
+		{"user missing instance", userSession.ID, "/stores/" + proj.StoreID + "/models/Foo/2", http.StatusNotFound},
+		{"other user project store", otherSession.ID, "/stores/" + proj.StoreID + "/models/Foo", http.StatusNotFound},
+		{"app user project store", appSession.ID, "/stores/" + proj.StoreID + "/models/Foo", http.StatusOK},
+		{"app user own store", appSession.ID, "/stores/" + appUser.StoreID + "/models/Foo", http.StatusOK},
+		{"app user unknown store", appSession.ID, "/stores/unknown/models/Foo", http.StatusNotFound},
+		{"app user listen", appSession.ID, "/stores/" + proj.StoreID + "/listen", http.StatusOK},
+		{"app user listen bad action", appSession.ID, "/stores/" + proj.StoreID + "/listen?action=foo", http.StatusBadRequest},
+		{"other user listen", otherSession.ID, "/stores/" + proj.StoreID + "/listen", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+			if res.StatusCode != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, res.StatusCode)
+			}
+		})
+	}
+
+	t.Run("listen with query token", func(t *testing.T) {
+		res, err := http.Get(url + "/stores/" + proj.StoreID + "/listen?token=" + appSession.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+
+	t.Run("listen with ticket", func(t *testing.T) {
+		ticket := getListenTicket(t, url, proj.StoreID, appSession.ID)
+		listen := url + "/stores/" + proj.StoreID + "/listen?ticket=" + ticket
+		res, err := http.Get(listen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+		}
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), "event:create") {
+			t.Errorf("expected a create event, got %s", body)
+		}
+
+		reused, err := http.Get(listen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reused.Body.Close()
+		if reused.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected a used ticket to be rejected, got status %d", reused.StatusCode)
+		}
+	})
+
+	t.Run("listen with ticket for another store", func(t *testing.T) {
+		ticket := getListenTicket(t, url, proj.StoreID, appSession.ID)
+		res, err := http.Get(url + "/stores/" + appUser.StoreID + "/listen?ticket=" + ticket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+
+	t.Run("ticket without token", func(t *testing.T) {
+		res, err := http.Post(url+"/stores/"+proj.StoreID+"/listen/ticket", "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+}
+
+func TestGateway_ListenOrigin(t *testing.T) {
+	ctx := context.Background()
+	cols := memory.NewCollections(nil)
+	user, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj, err := cols.Projects.Create(ctx, "foo", user.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := cols.Sessions.Create(ctx, user.ID, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{
+		Addr:        util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)),
+		Url:         url,
+		Collections: cols,
+		Stores:      fakeStores{},
+		CORS:        CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+	})
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, url)
+
+	wsURL := "ws" + strings.TrimPrefix(url, "http") + "/stores/" + proj.StoreID + "/listen"
+	tests := []struct {
+		name   string
+		origin string
+		ok     bool
+	}{
+		{"allowed origin", "https://app.example.com", true},
+		{"other origin", "https://evil.example.com", false},
+		{"no origin", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Authorization", "Bearer "+session.ID)
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+			conn, res, err := websocket.DefaultDialer.Dial(wsURL, header)
+			if !tt.ok {
+				if err == nil {
+					conn.Close()
+					t.Fatal("expected the handshake to fail")
+				}
+				if res == nil || res.StatusCode != http.StatusForbidden {
+					t.Fatalf("expected status %d, got %v", http.StatusForbidden, res)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			var e StoreEvent
+			if err = conn.ReadJSON(&e); err != nil {
+				t.Fatal(err)
+			}
+			if e.Action != "create" {
+				t.Fatalf("expected a create event, got %s", e.Action)
+			}
+		})
+	}
+}
+
+// getListenTicket returns a listen ticket for a store.
+func getListenTicket(t *testing.T, url, storeID, token string) string {
+	req, err := http.NewRequest(http.MethodPost, url+"/stores/"+storeID+"/listen/ticket", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+	}
+	var body struct {
+		Ticket string `json:"ticket"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return body.Ticket
+}
+
+// waitForGateway blocks until the gateway at url is accepting requests.
+func waitForGateway(t *testing.T, url string) {
+	for i := 0; i < 50; i++ {
+		res, err := http.Get(url + "/health")
+		if err == nil {
+			res.Body.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("gateway did not start")
+}
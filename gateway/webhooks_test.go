@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/phayes/freeport"
+	c "github.com/textileio/textile/collections"
+	"github.com/textileio/textile/collections/memory"
+	"github.com/textileio/textile/util"
+	"github.com/textileio/textile/webhooks"
+)
+
+func TestGateway_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	cols := memory.NewCollections(nil)
+	owner, err := cols.Users.Create(ctx, "jon@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := cols.Teams.Create(ctx, owner.ID, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj, err := cols.Projects.Create(ctx, "foo", team.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := cols.AppTokens.Create(ctx, proj.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invite, err := cols.Invites.Create(ctx, team.ID, owner.ID, "jane@doe.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cols.Webhooks.Create(ctx, proj.ID, "http://127.0.0.1:1", "secret",
+		[]string{c.EventAppUserRegister, c.EventTeamMemberJoin}); err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	gateway := NewGateway(Config{
+		Addr:        addr,
+		Url:         url,
+		Collections: cols,
+		// Not started, so deliveries stay queued.
+		Webhooks: webhooks.NewDispatcher(cols, webhooks.Config{}),
+	})
+	gateway.Start()
+	defer gateway.Stop()
+	waitForGateway(t, url)
+
+	t.Run("test consent invite", func(t *testing.T) {
+		res, err := http.Get(url + "/consent/" + invite.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+		}
+		checkQueued(t, cols, c.EventTeamMemberJoin)
+		checkAudited(t, cols, team.ID, c.ActionInviteAccept)
+	})
+
+	t.Run("test register app user", func(t *testing.T) {
+		body, err := json.Marshal(registrationParams{Token: token.ID, DeviceID: "device"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := http.Post(url+"/register", "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+		}
+		checkQueued(t, cols, c.EventAppUserRegister)
+		checkAudited(t, cols, team.ID, c.ActionAppUserRegister)
+	})
+}
+
+// checkQueued fails unless a delivery of event is queued.
+func checkQueued(t *testing.T, cols *c.Collections, event string) {
+	list, err := cols.WebhookDeliveries.ListDue(context.Background(), time.Now().Unix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range list {
+		if d.Event == event {
+			return
+		}
+	}
+	t.Fatalf("expected a queued %s delivery", event)
+}
+
+// checkAudited fails unless an event with action was recorded in scope.
+func checkAudited(t *testing.T, cols *c.Collections, scope, action string) {
+	list, err := cols.AuditEvents.List(context.Background(), scope, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range list {
+		if e.Action == action && e.IP == "127.0.0.1" {
+			return
+		}
+	}
+	t.Fatalf("expected a %s audit event", action)
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that didn't match a registered route,
+// e.g., websites.
+const unmatchedRoute = "unmatched"
+
+// Gin is gin middleware that records the status, latency, and response size
+// of gateway requests. Routes are labeled with their pattern rather than the
+// request path to keep the number of series bounded.
+func Gin(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	method := c.Request.Method
+	GatewayRequests.Inc(method, route, strconv.Itoa(c.Writer.Status()))
+	GatewayDuration.Observe(time.Since(start).Seconds(), method, route)
+	if size := c.Writer.Size(); size > 0 {
+		GatewayResponseBytes.Add(float64(size), method, route)
+	}
+}
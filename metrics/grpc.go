@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor records the status and latency of unary requests
+// to server. It should come first in the chain so that rejected requests
+// are recorded too.
+func UnaryServerInterceptor(server string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		observeRPC(server, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor records the status and duration of streams to server.
+func StreamServerInterceptor(server string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(server, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeRPC(server, method string, start time.Time, err error) {
+	RPCRequests.Inc(server, method, status.Code(err).String())
+	RPCDuration.Observe(time.Since(start).Seconds(), server, method)
+}
+
+// AuthFunc wraps f to count the auth attempts it rejects on server.
+func AuthFunc(server string, f auth.AuthFunc) auth.AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		newCtx, err := f(ctx)
+		if err != nil {
+			AuthFailures.Inc(server, status.Code(err).String())
+		}
+		return newCtx, err
+	}
+}
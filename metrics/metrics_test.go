@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	counter := r.NewCounter("test_requests_total", "Requests.\nBy code.", "code")
+	gauge := r.NewGauge("test_users", "Users.")
+	hist := r.NewHistogram("test_duration_seconds", "Duration.", []float64{.1, 1}, "method")
+
+	counter.Inc("OK")
+	counter.Add(2, `Not "found"`)
+	gauge.Set(3)
+	hist.Observe(.05, "get")
+	hist.Observe(.5, "get")
+	hist.Observe(5, "get")
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="get",le="0.1"} 1
+test_duration_seconds_bucket{method="get",le="1"} 2
+test_duration_seconds_bucket{method="get",le="+Inf"} 3
+test_duration_seconds_sum{method="get"} 5.55
+test_duration_seconds_count{method="get"} 3
+# HELP test_requests_total Requests.\nBy code.
+# TYPE test_requests_total counter
+test_requests_total{code="Not \"found\""} 2
+test_requests_total{code="OK"} 1
+# HELP test_users Users.
+# TYPE test_users gauge
+test_users 3
+`
+	if buf.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestRegistry_duplicate(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.NewCounter("test_total", "Test.")
+	defer func() {
+		if recover() == nil {
+			t.Fatal("registering a duplicate name should panic")
+		}
+	}()
+	r.NewGauge("test_total", "Test.")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+	interceptor := UnaryServerInterceptor("test")
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.Test/Unary"}
+	for _, err := range []error{nil, status.Error(codes.NotFound, "Not found"), errors.New("boom")} {
+		_, _ = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+	for _, code := range []codes.Code{codes.OK, codes.NotFound, codes.Unknown} {
+		if RPCRequests.Value("test", info.FullMethod, code.String()) != 1 {
+			t.Fatalf("expected one %s request", code)
+		}
+	}
+	if RPCDuration.Count("test", info.FullMethod) != 3 {
+		t.Fatal("expected three observed durations")
+	}
+}
+
+func TestAuthFunc(t *testing.T) {
+	t.Parallel()
+	f := AuthFunc("test", func(ctx context.Context) (context.Context, error) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid auth token")
+	})
+	if _, err := f(context.Background()); err == nil {
+		t.Fatal("auth should fail")
+	}
+	if AuthFailures.Value("test", codes.Unauthenticated.String()) != 1 {
+		t.Fatal("expected one auth failure")
+	}
+}
+
+func TestGin(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Gin)
+	router.GET("/test/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	for _, path := range []string{"/test/1", "/test/2", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+	if GatewayRequests.Value("GET", "/test/:id", "200") != 2 {
+		t.Fatal("expected two requests to the route")
+	}
+	if GatewayRequests.Value("GET", unmatchedRoute, "404") != 1 {
+		t.Fatal("expected one unmatched request")
+	}
+	if GatewayResponseBytes.Value("GET", "/test/:id") != 4 {
+		t.Fatal("expected four response bytes")
+	}
+}
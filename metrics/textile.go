@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("metrics")
+
+// Daemon metrics, served by textiled at /metrics.
+var (
+	// RPCRequests counts handled gRPC requests. Server is "api", "threads", or
+	// "threads_service".
+	RPCRequests = NewCounter(
+		"textile_grpc_requests_total",
+		"gRPC requests handled, by server, method, and status code.",
+		"server", "method", "code")
+	RPCDuration = NewHistogram(
+		"textile_grpc_request_duration_seconds",
+		"gRPC request latency, by server and method.",
+		DefaultBuckets,
+		"server", "method")
+	AuthFailures = NewCounter(
+		"textile_auth_failures_total",
+		"Rejected gRPC auth attempts, by server and status code.",
+		"server", "code")
+
+	// Logins counts login requests that finished with result "completed", or
+	// "unverified" if the email address wasn't verified in time.
+	Logins = NewCounter(
+		"textile_logins_total",
+		"Logins, by result.",
+		"result")
+	EmailsSent = NewCounter(
+		"textile_emails_sent_total",
+		"Emails sent, by kind and result.",
+		"kind", "result")
+
+	GatewayRequests = NewCounter(
+		"textile_gateway_requests_total",
+		"Gateway HTTP requests, by method, route, and status code.",
+		"method", "route", "code")
+	GatewayDuration = NewHistogram(
+		"textile_gateway_request_duration_seconds",
+		"Gateway HTTP request latency, by method and route.",
+		DefaultBuckets,
+		"method", "route")
+	GatewayResponseBytes = NewCounter(
+		"textile_gateway_response_bytes_total",
+		"Gateway HTTP response body bytes, by method and route.",
+		"method", "route")
+
+	Users = NewGauge(
+		"textile_users",
+		"Registered users.")
+	Projects = NewGauge(
+		"textile_projects",
+		"Projects.")
+	AppUsers = NewGauge(
+		"textile_app_users",
+		"Registered app users.")
+	ActiveSessions = NewGauge(
+		"textile_active_sessions",
+		"Sessions that haven't expired.")
+)
+
+// Result returns the result label of an operation that returned err.
+func Result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
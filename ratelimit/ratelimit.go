@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit specifies a token bucket that refills one token every Every, holding
+// at most Burst tokens. A zero Limit allows all events.
+type Limit struct {
+	Every time.Duration
+	Burst int
+}
+
+// Config specifies the limits for each kind of key.
+type Config struct {
+	IP    Limit // client IP address
+	Email Limit // login email address
+	Token Limit // app token
+}
+
+// Limiter rate limits events by key with a token bucket per key.
+type Limiter struct {
+	limit rate.Limit
+	burst int
+
+	lock      sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter *rate.Limiter
+	seen    time.Time
+}
+
+// NewLimiter returns a limiter enforcing l for each key.
+func NewLimiter(l Limit) *Limiter {
+	lim := &Limiter{
+		limit:     rate.Inf,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+	if l.Every > 0 {
+		lim.limit = rate.Every(l.Every)
+		lim.burst = l.Burst
+		if lim.burst < 1 {
+			lim.burst = 1
+		}
+	}
+	return lim
+}
+
+// Allow returns whether or not an event for key may happen now.
+// A nil limiter allows all events.
+func (l *Limiter) Allow(key string) bool {
+	if l == nil || l.limit == rate.Inf {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.seen = now
+	return b.limiter.AllowN(now, 1)
+}
+
+// sweep removes the buckets of keys that have been idle long enough
+// to refill completely, since they're equivalent to new ones.
+func (l *Limiter) sweep(now time.Time) {
+	idle := time.Duration(float64(l.burst) / float64(l.limit) * float64(time.Second))
+	if now.Sub(l.lastSweep) < idle {
+		return
+	}
+	for k, b := range l.buckets {
+		if now.Sub(b.seen) >= idle {
+			delete(l.buckets, k)
+		}
+	}
+	l.lastSweep = now
+}
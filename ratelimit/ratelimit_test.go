@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	l := NewLimiter(Limit{Every: time.Hour, Burst: 2})
+	for i := 0; i < 2; i++ {
+		if !l.Allow("foo") {
+			t.Fatalf("event %d should be allowed", i)
+		}
+	}
+	if l.Allow("foo") {
+		t.Fatal("event over burst should not be allowed")
+	}
+	if !l.Allow("bar") {
+		t.Fatal("events for other keys should be allowed")
+	}
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l := NewLimiter(Limit{})
+	for i := 0; i < 100; i++ {
+		if !l.Allow("foo") {
+			t.Fatal("all events should be allowed")
+		}
+	}
+	var nl *Limiter
+	if !nl.Allow("foo") {
+		t.Fatal("nil limiter should allow all events")
+	}
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor traces and logs unary requests to server. It should
+// come first in the chain so that rejected requests are logged too.
+func UnaryServerInterceptor(server string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, r := startServerRequest(ctx, server, info.FullMethod)
+		res, err := handler(ctx, req)
+		r.Finish(status.Code(err).String(), err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor traces and logs streams to server.
+func StreamServerInterceptor(server string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, r := startServerRequest(ss.Context(), server, info.FullMethod)
+		wrapped := grpcmiddleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		err := handler(srv, wrapped)
+		r.Finish(status.Code(err).String(), err)
+		return err
+	}
+}
+
+// startServerRequest starts a request that continues the caller's trace, if
+// any, and returns its ID in the response header.
+func startServerRequest(ctx context.Context, server, method string) (context.Context, *Request) {
+	var parent string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(TraceparentHeader); len(v) > 0 {
+			parent = v[0]
+		}
+	}
+	ctx, r := StartRequest(ctx, server, method, parent)
+	// This fails if the request didn't come over a gRPC transport, which is fine.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, r.ID()))
+	return ctx, r
+}
+
+// UnaryClientInterceptor starts a client span for each call and propagates
+// it to the server.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := trace.StartSpan(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	ctx = metadata.AppendToOutgoingContext(ctx, TraceparentHeader, FormatTraceparent(span.SpanContext()))
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil {
+		st := status.Convert(err)
+		span.SetStatus(trace.Status{Code: int32(st.Code()), Message: st.Message()})
+	}
+	return err
+}
+
+// StreamClientInterceptor propagates the caller's span, if any, to the server.
+// Streams don't get their own client span since they may outlive the caller.
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if parent := Traceparent(ctx); parent != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, TraceparentHeader, parent)
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// DialOptions returns the options that add the client interceptors to a connection.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor),
+		grpc.WithStreamInterceptor(StreamClientInterceptor),
+	}
+}
@@ -0,0 +1,266 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+const (
+	// tracesPath is where OTLP/HTTP collectors receive spans.
+	tracesPath = "/v1/traces"
+	// batchSize is the most spans sent in one export request.
+	batchSize = 512
+	// queueSize is the most spans waiting to be exported. Later spans are dropped.
+	queueSize = 4096
+	// flushInterval is how often queued spans are exported.
+	flushInterval = time.Second * 5
+)
+
+// Exporter exports spans to an OTLP/HTTP collector as JSON. Spans are queued
+// and exported in batches in the background.
+type Exporter struct {
+	url     string
+	service string
+	client  *http.Client
+
+	lock    sync.Mutex
+	closed  bool
+	dropped int
+	queue   chan *trace.SpanData
+	done    chan struct{}
+}
+
+// NewExporter returns a started exporter for the collector at endpoint, see
+// Config. Register it with trace.RegisterExporter, or use Start.
+func NewExporter(endpoint, service string) (*Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("OTLP endpoint must be an http or https URL")
+	}
+	if !strings.HasSuffix(u.Path, tracesPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + tracesPath
+	}
+	e := &Exporter{
+		url:     u.String(),
+		service: service,
+		client:  &http.Client{Timeout: time.Second * 10},
+		queue:   make(chan *trace.SpanData, queueSize),
+		done:    make(chan struct{}),
+	}
+	go e.run()
+	return e, nil
+}
+
+// ExportSpan queues a span for export. It implements trace.Exporter.
+func (e *Exporter) ExportSpan(sd *trace.SpanData) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.closed {
+		return
+	}
+	select {
+	case e.queue <- sd:
+	default:
+		e.dropped++
+	}
+}
+
+// Close stops the exporter after exporting queued spans. It does nothing if
+// the exporter is nil.
+func (e *Exporter) Close() {
+	if e == nil {
+		return
+	}
+	trace.UnregisterExporter(e)
+	e.lock.Lock()
+	if e.closed {
+		e.lock.Unlock()
+		return
+	}
+	e.closed = true
+	close(e.queue)
+	e.lock.Unlock()
+	<-e.done
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	var batch []*trace.SpanData
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			log.Errorf("exporting %d spans: %v", len(batch), err)
+		}
+		batch = nil
+		e.lock.Lock()
+		if e.dropped > 0 {
+			log.Warnf("dropped %d spans, the export queue was full", e.dropped)
+			e.dropped = 0
+		}
+		e.lock.Unlock()
+	}
+	for {
+		select {
+		case sd, ok := <-e.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, sd)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// export sends spans in a single request.
+func (e *Exporter) export(spans []*trace.SpanData) error {
+	body, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		return err
+	}
+	res, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(res.Body, 1<<16))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// The OTLP/HTTP JSON encoding of spans. IDs are hex encoded and 64-bit
+// integers are strings, as the OTLP spec requires.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpKeyValue struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    string   `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+)
+
+// OTLP span kinds and status codes.
+const (
+	otlpKindInternal = 1
+	otlpKindServer   = 2
+	otlpKindClient   = 3
+
+	otlpStatusError = 2
+)
+
+func (e *Exporter) encode(spans []*trace.SpanData) *otlpRequest {
+	encoded := make([]otlpSpan, len(spans))
+	for i, sd := range spans {
+		s := otlpSpan{
+			TraceID:           hex.EncodeToString(sd.TraceID[:]),
+			SpanID:            hex.EncodeToString(sd.SpanID[:]),
+			Name:              sd.Name,
+			Kind:              otlpKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(sd.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(sd.EndTime.UnixNano(), 10),
+		}
+		if sd.ParentSpanID != ([8]byte{}) {
+			s.ParentSpanID = hex.EncodeToString(sd.ParentSpanID[:])
+		}
+		switch sd.SpanKind {
+		case trace.SpanKindServer:
+			s.Kind = otlpKindServer
+		case trace.SpanKindClient:
+			s.Kind = otlpKindClient
+		}
+		for k, v := range sd.Attributes {
+			s.Attributes = append(s.Attributes, otlpKeyValue{Key: k, Value: encodeValue(v)})
+		}
+		if sd.Status.Code != trace.StatusCodeOK {
+			s.Status = otlpStatus{Code: otlpStatusError, Message: sd.Status.Message}
+		}
+		encoded[i] = s
+	}
+	service := e.service
+	return &otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpKeyValue{
+			{Key: "service.name", Value: otlpValue{StringValue: &service}},
+		}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/textileio/textile/tracing"},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+func encodeValue(v interface{}) otlpValue {
+	switch v := v.(type) {
+	case string:
+		return otlpValue{StringValue: &v}
+	case bool:
+		return otlpValue{BoolValue: &v}
+	case int64:
+		return otlpValue{IntValue: strconv.FormatInt(v, 10)}
+	case float64:
+		return otlpValue{DoubleValue: &v}
+	default:
+		s := fmt.Sprint(v)
+		return otlpValue{StringValue: &s}
+	}
+}
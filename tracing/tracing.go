@@ -0,0 +1,218 @@
+// Package tracing traces requests across the API, the gateway, and threads,
+// and logs each request with structured fields.
+//
+// Spans are recorded with OpenCensus and propagated with W3C trace context,
+// which is OpenTelemetry's default propagator, so traces continue across
+// services instrumented with either. Sampled spans can be exported to an
+// OpenTelemetry collector over OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log"
+	"go.opencensus.io/trace"
+)
+
+var log = logging.Logger("requests")
+
+const (
+	// TraceparentHeader carries the W3C trace context of a request.
+	TraceparentHeader = "traceparent"
+	// RequestIDHeader returns the ID of a request to the client. It's the ID
+	// of the request's server span.
+	RequestIDHeader = "x-request-id"
+)
+
+// Config specifies how traces are sampled and exported.
+type Config struct {
+	// Service names the process in exported spans, e.g., "textiled".
+	Service string
+	// OTLPEndpoint is the base URL of an OTLP/HTTP collector, e.g.,
+	// http://127.0.0.1:4318. Spans are only exported if not empty.
+	OTLPEndpoint string
+	// SampleRate is the fraction of traces that are exported. Zero exports
+	// all. Traces continued from a caller that sampled them are always
+	// exported. Other traces are sampled at this rate, including those the
+	// caller didn't sample, e.g., because the caller doesn't export spans.
+	SampleRate float64
+}
+
+// Start configures tracing for the process. Without an OTLP endpoint, spans
+// aren't recorded, but requests still get IDs and trace context is still
+// propagated. The returned exporter is nil in that case.
+func Start(conf Config) (*Exporter, error) {
+	if conf.OTLPEndpoint == "" {
+		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
+		return nil, nil
+	}
+	exporter, err := NewExporter(conf.OTLPEndpoint, conf.Service)
+	if err != nil {
+		return nil, err
+	}
+	trace.ApplyConfig(trace.Config{DefaultSampler: newSampler(conf.SampleRate)})
+	trace.RegisterExporter(exporter)
+	return exporter, nil
+}
+
+// newSampler returns a sampler for Config.SampleRate. Unsampled remote parents
+// aren't followed, since the CLI doesn't sample without an OTLP endpoint and
+// would otherwise keep the daemon from exporting its requests.
+func newSampler(rate float64) trace.Sampler {
+	if rate <= 0 || rate >= 1 {
+		return trace.AlwaysSample()
+	}
+	// This samples every trace whose parent is sampled.
+	return trace.ProbabilitySampler(rate)
+}
+
+// requestKey provides a concrete type for the request context value.
+type requestKey struct{}
+
+// Request is an incoming request, which is logged when it finishes.
+type Request struct {
+	server string
+	method string
+	start  time.Time
+	span   *trace.Span
+
+	lock   sync.Mutex
+	fields []interface{}
+	quiet  bool
+}
+
+// StartRequest starts a server span for an incoming request to server and
+// returns a context carrying it. The span continues the trace of parent,
+// a W3C traceparent header value, if it's valid.
+func StartRequest(ctx context.Context, server, method, parent string) (context.Context, *Request) {
+	var span *trace.Span
+	if sc, ok := ParseTraceparent(parent); ok {
+		ctx, span = trace.StartSpanWithRemoteParent(ctx, method, sc, trace.WithSpanKind(trace.SpanKindServer))
+	} else {
+		ctx, span = trace.StartSpan(ctx, method, trace.WithSpanKind(trace.SpanKindServer))
+	}
+	span.AddAttributes(trace.StringAttribute("server", server))
+	r := &Request{
+		server: server,
+		method: method,
+		start:  time.Now(),
+		span:   span,
+	}
+	return context.WithValue(ctx, requestKey{}, r), r
+}
+
+// ID returns the request ID.
+func (r *Request) ID() string {
+	sc := r.span.SpanContext()
+	return hex.EncodeToString(sc.SpanID[:])
+}
+
+// Finish ends the request's span and logs the request with its status.
+// Requests are logged at info level, or at debug level if quiet.
+func (r *Request) Finish(status string, err error) {
+	if err != nil {
+		r.span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	r.span.AddAttributes(trace.StringAttribute("status", status))
+	r.span.End()
+
+	sc := r.span.SpanContext()
+	r.lock.Lock()
+	fields := append([]interface{}{
+		"request_id", r.ID(),
+		"trace_id", hex.EncodeToString(sc.TraceID[:]),
+		"server", r.server,
+		"method", r.method,
+		"status", status,
+		"duration", time.Since(r.start),
+	}, r.fields...)
+	quiet := r.quiet
+	r.lock.Unlock()
+	if err != nil {
+		fields = append(fields, "error", err.Error())
+	}
+	if quiet && err == nil {
+		log.Debugw("request", fields...)
+	} else {
+		log.Infow("request", fields...)
+	}
+}
+
+// Annotate adds a field to the log entry and the span of the request in ctx,
+// e.g., the authorized user. It does nothing if ctx has no request.
+func Annotate(ctx context.Context, key, value string) {
+	r, ok := ctx.Value(requestKey{}).(*Request)
+	if !ok {
+		return
+	}
+	r.lock.Lock()
+	r.fields = append(r.fields, key, value)
+	r.lock.Unlock()
+	r.span.AddAttributes(trace.StringAttribute(key, value))
+}
+
+// Quiet logs the request in ctx at debug level unless it fails, e.g., for
+// frequent internal requests. It does nothing if ctx has no request.
+func Quiet(ctx context.Context) {
+	r, ok := ctx.Value(requestKey{}).(*Request)
+	if !ok {
+		return
+	}
+	r.lock.Lock()
+	r.quiet = true
+	r.lock.Unlock()
+}
+
+// Traceparent returns the W3C traceparent header value of the span in ctx,
+// or an empty string if there is none.
+func Traceparent(ctx context.Context) string {
+	span := trace.FromContext(ctx)
+	if span == nil {
+		return ""
+	}
+	return FormatTraceparent(span.SpanContext())
+}
+
+// FormatTraceparent encodes sc as a W3C traceparent header value.
+func FormatTraceparent(sc trace.SpanContext) string {
+	return fmt.Sprintf("00-%s-%s-%02x",
+		hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), sc.TraceOptions&1)
+}
+
+// ParseTraceparent decodes a W3C traceparent header value.
+func ParseTraceparent(v string) (sc trace.SpanContext, ok bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, false
+	}
+	// Version 00 has exactly four parts, later versions may append more.
+	if parts[0] == "00" && len(parts) != 4 {
+		return sc, false
+	}
+	if !decodeHex(sc.TraceID[:], parts[1]) || !decodeHex(sc.SpanID[:], parts[2]) {
+		return sc, false
+	}
+	if sc.TraceID == ([16]byte{}) || sc.SpanID == ([8]byte{}) {
+		return sc, false
+	}
+	var flags [1]byte
+	if !decodeHex(flags[:], parts[3]) {
+		return sc, false
+	}
+	sc.TraceOptions = trace.TraceOptions(flags[0] & 1)
+	return sc, true
+}
+
+// decodeHex decodes s into dst, which s must fill exactly.
+func decodeHex(dst []byte, s string) bool {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
@@ -0,0 +1,245 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+	v := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, ok := ParseTraceparent(v)
+	if !ok {
+		t.Fatal("expected a valid traceparent")
+	}
+	if !sc.TraceOptions.IsSampled() {
+		t.Fatal("expected a sampled trace")
+	}
+	if FormatTraceparent(sc) != v {
+		t.Fatalf("expected %s, got %s", v, FormatTraceparent(sc))
+	}
+
+	for _, v := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz",
+	} {
+		if _, ok := ParseTraceparent(v); ok {
+			t.Fatalf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestNewSampler(t *testing.T) {
+	t.Parallel()
+	// The sampled fraction is decided by the high bits of the trace ID.
+	unlikely := trace.TraceID{0xff}
+	sampled := trace.SpanContext{TraceID: unlikely, SpanID: trace.SpanID{1}, TraceOptions: 1}
+	unsampled := trace.SpanContext{TraceID: unlikely, SpanID: trace.SpanID{1}}
+	tests := []struct {
+		name   string
+		rate   float64
+		params trace.SamplingParameters
+		sample bool
+	}{
+		{
+			name:   "sampled remote parent",
+			rate:   0.01,
+			params: trace.SamplingParameters{ParentContext: sampled, TraceID: unlikely, HasRemoteParent: true},
+			sample: true,
+		},
+		{
+			name:   "unsampled remote parent",
+			rate:   0.01,
+			params: trace.SamplingParameters{ParentContext: unsampled, TraceID: unlikely, HasRemoteParent: true},
+			sample: false,
+		},
+		{
+			name:   "new trace",
+			rate:   0.01,
+			params: trace.SamplingParameters{TraceID: trace.TraceID{0x01}},
+			sample: true,
+		},
+		{
+			name:   "zero rate",
+			params: trace.SamplingParameters{ParentContext: unsampled, TraceID: unlikely, HasRemoteParent: true},
+			sample: true,
+		},
+	}
+	for _, tt := range tests {
+		if newSampler(tt.rate)(tt.params).Sample != tt.sample {
+			t.Fatalf("expected %s to be sampled: %v", tt.name, tt.sample)
+		}
+	}
+}
+
+func TestQuiet(t *testing.T) {
+	t.Parallel()
+	ctx, r := StartRequest(context.Background(), "test", "/test", "")
+	Annotate(ctx, "user", "internal")
+	Quiet(ctx)
+	r.lock.Lock()
+	quiet := r.quiet
+	r.lock.Unlock()
+	if !quiet {
+		t.Fatal("expected a quiet request")
+	}
+	r.Finish("OK", nil)
+
+	// Contexts without a request are ignored.
+	Quiet(context.Background())
+}
+
+// annotatingHealth annotates each request to check that the server
+// interceptor's request reaches handlers.
+type annotatingHealth struct {
+	*health.Server
+
+	lock   sync.Mutex
+	parent string
+}
+
+func (s *annotatingHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	Annotate(ctx, "user", "test")
+	s.lock.Lock()
+	s.parent = Traceparent(ctx)
+	s.lock.Unlock()
+	return s.Server.Check(ctx, req)
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(UnaryServerInterceptor("test")))
+	svc := &annotatingHealth{Server: health.NewServer()}
+	healthpb.RegisterHealthServer(server, svc)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), append([]grpc.DialOption{grpc.WithInsecure()}, DialOptions()...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, span := trace.StartSpan(context.Background(), "test")
+	defer span.End()
+	var header metadata.MD
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatal(err)
+	}
+
+	svc.lock.Lock()
+	parent := svc.parent
+	svc.lock.Unlock()
+	sc, ok := ParseTraceparent(parent)
+	if !ok {
+		t.Fatalf("expected the server to have a trace, got %q", parent)
+	}
+	if sc.TraceID != span.SpanContext().TraceID {
+		t.Fatal("expected the server to continue the client's trace")
+	}
+	ids := header.Get(RequestIDHeader)
+	if len(ids) != 1 || len(ids[0]) != 16 {
+		t.Fatalf("expected a request ID header, got %v", ids)
+	}
+}
+
+func TestExporter(t *testing.T) {
+	t.Parallel()
+	var lock sync.Mutex
+	var received []otlpRequest
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != tracesPath || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		received = append(received, req)
+		lock.Unlock()
+	}))
+	defer collector.Close()
+
+	e, err := NewExporter(collector.URL, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	e.ExportSpan(&trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{2},
+		},
+		ParentSpanID: trace.SpanID{3},
+		SpanKind:     trace.SpanKindServer,
+		Name:         "/pb.API/Test",
+		StartTime:    now,
+		EndTime:      now.Add(time.Millisecond),
+		Attributes:   map[string]interface{}{"user": "test"},
+		Status:       trace.Status{Code: trace.StatusCodeUnknown, Message: "boom"},
+	})
+	e.Close()
+	e.ExportSpan(&trace.SpanData{Name: "late"})
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(received) != 1 {
+		t.Fatalf("expected one export request, got %d", len(received))
+	}
+	rs := received[0].ResourceSpans
+	if len(rs) != 1 || *rs[0].Resource.Attributes[0].Value.StringValue != "test" {
+		t.Fatal("expected the service name resource")
+	}
+	spans := rs[0].ScopeSpans[0].Spans
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	s := spans[0]
+	if s.TraceID != "01000000000000000000000000000000" || s.SpanID != "0200000000000000" ||
+		s.ParentSpanID != "0300000000000000" {
+		t.Fatal("unexpected span IDs")
+	}
+	if s.Kind != otlpKindServer || s.Status.Code != otlpStatusError {
+		t.Fatal("unexpected span kind or status")
+	}
+	if len(s.Attributes) != 1 || *s.Attributes[0].Value.StringValue != "test" {
+		t.Fatal("expected the user attribute")
+	}
+}
+
+func TestNewExporter_invalid(t *testing.T) {
+	t.Parallel()
+	for _, endpoint := range []string{"127.0.0.1:4318", "grpc://127.0.0.1:4317", "http://"} {
+		if _, err := NewExporter(endpoint, "test"); err == nil {
+			t.Fatalf("expected endpoint %q to be invalid", endpoint)
+		}
+	}
+}
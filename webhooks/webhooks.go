@@ -0,0 +1,387 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	logging "github.com/ipfs/go-log"
+	c "github.com/textileio/textile/collections"
+)
+
+var log = logging.Logger("webhooks")
+
+// ErrAddressNotAllowed indicates a webhook host is a loopback, private, or link-local address.
+var ErrAddressNotAllowed = errors.New("webhook address is not allowed")
+
+// Headers sent with each delivery.
+const (
+	// SignatureHeader holds "sha256=" followed by the hex encoded HMAC-SHA256
+	// of the request body, keyed with the webhook's secret.
+	SignatureHeader = "X-Textile-Signature"
+	EventHeader     = "X-Textile-Event"
+	DeliveryHeader  = "X-Textile-Delivery"
+)
+
+// Config specifies how deliveries are retried. Zero values use the defaults.
+type Config struct {
+	// PollInterval is how often the queue is checked for due deliveries.
+	PollInterval time.Duration
+	// RetryBackoff is the delay before the first retry. It doubles with each
+	// later retry up to MaxBackoff.
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	// MaxAttempts is how many times a delivery is attempted before it's dropped.
+	MaxAttempts int
+	// Timeout limits each delivery request.
+	Timeout time.Duration
+	// AllowPrivateAddrs allows webhooks on loopback, private, and link-local
+	// addresses. Otherwise, project owners could use webhooks to reach the
+	// daemon's internal network, so only allow them for testing.
+	AllowPrivateAddrs bool
+}
+
+// DefaultConfig retries for about a day.
+var DefaultConfig = Config{
+	PollInterval: time.Second * 5,
+	RetryBackoff: time.Second * 30,
+	MaxBackoff:   time.Hour * 6,
+	MaxAttempts:  10,
+	Timeout:      time.Second * 10,
+}
+
+// Dispatcher queues events for the webhooks of projects and delivers them
+// in the background. Queued deliveries survive restarts.
+type Dispatcher struct {
+	collections *c.Collections
+	conf        Config
+	client      *http.Client
+
+	lock    sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewDispatcher returns a dispatcher that isn't delivering yet, see Start.
+func NewDispatcher(collections *c.Collections, conf Config) *Dispatcher {
+	if conf.PollInterval == 0 {
+		conf.PollInterval = DefaultConfig.PollInterval
+	}
+	if conf.RetryBackoff == 0 {
+		conf.RetryBackoff = DefaultConfig.RetryBackoff
+	}
+	if conf.MaxBackoff == 0 {
+		conf.MaxBackoff = DefaultConfig.MaxBackoff
+	}
+	if conf.MaxAttempts == 0 {
+		conf.MaxAttempts = DefaultConfig.MaxAttempts
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = DefaultConfig.Timeout
+	}
+	d := &Dispatcher{
+		collections: collections,
+		conf:        conf,
+	}
+	// Addresses are checked when connecting, after DNS resolution, so a host
+	// can't pass CheckURL and then resolve to an internal address.
+	dialer := &net.Dialer{
+		Timeout:   conf.Timeout,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			return d.checkIP(net.ParseIP(host))
+		},
+	}
+	d.client = &http.Client{
+		Timeout: conf.Timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+	return d
+}
+
+// CheckURL returns ErrAddressNotAllowed if the host of u is or resolves to a
+// loopback, private, or link-local address.
+func (d *Dispatcher) CheckURL(ctx context.Context, u *url.URL) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err = d.checkIP(addr.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) checkIP(ip net.IP) error {
+	if d.conf.AllowPrivateAddrs {
+		return nil
+	}
+	if ip == nil || !publicIP(ip) {
+		return ErrAddressNotAllowed
+	}
+	return nil
+}
+
+// privateNets are not globally routable, see RFC 1122, RFC 1918, RFC 6598, and RFC 4193.
+var privateNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7",
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// publicIP returns whether or not ip is a globally routable unicast address.
+func publicIP(ip net.IP) bool {
+	// This excludes loopback, link-local, multicast, and unspecified addresses.
+	if !ip.IsGlobalUnicast() {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, n := range privateNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Start delivering queued events until Close is called.
+func (d *Dispatcher) Start() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.started {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d.started = true
+	d.cancel = cancel
+	d.done = make(chan struct{})
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(d.conf.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.DeliverDue(ctx)
+			}
+		}
+	}()
+}
+
+// Close stops delivering. Pending deliveries stay queued.
+func (d *Dispatcher) Close() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if !d.started {
+		return
+	}
+	d.cancel()
+	<-d.done
+	d.started = false
+}
+
+// Enqueue queues event with data for the webhooks of the project that subscribe to it.
+// A nil dispatcher drops all events.
+func (d *Dispatcher) Enqueue(ctx context.Context, projectID, event string, data interface{}) error {
+	if d == nil {
+		return nil
+	}
+	hooks, err := d.collections.Webhooks.List(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	var encoded []byte
+	for _, hook := range hooks {
+		if !hook.Subscribed(event) {
+			continue
+		}
+		if encoded == nil {
+			if encoded, err = json.Marshal(data); err != nil {
+				return err
+			}
+		}
+		if err = d.collections.WebhookDeliveries.Create(ctx, &c.WebhookDelivery{
+			WebhookID:   hook.ID,
+			URL:         hook.URL,
+			Secret:      hook.Secret,
+			Event:       event,
+			Data:        string(encoded),
+			NextAttempt: time.Now().Unix(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnqueueScope queues event with data for the webhooks of every project in scope,
+// i.e., a team or a user.
+func (d *Dispatcher) EnqueueScope(ctx context.Context, scope, event string, data interface{}) error {
+	if d == nil {
+		return nil
+	}
+	projs, err := d.collections.Projects.List(ctx, scope)
+	if err != nil {
+		return err
+	}
+	for _, proj := range projs {
+		if err = d.Enqueue(ctx, proj.ID, event, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send delivers event with data to hook right away, without retries, e.g.,
+// to test a webhook. Responses other than 2xx are errors.
+func (d *Dispatcher) Send(ctx context.Context, hook *c.Webhook, event string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return d.post(ctx, hook.URL, hook.Secret, uuid.New().String(), event, time.Now().Unix(), encoded)
+}
+
+// DeliverDue attempts each due delivery once. Delivered and expired deliveries
+// are removed from the queue, the others are rescheduled with backoff.
+func (d *Dispatcher) DeliverDue(ctx context.Context) {
+	due, err := d.collections.WebhookDeliveries.ListDue(ctx, time.Now().Unix())
+	if err != nil {
+		log.Errorf("listing due webhook deliveries: %v", err)
+		return
+	}
+	for _, dl := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		d.attempt(ctx, dl)
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, dl *c.WebhookDelivery) {
+	err := d.post(ctx, dl.URL, dl.Secret, dl.ID, dl.Event, dl.Created, []byte(dl.Data))
+	if err == nil {
+		if err = d.collections.WebhookDeliveries.Delete(ctx, dl.ID); err != nil {
+			log.Errorf("removing webhook delivery %s: %v", dl.ID, err)
+		}
+		return
+	}
+
+	dl.Attempts++
+	dl.LastError = err.Error()
+	if dl.Attempts >= d.conf.MaxAttempts {
+		log.Warnf("dropping %s delivery %s to webhook %s after %d attempts: %v",
+			dl.Event, dl.ID, dl.WebhookID, dl.Attempts, err)
+		if err = d.collections.WebhookDeliveries.Delete(ctx, dl.ID); err != nil {
+			log.Errorf("removing webhook delivery %s: %v", dl.ID, err)
+		}
+		return
+	}
+	dl.NextAttempt = time.Now().Add(d.backoff(dl.Attempts)).Unix()
+	if err = d.collections.WebhookDeliveries.Save(ctx, dl); err != nil {
+		log.Errorf("rescheduling webhook delivery %s: %v", dl.ID, err)
+	}
+}
+
+// backoff returns the delay before the next attempt after attempts failures.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.conf.RetryBackoff
+	for i := 1; i < attempts && delay < d.conf.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.conf.MaxBackoff {
+		delay = d.conf.MaxBackoff
+	}
+	return delay
+}
+
+// payload is the body of a delivery.
+type payload struct {
+	ID      string          `json:"id"`
+	Event   string          `json:"event"`
+	Created int64           `json:"created"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// post sends a signed delivery. Responses other than 2xx are errors.
+func (d *Dispatcher) post(ctx context.Context, url, secret, id, event string, created int64, data []byte) error {
+	body, err := json.Marshal(payload{ID: id, Event: event, Created: created, Data: data})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Textile-Webhooks")
+	req.Header.Set(SignatureHeader, Sign(secret, body))
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, id)
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(res.Body, 1<<16))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// Sign returns the signature of body keyed with secret, as sent in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret returns a random secret for signing deliveries.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
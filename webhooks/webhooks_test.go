@@ -0,0 +1,190 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	c "github.com/textileio/textile/collections"
+	"github.com/textileio/textile/collections/memory"
+)
+
+func TestDispatcher_DeliverDue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cols := memory.NewCollections(nil)
+	d := NewDispatcher(cols, Config{MaxAttempts: 2, AllowPrivateAddrs: true})
+
+	var code = http.StatusOK
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	hook, err := cols.Webhooks.Create(ctx, "proj", server.URL, "secret", []string{c.EventProjectRemove})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test unsubscribed event", func(t *testing.T) {
+		if err := d.Enqueue(ctx, "proj", c.EventAppUserRegister, nil); err != nil {
+			t.Fatal(err)
+		}
+		checkQueue(t, cols, 0)
+	})
+
+	t.Run("test deliver", func(t *testing.T) {
+		if err := d.Enqueue(ctx, "proj", c.EventProjectRemove, map[string]string{"project_id": "proj"}); err != nil {
+			t.Fatalf("enqueue should succeed: %v", err)
+		}
+		checkQueue(t, cols, 1)
+		d.DeliverDue(ctx)
+		checkQueue(t, cols, 0)
+
+		r := <-received
+		body := <-bodies
+		if r.Header.Get(SignatureHeader) != Sign(hook.Secret, body) {
+			t.Fatal("got bad signature")
+		}
+		if r.Header.Get(EventHeader) != c.EventProjectRemove {
+			t.Fatal("got bad event header")
+		}
+		var p payload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Fatal(err)
+		}
+		if p.Event != c.EventProjectRemove || string(p.Data) != `{"project_id":"proj"}` {
+			t.Fatalf("got bad payload %s", body)
+		}
+	})
+
+	t.Run("test retry and drop", func(t *testing.T) {
+		code = http.StatusInternalServerError
+		if err := d.Enqueue(ctx, "proj", c.EventProjectRemove, nil); err != nil {
+			t.Fatal(err)
+		}
+		d.DeliverDue(ctx)
+		<-received
+		<-bodies
+		list := checkQueue(t, cols, 1)
+		if list[0].Attempts != 1 || list[0].LastError == "" || list[0].NextAttempt <= time.Now().Unix() {
+			t.Fatal("failed delivery should be rescheduled")
+		}
+		d.DeliverDue(ctx)
+		if len(received) != 0 {
+			t.Fatal("delivery should not be attempted before its next attempt")
+		}
+
+		list[0].NextAttempt = 0
+		if err := cols.WebhookDeliveries.Save(ctx, list[0]); err != nil {
+			t.Fatal(err)
+		}
+		d.DeliverDue(ctx)
+		<-received
+		<-bodies
+		// The second failure reaches MaxAttempts.
+		checkQueue(t, cols, 0)
+	})
+}
+
+func TestDispatcher_backoff(t *testing.T) {
+	d := NewDispatcher(nil, Config{RetryBackoff: time.Second, MaxBackoff: time.Second * 5})
+	for attempts, want := range map[int]time.Duration{
+		1:  time.Second,
+		2:  time.Second * 2,
+		3:  time.Second * 4,
+		4:  time.Second * 5,
+		20: time.Second * 5,
+	} {
+		if got := d.backoff(attempts); got != want {
+			t.Fatalf("expected backoff %s after %d attempts, got %s", want, attempts, got)
+		}
+	}
+}
+
+func TestDispatcher_CheckURL(t *testing.T) {
+	t.Parallel()
+	d := NewDispatcher(nil, Config{})
+	for rawurl, allowed := range map[string]bool{
+		"https://93.184.216.34/hook":        true,
+		"https://[2606:4700::1111]/hook":    true,
+		"http://127.0.0.1:8080/hook":        false,
+		"http://localhost/hook":             false,
+		"http://10.1.2.3/hook":              false,
+		"http://172.16.0.1/hook":            false,
+		"http://192.168.1.1/hook":           false,
+		"http://100.64.0.1/hook":            false,
+		"http://169.254.169.254/latest":     false,
+		"http://0.0.0.0/hook":               false,
+		"http://[::1]/hook":                 false,
+		"http://[fd00::1]/hook":             false,
+		"http://[fe80::1]/hook":             false,
+		"http://[::ffff:127.0.0.1]/hook":    false,
+		"http://[::ffff:169.254.169.254]/x": false,
+	} {
+		u, err := url.Parse(rawurl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = d.CheckURL(context.Background(), u)
+		if allowed && err != nil {
+			t.Fatalf("expected %s to be allowed, got %v", rawurl, err)
+		}
+		if !allowed && !errors.Is(err, ErrAddressNotAllowed) {
+			t.Fatalf("expected %s to not be allowed, got %v", rawurl, err)
+		}
+	}
+}
+
+func TestDispatcher_Send(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	hook := &c.Webhook{URL: server.URL, Secret: "secret"}
+
+	t.Run("test send to private address", func(t *testing.T) {
+		d := NewDispatcher(nil, Config{})
+		if err := d.Send(context.Background(), hook, c.EventPing, nil); !errors.Is(err, ErrAddressNotAllowed) {
+			t.Fatalf("expected ErrAddressNotAllowed, got %v", err)
+		}
+	})
+
+	t.Run("test send with private addresses allowed", func(t *testing.T) {
+		d := NewDispatcher(nil, Config{AllowPrivateAddrs: true})
+		if err := d.Send(context.Background(), hook, c.EventPing, nil); err != nil {
+			t.Fatalf("send should succeed: %v", err)
+		}
+	})
+}
+
+func TestDispatcher_Nil(t *testing.T) {
+	var d *Dispatcher
+	if err := d.Enqueue(context.Background(), "proj", c.EventProjectRemove, nil); err != nil {
+		t.Fatal("nil dispatcher should drop events")
+	}
+}
+
+// checkQueue fails unless there are count deliveries in the queue, and returns them.
+func checkQueue(t *testing.T, cols *c.Collections, count int) []*c.WebhookDelivery {
+	list, err := cols.WebhookDeliveries.ListDue(context.Background(), time.Now().Add(time.Hour*24).Unix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != count {
+		t.Fatalf("expected %d queued deliveries, got %d", count, len(list))
+	}
+	return list
+}